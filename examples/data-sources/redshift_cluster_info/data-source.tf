@@ -0,0 +1,9 @@
+data "redshift_cluster_info" "current" {}
+
+output "redshift_version" {
+  value = data.redshift_cluster_info.current.redshift_version
+}
+
+output "is_serverless" {
+  value = data.redshift_cluster_info.current.serverless
+}
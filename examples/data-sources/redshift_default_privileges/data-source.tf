@@ -0,0 +1,10 @@
+data "redshift_default_privileges" "global" {
+  owner       = "root"
+  object_type = "table"
+}
+
+data "redshift_default_privileges" "analytics" {
+  owner       = "root"
+  schema      = "analytics"
+  object_type = "table"
+}
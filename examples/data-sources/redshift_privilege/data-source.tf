@@ -0,0 +1,10 @@
+data "redshift_privilege" "schema" {
+  schema = "reporting"
+  user   = "john"
+}
+
+data "redshift_privilege" "table" {
+  schema = "reporting"
+  object = "daily_sales"
+  group  = "analysts"
+}
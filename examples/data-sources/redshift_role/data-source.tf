@@ -0,0 +1,3 @@
+data "redshift_role" "analyst" {
+  name = "analyst"
+}
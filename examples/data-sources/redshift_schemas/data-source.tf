@@ -0,0 +1,12 @@
+data "redshift_schemas" "local" {
+  schema_type = "local"
+}
+
+resource "redshift_grant" "analysts_usage" {
+  for_each = toset([for s in data.redshift_schemas.local.schemas : s.name])
+
+  group       = "analysts"
+  schema      = each.value
+  object_type = "schema"
+  privileges  = ["usage"]
+}
@@ -0,0 +1,8 @@
+data "redshift_table_info" "events" {
+  schema = "analytics"
+  table  = "events"
+}
+
+output "events_needs_vacuum" {
+  value = data.redshift_table_info.events.unsorted_pct > 20
+}
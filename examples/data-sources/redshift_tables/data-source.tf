@@ -0,0 +1,14 @@
+data "redshift_tables" "analytics" {
+  schema     = "analytics"
+  table_type = "table"
+}
+
+resource "redshift_grant" "analysts_select" {
+  for_each = toset([for t in data.redshift_tables.analytics.tables : t.name])
+
+  group       = "analysts"
+  schema      = "analytics"
+  object_type = "table"
+  objects     = [each.value]
+  privileges  = ["select"]
+}
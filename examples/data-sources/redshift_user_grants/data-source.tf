@@ -0,0 +1,7 @@
+data "redshift_user_grants" "analyst" {
+  user = "analyst"
+}
+
+output "analyst_roles" {
+  value = data.redshift_user_grants.analyst.effective_roles
+}
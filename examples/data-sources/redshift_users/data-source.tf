@@ -0,0 +1,7 @@
+data "redshift_users" "superusers" {
+  superuser = true
+}
+
+data "redshift_users" "etl" {
+  name_regex = "^etl_[a-z]+$"
+}
@@ -0,0 +1,5 @@
+data "redshift_wlm_queues" "current" {}
+
+output "wlm_queue_names" {
+  value = [for queue in data.redshift_wlm_queues.current.queues : queue.name]
+}
@@ -0,0 +1,5 @@
+provider "redshift" {
+  # The username, the password, the host, the port and the database are read
+  # from the secret, e.g. the admin secret managed by Redshift.
+  secret_arn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:redshift!my-cluster-admin-AbCdEf"
+}
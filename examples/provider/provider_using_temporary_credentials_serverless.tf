@@ -0,0 +1,10 @@
+provider "redshift" {
+  # The endpoint of the workgroup, or of a VPC endpoint of the workgroup
+  host       = var.redshift_host
+  database   = "dev"
+  serverless = true
+  temporary_credentials {
+    workgroup_name = "my-workgroup"
+    region         = "us-east-1"
+  }
+}
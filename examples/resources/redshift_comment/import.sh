@@ -0,0 +1,5 @@
+# Import the comment of a table, view, schema or database with <object_type>:<object_name>
+terraform import redshift_comment.sales table:analytics.sales
+
+# Import the comment of a column with column:<schema>.<table>:<column>
+terraform import redshift_comment.sales_amount column:analytics.sales:amount
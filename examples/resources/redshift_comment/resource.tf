@@ -0,0 +1,12 @@
+resource "redshift_comment" "sales" {
+  object_type = "table"
+  object_name = "analytics.sales"
+  comment     = "One row per sale, loaded hourly"
+}
+
+resource "redshift_comment" "sales_amount" {
+  object_type = "column"
+  object_name = "analytics.sales"
+  column      = "amount"
+  comment     = "Amount of the sale in USD"
+}
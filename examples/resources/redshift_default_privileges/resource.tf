@@ -11,3 +11,11 @@ resource "redshift_default_privileges" "user" {
   object_type = "table"
   privileges  = ["select", "update", "insert", "delete", "drop", "references"]
 }
+
+resource "redshift_default_privileges" "role" {
+  role        = "analyst"
+  schema      = "my_schema"
+  owner       = "root"
+  object_type = "function"
+  privileges  = ["execute"]
+}
@@ -0,0 +1,2 @@
+# Import a partition with an ID <schema>.<table>/<column>=<value>, with a <column>=<value> pair for each partition column.
+terraform import 'redshift_external_partition.sales["2024-01-01"]' spectrum.sales/day=2024-01-01
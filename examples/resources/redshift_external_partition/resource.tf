@@ -0,0 +1,12 @@
+locals {
+  days = ["2024-01-01", "2024-01-02", "2024-01-03"]
+}
+
+resource "redshift_external_partition" "sales" {
+  for_each = toset(local.days)
+
+  schema   = "spectrum"
+  table    = "sales"
+  values   = { day = each.value }
+  location = "s3://my-bucket/sales/day=${each.value}/"
+}
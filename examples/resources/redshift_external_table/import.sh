@@ -0,0 +1,3 @@
+# Import an external table with an ID <schema>.<name>.
+# Partitions aren't imported, the partition blocks are added to the state on the next apply.
+terraform import redshift_external_table.sales spectrum.sales
@@ -0,0 +1,29 @@
+resource "redshift_external_table" "sales" {
+  name     = "sales"
+  schema   = "spectrum"
+  location = "s3://my-bucket/sales/"
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+
+  column {
+    name = "price"
+    type = "decimal(8,2)"
+  }
+
+  partition_by {
+    name = "day"
+    type = "date"
+  }
+
+  table_properties = {
+    "numRows" = "170000"
+  }
+
+  partition {
+    values   = { day = "2024-01-01" }
+    location = "s3://my-bucket/sales/day=2024-01-01/"
+  }
+}
@@ -0,0 +1,3 @@
+# Import function with its signature: <schema>.<name>(<argument type>,...)
+
+terraform import redshift_function.myfunction 'myschema.f_myfunction(integer,varchar)'
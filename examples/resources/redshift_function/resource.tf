@@ -0,0 +1,58 @@
+# SQL UDF
+resource "redshift_function" "sql" {
+  name       = "f_greater"
+  schema     = "analytics"
+  returns    = "float"
+  volatility = "STABLE"
+
+  argument {
+    type = "float"
+  }
+
+  argument {
+    type = "float"
+  }
+
+  body = <<-EOT
+    SELECT CASE WHEN $1 > $2 THEN $1 ELSE $2 END
+  EOT
+}
+
+# Python UDF
+resource "redshift_function" "python" {
+  name       = "f_py_greater"
+  schema     = "analytics"
+  returns    = "float"
+  language   = "plpythonu"
+  volatility = "STABLE"
+
+  argument {
+    name = "a"
+    type = "float"
+  }
+
+  argument {
+    name = "b"
+    type = "float"
+  }
+
+  body = <<-EOT
+    if a > b:
+      return a
+    return b
+  EOT
+}
+
+# Lambda UDF
+resource "redshift_function" "lambda" {
+  name            = "f_lambda_upper"
+  schema          = "analytics"
+  returns         = "varchar"
+  language        = "lambda"
+  lambda_function = "redshift-upper"
+  iam_role        = "arn:aws:iam::123456789012:role/RedshiftLambdaRole"
+
+  argument {
+    type = "varchar"
+  }
+}
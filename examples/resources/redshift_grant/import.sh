@@ -0,0 +1,11 @@
+# Import grant with an ID <grantee_type>:<grantee>:<object_type>:<schema>:<object>.
+# The grantee type is one of user, group or role, use group:public for grants to PUBLIC.
+# Leave the schema empty for databases and languages, and the object empty for databases, schemas or all objects of a type.
+# Multiple objects are separated with commas. Append :<database> for grants in another database.
+# Functions and procedures are imported with their signature, and column-level grants with the columns of the table in parentheses.
+
+terraform import redshift_grant.grant user:alice:table:myschema:mytable
+terraform import redshift_grant.schema_to_public group:public:schema:myschema:
+terraform import redshift_grant.other_database user:alice:table:myschema:mytable:otherdb
+terraform import redshift_grant.function user:alice:function:myschema:my_function(integer,varchar)
+terraform import redshift_grant.columns user:alice:table:myschema:mytable(id,name)
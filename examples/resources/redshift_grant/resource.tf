@@ -12,6 +12,22 @@ resource "redshift_grant" "group" {
   privileges  = ["usage"]
 }
 
+resource "redshift_grant" "role" {
+  role        = "analyst"
+  schema      = "my_schema"
+  object_type = "table"
+  objects     = ["my_table"]
+  privileges  = ["select"]
+}
+
+# Privileges on all the tables currently in the schema (GRANT ... ON ALL TABLES IN SCHEMA)
+resource "redshift_grant" "all_tables" {
+  group       = "analysts"
+  schema      = "my_schema"
+  object_type = "table"
+  privileges  = ["select"]
+}
+
 # Granting permissions to execute functions or procedures requires providing their arguments' types
 resource "redshift_grant" "user" {
   user        = "john"
@@ -21,6 +37,25 @@ resource "redshift_grant" "user" {
   privileges  = ["execute"]
 }
 
+# The arguments' types can also be provided separately
+resource "redshift_grant" "procedure" {
+  user           = "john"
+  schema         = "my_schema"
+  object_type    = "procedure"
+  objects        = ["my_procedure"]
+  argument_types = ["integer", "varchar"]
+  privileges     = ["execute"]
+}
+
+# Execution of all the procedures currently in the schema (GRANT EXECUTE ON ALL PROCEDURES IN SCHEMA).
+# Use redshift_default_privileges to cover the procedures created later.
+resource "redshift_grant" "all_procedures" {
+  group       = "analysts"
+  schema      = "my_schema"
+  object_type = "procedure"
+  privileges  = ["execute"]
+}
+
 # Granting permission to PUBLIC (GRANT ... TO PUBLIC)
 resource "redshift_grant" "public" {
   group       = "public" // "public" or "PUBLIC" (it is case insensitive for this case) here indicates we want grant TO PUBLIC, not "public" group which cannot even be created in Redshift (keyword).
@@ -28,3 +63,38 @@ resource "redshift_grant" "public" {
   object_type = "schema"
   privileges  = ["usage"]
 }
+
+resource "redshift_grant" "user_with_grant_option" {
+  user              = "john"
+  schema            = "my_schema"
+  object_type       = "table"
+  objects           = ["my_table"]
+  privileges        = ["select"]
+  with_grant_option = true
+}
+
+# Column-level privileges (GRANT SELECT (col1, col2) ON TABLE ...)
+resource "redshift_grant" "columns" {
+  group       = "analysts"
+  schema      = "my_schema"
+  object_type = "table"
+  objects     = ["my_table"]
+  columns     = ["id", "name"]
+  privileges  = ["select"]
+}
+
+# Privileges on objects in another database (requires RA3 node types or Redshift Serverless)
+resource "redshift_grant" "other_database" {
+  database    = "other_db"
+  group       = "analysts"
+  schema      = "my_schema"
+  object_type = "table"
+  privileges  = ["select"]
+}
+
+# Allowing a user to assume an IAM role in COPY and UNLOAD (GRANT ASSUMEROLE ON '<arn>' TO ... FOR ...)
+resource "redshift_grant" "assume_role" {
+  user            = "john"
+  assume_role_arn = "arn:aws:iam::123456789012:role/RedshiftCopyUnload"
+  for             = ["COPY", "UNLOAD"]
+}
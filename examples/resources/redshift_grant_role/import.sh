@@ -0,0 +1,4 @@
+# Import a role grant with an ID <role>:user:<user> or <role>:role:<role>.
+
+terraform import redshift_grant_role.analyst_to_reporting analyst:role:reporting
+terraform import redshift_grant_role.analyst_to_john analyst:user:john
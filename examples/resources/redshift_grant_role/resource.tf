@@ -0,0 +1,10 @@
+resource "redshift_grant_role" "analyst_to_reporting" {
+  role    = "analyst"
+  to_role = "reporting"
+}
+
+resource "redshift_grant_role" "analyst_to_john" {
+  role         = "analyst"
+  to_user      = "john"
+  admin_option = true
+}
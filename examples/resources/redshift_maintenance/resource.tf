@@ -0,0 +1,10 @@
+# Sorts the table and refreshes its statistics after every load
+resource "redshift_maintenance" "events" {
+  tables  = ["analytics.events"]
+  vacuum  = "SORT ONLY"
+  analyze = true
+
+  triggers = {
+    loaded_at = var.events_loaded_at
+  }
+}
@@ -0,0 +1,3 @@
+# Import materialized view with oid: SELECT oid FROM pg_class WHERE relname = 'mymaterializedview' AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = 'myschema');
+
+terraform import redshift_materialized_view.mymaterializedview 123456
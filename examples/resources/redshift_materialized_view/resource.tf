@@ -0,0 +1,11 @@
+resource "redshift_materialized_view" "daily_sales" {
+  name             = "daily_sales"
+  schema           = "analytics"
+  auto_refresh     = true
+  refresh_on_apply = true
+  query            = <<-EOT
+    SELECT sale_date, sum(amount) AS total
+    FROM analytics.sales
+    GROUP BY sale_date
+  EOT
+}
@@ -0,0 +1,15 @@
+resource "redshift_role" "analyst" {
+  name = "analyst"
+}
+
+resource "redshift_role" "senior_analyst" {
+  name = "senior_analyst"
+  roles = [
+    redshift_role.analyst.name,
+  ]
+}
+
+resource "redshift_role" "user_admin" {
+  name              = "user_admin"
+  system_privileges = ["CREATE USER", "ALTER USER", "DROP USER"]
+}
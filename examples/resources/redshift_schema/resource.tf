@@ -9,6 +9,13 @@ resource "redshift_schema" "schema" {
   quota = 150
 }
 
+# Internal schema with a quota expressed in MB
+resource "redshift_schema" "small_schema" {
+  name       = "my_small_schema"
+  quota      = 512
+  quota_unit = "MB"
+}
+
 # External schema using AWS Glue Data Catalog
 resource "redshift_schema" "external_from_glue_data_catalog" {
   name = "spectrum_schema"
@@ -0,0 +1,2 @@
+# Import the privileges of a schema with an ID <database>.<schema>.
+terraform import redshift_schema_grants.analytics mydb.analytics
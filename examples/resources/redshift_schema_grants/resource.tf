@@ -0,0 +1,17 @@
+resource "redshift_schema_grants" "analytics" {
+  schema = "analytics"
+
+  grant {
+    grantee_type      = "group"
+    grantee           = "analysts"
+    schema_privileges = ["usage"]
+    table_privileges  = ["select"]
+  }
+
+  grant {
+    grantee_type      = "user"
+    grantee           = "etl"
+    schema_privileges = ["usage", "create"]
+    table_privileges  = ["select", "insert", "update", "delete"]
+  }
+}
@@ -0,0 +1,3 @@
+# Import stored procedure with oid: SELECT prooid FROM pg_proc_info WHERE proname = 'myprocedure' AND prokind = 'p' AND pronamespace = (SELECT oid FROM pg_namespace WHERE nspname = 'myschema');
+
+terraform import redshift_stored_procedure.myprocedure 123456
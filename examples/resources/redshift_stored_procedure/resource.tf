@@ -0,0 +1,24 @@
+resource "redshift_stored_procedure" "procedure" {
+  name   = "refresh_sales"
+  schema = "analytics"
+
+  argument {
+    name = "since"
+    type = "date"
+  }
+
+  argument {
+    name = "refreshed"
+    type = "integer"
+    mode = "OUT"
+  }
+
+  security = "DEFINER"
+  body     = <<-EOT
+    BEGIN
+      DELETE FROM analytics.sales_summary WHERE day >= since;
+      INSERT INTO analytics.sales_summary SELECT day, sum(amount) FROM analytics.sales WHERE day >= since GROUP BY day;
+      GET DIAGNOSTICS refreshed := ROW_COUNT;
+    END;
+  EOT
+}
@@ -0,0 +1,3 @@
+# Import table with oid: SELECT oid FROM pg_class WHERE relname = 'mytable' AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = 'myschema');
+
+terraform import redshift_table.mytable 123456
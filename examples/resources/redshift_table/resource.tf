@@ -0,0 +1,46 @@
+resource "redshift_table" "events" {
+  name      = "events"
+  schema    = "analytics"
+  diststyle = "KEY"
+  distkey   = "user_id"
+  sortkey   = ["created_at"]
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    encoding = "az64"
+    nullable = false
+
+    identity {
+      seed = 1
+      step = 1
+    }
+  }
+
+  column {
+    name = "user_id"
+    type = "integer"
+  }
+
+  column {
+    name     = "event_type"
+    type     = "varchar(64)"
+    encoding = "zstd"
+    default  = "'unknown'"
+  }
+
+  column {
+    name = "created_at"
+    type = "timestamp"
+  }
+
+  primary_key {
+    columns = ["id"]
+  }
+
+  foreign_key {
+    columns            = ["user_id"]
+    references_table   = "users"
+    references_columns = ["id"]
+  }
+}
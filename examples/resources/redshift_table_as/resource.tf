@@ -0,0 +1,13 @@
+resource "redshift_table_as" "sales_by_region" {
+  name   = "sales_by_region"
+  schema = "analytics"
+  query  = <<-EOT
+    SELECT region, sum(amount) AS total
+    FROM analytics.sales
+    GROUP BY region
+  EOT
+
+  refresh_triggers = {
+    last_load = var.sales_last_load
+  }
+}
@@ -8,3 +8,41 @@ resource "redshift_user" "user_with_unrestricted_syslog" {
   name          = "user_syslog"
   syslog_access = "UNRESTRICTED"
 }
+
+# The MD5 hash of the password concatenated with the user name
+resource "redshift_user" "user_with_password_hash" {
+  name          = "user_hash"
+  password_hash = "md5153c4d4e8b2c5b0e6e3c0e8a8d9e1f2a"
+}
+
+resource "redshift_user" "etl" {
+  name = "etl"
+
+  parameters = {
+    statement_timeout = "3600000"
+    query_group       = "etl"
+  }
+}
+
+resource "redshift_user" "analyst" {
+  name        = "analyst"
+  search_path = ["$user", "analytics", "public"]
+}
+
+# The objects owned by the user are transferred to the etl user when it's dropped
+resource "redshift_user" "contractor" {
+  name              = "contractor"
+  reassign_owned_to = redshift_user.etl.name
+}
+
+# A user provisioned by the identity provider, whose password is never managed
+resource "redshift_user" "sso_user" {
+  name     = "IAMR:jane@example.com"
+  external = true
+}
+
+# An existing user is adopted into the state instead of failing to create it
+resource "redshift_user" "legacy" {
+  name           = "legacy"
+  adopt_existing = true
+}
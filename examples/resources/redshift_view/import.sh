@@ -0,0 +1,3 @@
+# Import view with oid: SELECT oid FROM pg_class WHERE relname = 'myview' AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = 'myschema');
+
+terraform import redshift_view.myview 123456
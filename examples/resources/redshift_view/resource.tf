@@ -0,0 +1,17 @@
+resource "redshift_view" "active_users" {
+  name   = "active_users"
+  schema = "analytics"
+  query  = <<-EOT
+    SELECT id, name
+    FROM analytics.users
+    WHERE active
+  EOT
+}
+
+# Late-binding view, which isn't bound to the referenced table
+resource "redshift_view" "late_binding" {
+  name                   = "events_summary"
+  schema                 = "analytics"
+  with_no_schema_binding = true
+  query                  = "SELECT event_type, count(*) FROM analytics.events GROUP BY 1"
+}
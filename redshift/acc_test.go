@@ -7,6 +7,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // Get the value of an environment variable, or skip the
@@ -25,3 +28,34 @@ func tfArray(s []string) string {
 	tokens := strings.Split(semiformat, " ")
 	return fmt.Sprintf(strings.Join(tokens, ","))
 }
+
+// Stores the ID of a resource, to compare it in a later step
+// with testAccCheckResourceID.
+func testAccStoreResourceID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", resourceName)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// Checks whether a resource kept the ID stored by testAccStoreResourceID,
+// or was recreated with a new one.
+func testAccCheckResourceID(resourceName string, id *string, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", resourceName)
+		}
+		if same && rs.Primary.ID != *id {
+			return fmt.Errorf("Resource %s was recreated: id changed from %s to %s", resourceName, *id, rs.Primary.ID)
+		}
+		if !same && rs.Primary.ID == *id {
+			return fmt.Errorf("Resource %s was not recreated: id is still %s", resourceName, *id)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,181 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterFeature is a feature not all clusters support, detected from the
+// system view coming with it or, for the statements coming without one, from
+// whether the cluster can parse a probe statement.
+type clusterFeature struct {
+	name string
+	view string
+
+	// probe is run when view is empty. It must fail on a missing schema when
+	// the feature is supported, and with a syntax error otherwise, so that it
+	// never changes anything.
+	probe string
+
+	// requirement tells what the cluster needs to support the feature.
+	requirement string
+}
+
+var (
+	clusterFeatureRoles = clusterFeature{
+		name:        "roles",
+		view:        "svv_roles",
+		requirement: "Role-based access control requires a Redshift release from 2022 or later.",
+	}
+	clusterFeatureDatashares = clusterFeature{
+		name:        "data sharing",
+		view:        "svv_datashares",
+		requirement: "Data sharing requires RA3 node types or Redshift Serverless.",
+	}
+	clusterFeatureAlterExternalSchema = clusterFeature{
+		name:        "altering external schemas",
+		probe:       `ALTER EXTERNAL SCHEMA "tf_redshift_capability_probe" IAM_ROLE default`,
+		requirement: "ALTER EXTERNAL SCHEMA requires a Redshift release from 2024 or later.",
+	}
+)
+
+// key identifies the feature in the cache of the capabilities.
+func (f clusterFeature) key() string {
+	if f.view != "" {
+		return f.view
+	}
+	return f.probe
+}
+
+// clusterCapabilities caches the features supported by the cluster, so that
+// they're only detected once per connection pool.
+type clusterCapabilities struct {
+	mutex     sync.Mutex
+	supported map[string]bool
+
+	// privilegesVisible caches whether the provider's user sees the
+	// privileges of every identity in the SVV_*_PRIVILEGES views.
+	privilegesVisible *bool
+}
+
+func newClusterCapabilities() *clusterCapabilities {
+	return &clusterCapabilities{supported: map[string]bool{}}
+}
+
+// supports reports whether the cluster supports the feature. Its system view
+// being missing means it doesn't, while lacking privileges on it still proves
+// that it exists.
+func (db *DBConnection) supports(feature clusterFeature) (bool, error) {
+	if db.capabilities == nil {
+		db.capabilities = newClusterCapabilities()
+	}
+	db.capabilities.mutex.Lock()
+	defer db.capabilities.mutex.Unlock()
+
+	if supported, ok := db.capabilities.supported[feature.key()]; ok {
+		return supported, nil
+	}
+
+	if feature.view == "" {
+		_, err := db.Exec(feature.probe)
+		switch {
+		case err == nil, isPqErrorWithCode(err, pqErrorCodeInvalidSchemaName):
+			db.capabilities.supported[feature.key()] = true
+		case isPqErrorWithCode(err, pqErrorCodeSyntaxError):
+			log.Printf("[DEBUG] the cluster can't parse %s, it doesn't support %s", feature.probe, feature.name)
+			db.capabilities.supported[feature.key()] = false
+		default:
+			return false, fmt.Errorf("could not check whether the cluster supports %s: %w", feature.name, err)
+		}
+		return db.capabilities.supported[feature.key()], nil
+	}
+
+	var one int
+	err := db.QueryRow(fmt.Sprintf("SELECT 1 FROM %s LIMIT 1", feature.view)).Scan(&one)
+	switch {
+	case err == nil, err == sql.ErrNoRows, isPqErrorWithCode(err, pgErrorCodeInsufficientPrivileges):
+		db.capabilities.supported[feature.view] = true
+	case isPqErrorWithCode(err, pqErrorCodeUndefinedTable):
+		log.Printf("[DEBUG] %s doesn't exist, the cluster doesn't support %s", feature.view, feature.name)
+		db.capabilities.supported[feature.view] = false
+	default:
+		return false, fmt.Errorf("could not check whether the cluster supports %s: %w", feature.name, err)
+	}
+
+	return db.capabilities.supported[feature.view], nil
+}
+
+// seesAllPrivileges reports whether the SVV_*_PRIVILEGES views show the
+// provider's user the privileges of every identity. Other users only see their
+// own privileges and the ones of their roles: the views don't fail for them,
+// they return fewer rows.
+func (db *DBConnection) seesAllPrivileges() (bool, error) {
+	if db.capabilities == nil {
+		db.capabilities = newClusterCapabilities()
+	}
+	db.capabilities.mutex.Lock()
+	cached := db.capabilities.privilegesVisible
+	db.capabilities.mutex.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	var visible bool
+	if err := db.QueryRow("SELECT usesuper FROM pg_user WHERE usename = current_user").Scan(&visible); err != nil {
+		return false, fmt.Errorf("could not check whether the provider's user is a superuser: %w", err)
+	}
+
+	supportsRoles, err := db.supports(clusterFeatureRoles)
+	if err != nil {
+		return false, err
+	}
+	if !visible && supportsRoles {
+		var one int
+		err := db.QueryRow(`
+  SELECT 1
+  FROM svv_system_privileges
+  WHERE system_privilege = 'ACCESS SYSTEM TABLE'
+    AND ((identity_type = 'user' AND identity_name = current_user)
+      OR (identity_type = 'role' AND identity_name IN (SELECT role_name FROM svv_user_grants WHERE user_name = current_user)))
+  LIMIT 1
+`).Scan(&one)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return false, fmt.Errorf("could not check whether the provider's user can access system tables: %w", err)
+		default:
+			visible = true
+		}
+	}
+
+	if !visible {
+		log.Printf("[DEBUG] the provider's user is neither a superuser nor granted ACCESS SYSTEM TABLE, it only sees its own privileges")
+	}
+
+	db.capabilities.mutex.Lock()
+	db.capabilities.privilegesVisible = &visible
+	db.capabilities.mutex.Unlock()
+
+	return visible, nil
+}
+
+// RedshiftResourceRequireFeature fails with an explanation when the cluster
+// doesn't support the feature, rather than with the error of the first
+// statement needing it.
+func RedshiftResourceRequireFeature(feature clusterFeature, name string, fn func(*DBConnection, *schema.ResourceData) error) func(*DBConnection, *schema.ResourceData) error {
+	return func(db *DBConnection, d *schema.ResourceData) error {
+		supported, err := db.supports(feature)
+		if err != nil {
+			return err
+		}
+		if !supported {
+			return fmt.Errorf("%s can't be used as the cluster doesn't support %s. %s", name, feature.name, feature.requirement)
+		}
+
+		return fn(db, d)
+	}
+}
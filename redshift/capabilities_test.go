@@ -0,0 +1,95 @@
+package redshift
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+func TestRedshiftResourceRequireFeature(t *testing.T) {
+	db := &DBConnection{
+		capabilities: &clusterCapabilities{supported: map[string]bool{
+			clusterFeatureRoles.view:      true,
+			clusterFeatureDatashares.view: false,
+		}},
+	}
+	called := false
+	fn := func(*DBConnection, *schema.ResourceData) error {
+		called = true
+		return nil
+	}
+
+	if err := RedshiftResourceRequireFeature(clusterFeatureRoles, "redshift_role", fn)(db, nil); err != nil || !called {
+		t.Errorf("Expected the function to be called for a supported feature, got %v", err)
+	}
+
+	called = false
+	err := RedshiftResourceRequireFeature(clusterFeatureDatashares, "redshift_datashare", fn)(db, nil)
+	if called {
+		t.Errorf("Expected the function not to be called for an unsupported feature")
+	}
+	if err == nil || !strings.Contains(err.Error(), "redshift_datashare can't be used as the cluster doesn't support data sharing") {
+		t.Errorf("Expected an error naming the resource and the feature, got %v", err)
+	}
+}
+
+func TestSupportsProbe(t *testing.T) {
+	tests := map[string]struct {
+		execErr   error
+		supported bool
+		err       bool
+	}{
+		"parsed": {
+			execErr:   nil,
+			supported: true,
+		},
+		"missing schema": {
+			execErr:   &pq.Error{Code: pqErrorCodeInvalidSchemaName},
+			supported: true,
+		},
+		"syntax error": {
+			execErr:   &pq.Error{Code: pqErrorCodeSyntaxError},
+			supported: false,
+		},
+		"other error": {
+			execErr: &pq.Error{Code: pgErrorCodeInsufficientPrivileges},
+			err:     true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var statements []string
+			db := &DBConnection{DB: sql.OpenDB(fakeConnector{statements: &statements, execErr: tt.execErr})}
+			defer db.Close()
+
+			for i := 0; i < 2; i++ {
+				supported, err := db.supports(clusterFeatureAlterExternalSchema)
+				if tt.err {
+					if err == nil {
+						t.Fatalf("Expected an error")
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if supported != tt.supported {
+					t.Errorf("Expected supported to be %t, got %t", tt.supported, supported)
+				}
+			}
+
+			// Errors aren't cached, so that the probe runs again.
+			expected := 1
+			if tt.err {
+				expected = 2
+			}
+			if len(statements) != expected || statements[0] != clusterFeatureAlterExternalSchema.probe {
+				t.Errorf("Expected the probe to run %d times, got %v", expected, statements)
+			}
+		})
+	}
+}
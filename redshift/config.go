@@ -1,15 +1,24 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
+// temporaryCredentialsRefreshMargin is how long before their expiration the
+// temporary credentials are refreshed, so that running operations can finish
+// with the connections opened using the previous password.
+const temporaryCredentialsRefreshMargin = 5 * time.Minute
+
 var (
 	dbRegistryLock sync.Mutex
 	dbRegistry     = make(map[string]*DBConnection, 1)
@@ -17,19 +26,107 @@ var (
 
 // Config - provider config
 type Config struct {
-	Host     string
-	Username string
-	Password string
-	Port     int
-	Database string
-	SSLMode  string
-	MaxConns int
+	Host        string
+	Username    string
+	Password    string
+	Port        int
+	Database    string
+	SSLMode     string
+	SSLRootCert string
+	MaxConns    int
+
+	// MaxIdleConns is the number of idle connections kept open to the database
+	// the provider connects to. ConnMaxLifetime is how long a connection may be
+	// reused, zero meaning forever.
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// StatementTimeout is the statement_timeout in milliseconds set on every
+	// session. Zero leaves the default of the cluster.
+	StatementTimeout int
+
+	// Timezone and ClientEncoding are the TimeZone and client_encoding set on
+	// every session. Empty keeps the defaults of the user or the cluster.
+	Timezone       string
+	ClientEncoding string
+
+	// QueryGroup is the WLM query group every session is assigned to, so that
+	// the statements run in its queue. Empty keeps the default queue.
+	QueryGroup string
+
+	// SearchPath is the schemas every session looks objects referenced without
+	// a schema up in, in order. Empty keeps the search_path of the user.
+	SearchPath []string
+
+	// AssumeUser is the user every session sets its authorization to, so that
+	// the statements run as that user. Empty keeps the connecting user.
+	AssumeUser string
+
+	// Tunnel forwards the connections through a bastion host when it isn't nil.
+	Tunnel *sshTunnel
+
+	MaxConnectionRetries int
+	ConnectionRetryDelay time.Duration
+
+	// WaitForCluster makes the operations wait up to WaitForClusterTimeout
+	// for a paused or resuming cluster to accept connections, instead of
+	// failing after MaxConnectionRetries.
+	WaitForCluster        bool
+	WaitForClusterTimeout time.Duration
+
+	// ValidateReferences makes the resources check when planning that the
+	// schemas they reference exist.
+	ValidateReferences bool
+
+	// credentials caches the temporary credentials, when they're used. It's
+	// shared by the copies of the config of all the clients.
+	credentials *credentialsCache
 
 	serverlessCheckMutex *sync.Mutex
 	isServerless         bool
 	checkedForServerless bool
 }
 
+// credentialsCache holds the temporary credentials shared by all the
+// resources, so that they're only requested again shortly before they expire,
+// once for all the operations running in parallel.
+type credentialsCache struct {
+	mu         sync.Mutex
+	username   string
+	password   string
+	expiration time.Time
+	fetch      func() (string, string, time.Time, error)
+}
+
+func newCredentialsCache(username, password string, expiration time.Time, fetch func() (string, string, time.Time, error)) *credentialsCache {
+	return &credentialsCache{
+		username:   username,
+		password:   password,
+		expiration: expiration,
+		fetch:      fetch,
+	}
+}
+
+// get returns the cached credentials, fetching new ones first when they expire
+// in less than temporaryCredentialsRefreshMargin.
+func (c *credentialsCache) get() (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Until(c.expiration) > temporaryCredentialsRefreshMargin {
+		return c.username, c.password, nil
+	}
+
+	log.Printf("[DEBUG] refreshing temporary credentials expiring at %s", c.expiration)
+	username, password, expiration, err := c.fetch()
+	if err != nil {
+		return "", "", fmt.Errorf("Error refreshing temporary credentials: %w", err)
+	}
+	c.username, c.password, c.expiration = username, password, expiration
+
+	return username, password, nil
+}
+
 // Client struct holding connection string
 type Client struct {
 	config       Config
@@ -42,6 +139,108 @@ type DBConnection struct {
 	*sql.DB
 
 	client *Client
+
+	// capabilities caches the features the cluster supports.
+	capabilities *clusterCapabilities
+
+	// ctx is the context of the Terraform operation using the connection.
+	// The statements are canceled when it is done.
+	ctx context.Context
+}
+
+// withContext returns a copy of the connection running its statements with ctx.
+func (db *DBConnection) withContext(ctx context.Context) *DBConnection {
+	return &DBConnection{
+		DB:           db.DB,
+		client:       db.client,
+		capabilities: db.capabilities,
+		ctx:          ctx,
+	}
+}
+
+func (db *DBConnection) context() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
+// Exec runs the statement with the context of the connection.
+func (db *DBConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	result, err := db.DB.ExecContext(db.context(), query, args...)
+	return result, newStatementError(err, query)
+}
+
+// Query runs the query with the context of the connection.
+func (db *DBConnection) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := db.DB.QueryContext(db.context(), query, args...)
+	return rows, newStatementError(err, query)
+}
+
+// QueryRow runs the query with the context of the connection.
+func (db *DBConnection) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRowContext(db.context(), query, args...)
+}
+
+// Begin starts a transaction running its statements with the context of the
+// connection.
+func (db *DBConnection) Begin() (*DBTransaction, error) {
+	ctx := db.context()
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBTransaction{tx, ctx}, nil
+}
+
+// WithTx runs fn in a transaction, committed when fn succeeds and rolled back
+// otherwise, so that a failing statement doesn't leave the ones before it
+// applied. Redshift doesn't allow some statements in a transaction block, e.g.
+// CREATE DATABASE or the DDL of external tables, so they must run on db itself.
+func (db *DBConnection) WithTx(fn func(tx *DBTransaction) error) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := fn(tx); err != nil {
+		var stmtErr *statementError
+		if errors.As(err, &stmtErr) {
+			return fmt.Errorf("%w, the transaction was rolled back after the failing statement: %s", err, stmtErr.Statement())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+type DBTransaction struct {
+	*sql.Tx
+
+	ctx context.Context
+}
+
+// Exec runs the statement with the context of the transaction.
+func (tx *DBTransaction) Exec(query string, args ...interface{}) (sql.Result, error) {
+	result, err := tx.Tx.ExecContext(tx.ctx, query, args...)
+	return result, newStatementError(err, query)
+}
+
+// Query runs the query with the context of the transaction.
+func (tx *DBTransaction) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := tx.Tx.QueryContext(tx.ctx, query, args...)
+	return rows, newStatementError(err, query)
+}
+
+// QueryRow runs the query with the context of the transaction.
+func (tx *DBTransaction) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRowContext(tx.ctx, query, args...)
 }
 
 // NewClient returns client config for the specified database.
@@ -87,10 +286,38 @@ func (c *Client) Connect() (*DBConnection, error) {
 	dbRegistryLock.Lock()
 	defer dbRegistryLock.Unlock()
 
-	dsn := c.config.connStr(c.databaseName)
-	conn, found := dbRegistry[dsn]
+	if err := c.refreshExpiredCredentials(); err != nil {
+		return nil, err
+	}
+
+	// The password isn't part of the key, as it changes when the temporary
+	// credentials are refreshed while the pool keeps being used: it connects
+	// with the current ones. The tunnel and the session settings aren't part
+	// of the DSN, so pools must be told apart by them.
+	keyConfig := c.config
+	keyConfig.Password = ""
+	key := keyConfig.connStr(c.databaseName)
+	if c.config.Tunnel != nil {
+		key = fmt.Sprintf("%s#bastion=%s", key, c.config.Tunnel.address)
+	}
+	if c.config.Timezone != "" {
+		key = fmt.Sprintf("%s#timezone=%s", key, c.config.Timezone)
+	}
+	if c.config.ClientEncoding != "" {
+		key = fmt.Sprintf("%s#client_encoding=%s", key, c.config.ClientEncoding)
+	}
+	if c.config.QueryGroup != "" {
+		key = fmt.Sprintf("%s#query_group=%s", key, c.config.QueryGroup)
+	}
+	if len(c.config.SearchPath) > 0 {
+		key = fmt.Sprintf("%s#search_path=%s", key, searchPathSQL(c.config.SearchPath))
+	}
+	if c.config.AssumeUser != "" {
+		key = fmt.Sprintf("%s#%s", key, c.config.AssumeUser)
+	}
+	conn, found := dbRegistry[key]
 	if !found {
-		db, err := sql.Open(proxyDriverName, dsn)
+		db, err := openDB(&c.config, c.databaseName)
 		if err != nil {
 			return nil, fmt.Errorf("Error connecting to PostgreSQL server %s: %w", c.config.Host, err)
 		}
@@ -98,19 +325,58 @@ func (c *Client) Connect() (*DBConnection, error) {
 		// We don't want to retain connection
 		// So when we connect on a specific database which might be managed by terraform,
 		// we don't keep opened connection in case of the db has to be dopped in the plan.
-		db.SetMaxIdleConns(0)
+		maxIdleConns := 0
+		if c.databaseName == c.config.Database {
+			maxIdleConns = c.config.MaxIdleConns
+		}
+		db.SetMaxIdleConns(maxIdleConns)
 		db.SetMaxOpenConns(c.config.MaxConns)
+		db.SetConnMaxLifetime(c.config.ConnMaxLifetime)
 
 		conn = &DBConnection{
-			db,
-			c,
+			DB:           db,
+			client:       c,
+			capabilities: newClusterCapabilities(),
 		}
-		dbRegistry[dsn] = conn
+		dbRegistry[key] = conn
 	}
 
 	return conn, nil
 }
 
+// refreshExpiredCredentials connects with the cached temporary credentials,
+// which are refreshed when they're about to expire.
+func (c *Client) refreshExpiredCredentials() error {
+	if c.config.credentials == nil {
+		return nil
+	}
+
+	username, password, err := c.config.credentials.get()
+	if err != nil {
+		return err
+	}
+	c.config.Username, c.config.Password = username, password
+
+	return nil
+}
+
+// currentConnStr returns the DSN with the current temporary credentials when
+// the config has some, refreshing them when they're about to expire.
+func (c *Config) currentConnStr(database string) (string, error) {
+	if c.credentials == nil {
+		return c.connStr(database), nil
+	}
+
+	username, password, err := c.credentials.get()
+	if err != nil {
+		return "", err
+	}
+	config := *c
+	config.Username, config.Password = username, password
+
+	return config.connStr(database), nil
+}
+
 func (c *Config) connStr(database string) string {
 	connStr := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?%s",
@@ -125,18 +391,16 @@ func (c *Config) connStr(database string) string {
 	return connStr
 }
 
+// connParams returns the parameters of the DSN in a fixed order, so that the
+// same configuration always gives the same DSN.
 func (c *Config) connParams() []string {
-	params := map[string]string{}
-
-	params["sslmode"] = c.SSLMode
-	params["connect_timeout"] = "180"
-
-	paramsArray := []string{}
-	for key, value := range params {
-		paramsArray = append(paramsArray, fmt.Sprintf("%s=%s", key, url.QueryEscape(value)))
+	params := []string{fmt.Sprintf("sslmode=%s", url.QueryEscape(c.SSLMode))}
+	if c.SSLRootCert != "" {
+		params = append(params, fmt.Sprintf("sslrootcert=%s", url.QueryEscape(c.SSLRootCert)))
 	}
+	params = append(params, "connect_timeout=180")
 
-	return paramsArray
+	return params
 }
 
 // New redshift client
@@ -149,6 +413,9 @@ func (c *Config) Client() (*Client, error) {
 		c.Host,
 		c.Port,
 		c.Database)
+	if c.SSLRootCert != "" {
+		conninfo = fmt.Sprintf("%s sslrootcert=%v", conninfo, c.SSLRootCert)
+	}
 
 	db, err := sql.Open(proxyDriverName, conninfo)
 	if err != nil {
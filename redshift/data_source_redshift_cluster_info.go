@@ -0,0 +1,147 @@
+package redshift
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	clusterInfoVersionAttr         = "version"
+	clusterInfoRedshiftVersionAttr = "redshift_version"
+	clusterInfoMajorVersionAttr    = "major_version"
+	clusterInfoMinorVersionAttr    = "minor_version"
+	clusterInfoPatchVersionAttr    = "patch_version"
+	clusterInfoServerlessAttr      = "serverless"
+	clusterInfoDatabaseAttr        = "database"
+	clusterInfoUserAttr            = "user"
+	clusterInfoRolesAttr           = "supports_roles"
+	clusterInfoDatasharesAttr      = "supports_datashares"
+)
+
+// redshiftVersionRegexp matches the Redshift release at the end of version(),
+// e.g. "PostgreSQL 8.0.2 on i686-pc-linux-gnu, ..., Redshift 1.0.77467".
+var redshiftVersionRegexp = regexp.MustCompile(`Redshift (\d+)\.(\d+)\.(\d+)`)
+
+func dataSourceRedshiftClusterInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Gets the version of the Amazon Redshift cluster or Serverless workgroup the provider connects to, along with the current database and user. Modules can use it to only enable the features some releases or deployment types support.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftClusterInfoRead),
+		Schema: map[string]*schema.Schema{
+			clusterInfoVersionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full version string returned by `version()`.",
+			},
+			clusterInfoRedshiftVersionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Redshift release, e.g. `1.0.77467`.",
+			},
+			clusterInfoMajorVersionAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The major number of the Redshift release.",
+			},
+			clusterInfoMinorVersionAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The minor number of the Redshift release.",
+			},
+			clusterInfoPatchVersionAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The patch number of the Redshift release, which increases with every cluster version.",
+			},
+			clusterInfoServerlessAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the provider connects to Redshift Serverless rather than a provisioned cluster.",
+			},
+			clusterInfoDatabaseAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The database the provider connects to.",
+			},
+			clusterInfoUserAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user the provider runs the statements as.",
+			},
+			clusterInfoRolesAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster supports roles, which `redshift_role` and `redshift_grant_role` need.",
+			},
+			clusterInfoDatasharesAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster supports data sharing, which `redshift_datashare` and `redshift_datashare_privilege` need.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftClusterInfoRead(db *DBConnection, d *schema.ResourceData) error {
+	var namespace, version, database, user string
+	if err := db.QueryRow("SELECT CURRENT_NAMESPACE, version(), current_database(), current_user").Scan(&namespace, &version, &database, &user); err != nil {
+		return fmt.Errorf("Error reading cluster info: %w", err)
+	}
+
+	major, minor, patch, err := parseRedshiftVersion(version)
+	if err != nil {
+		return err
+	}
+
+	isServerless, err := db.client.config.IsServerless(db)
+	if err != nil {
+		return fmt.Errorf("could not determine whether the cluster is Redshift Serverless: %w", err)
+	}
+
+	supportsRoles, err := db.supports(clusterFeatureRoles)
+	if err != nil {
+		return err
+	}
+	supportsDatashares, err := db.supports(clusterFeatureDatashares)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(namespace)
+	d.Set(clusterInfoVersionAttr, version)
+	d.Set(clusterInfoRedshiftVersionAttr, fmt.Sprintf("%d.%d.%d", major, minor, patch))
+	d.Set(clusterInfoMajorVersionAttr, major)
+	d.Set(clusterInfoMinorVersionAttr, minor)
+	d.Set(clusterInfoPatchVersionAttr, patch)
+	d.Set(clusterInfoServerlessAttr, isServerless)
+	d.Set(clusterInfoDatabaseAttr, database)
+	d.Set(clusterInfoUserAttr, user)
+	d.Set(clusterInfoRolesAttr, supportsRoles)
+	d.Set(clusterInfoDatasharesAttr, supportsDatashares)
+
+	return nil
+}
+
+// parseRedshiftVersion returns the major, minor and patch numbers of the
+// Redshift release reported by version().
+func parseRedshiftVersion(version string) (int, int, int, error) {
+	match := redshiftVersionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return 0, 0, 0, fmt.Errorf("could not find the Redshift release in version %q", version)
+	}
+
+	numbers := make([]int, 3)
+	for i, raw := range match[1:] {
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("could not parse the Redshift release in version %q: %w", version, err)
+		}
+		numbers[i] = number
+	}
+
+	return numbers[0], numbers[1], numbers[2], nil
+}
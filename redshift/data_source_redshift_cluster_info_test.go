@@ -0,0 +1,48 @@
+package redshift
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftClusterInfo(t *testing.T) {
+	config := `
+data "redshift_cluster_info" "info" {}
+`
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.redshift_cluster_info.info", "id", uuidRegex),
+					resource.TestMatchResourceAttr("data.redshift_cluster_info.info", clusterInfoRedshiftVersionAttr, regexp.MustCompile(`^\d+\.\d+\.\d+$`)),
+					resource.TestCheckResourceAttrSet("data.redshift_cluster_info.info", clusterInfoMajorVersionAttr),
+					resource.TestCheckResourceAttrSet("data.redshift_cluster_info.info", clusterInfoServerlessAttr),
+					resource.TestCheckResourceAttrSet("data.redshift_cluster_info.info", clusterInfoDatabaseAttr),
+					resource.TestCheckResourceAttr("data.redshift_cluster_info.info", clusterInfoRolesAttr, "true"),
+					resource.TestCheckResourceAttr("data.redshift_cluster_info.info", clusterInfoUserAttr, strings.ToLower(permanentUsername(os.Getenv("REDSHIFT_USER")))),
+				),
+			},
+		},
+	})
+}
+
+func TestParseRedshiftVersion(t *testing.T) {
+	major, minor, patch, err := parseRedshiftVersion("PostgreSQL 8.0.2 on i686-pc-linux-gnu, compiled by GCC gcc (GCC) 3.4.2 20041017 (Red Hat 3.4.2-6.fc3), Redshift 1.0.77467")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if major != 1 || minor != 0 || patch != 77467 {
+		t.Errorf("Expected version 1.0.77467, got %d.%d.%d", major, minor, patch)
+	}
+
+	if _, _, _, err := parseRedshiftVersion("PostgreSQL 8.0.2"); err == nil {
+		t.Errorf("Expected an error for a version without a Redshift release")
+	}
+}
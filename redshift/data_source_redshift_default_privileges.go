@@ -0,0 +1,170 @@
+package redshift
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultPrivilegesGranteesAttr    = "grantees"
+	defaultPrivilegesGranteeAttr     = "grantee"
+	defaultPrivilegesGranteeTypeAttr = "grantee_type"
+)
+
+func dataSourceRedshiftDefaultPrivileges() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source reads the default privileges defined for the objects created in the future by a user, as stored in ` + "`pg_default_acl`" + `, e.g. to check which privileges new tables will be granted before creating them. Default privileges defined for a schema are added to the database-wide ones when an object is created: when ` + "`schema`" + ` is set only the former are returned, when it isn't only the latter. Default privileges granted to roles aren't stored in ` + "`pg_default_acl`" + ` and aren't returned.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftDefaultPrivilegesRead),
+		Schema: map[string]*schema.Schema{
+			defaultPrivilegesOwnerAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the user for which default privileges are defined.",
+			},
+			defaultPrivilegesSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the schema the default privileges are defined for. When not set, the default privileges applied globally to the entire database are returned.",
+			},
+			defaultPrivilegesObjectTypeAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(defaultPrivilegesAllowedObjectTypes, false),
+				Description:  "The Redshift object type the default privileges are defined on (one of: " + strings.Join(defaultPrivilegesAllowedObjectTypes, ", ") + ").",
+			},
+			defaultPrivilegesGranteesAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users and groups granted default privileges, and `PUBLIC`. It's empty when the owner, the schema or the default privileges don't exist.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						defaultPrivilegesGranteeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the user or the group. It's empty for `PUBLIC`.",
+						},
+						defaultPrivilegesGranteeTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the grantee: `user`, `group` or `public`.",
+						},
+						defaultPrivilegesPrivilegesAttr: {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Set:         schema.HashString,
+							Description: "The privileges granted by default.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftDefaultPrivilegesRead(db *DBConnection, d *schema.ResourceData) error {
+	ownerName := d.Get(defaultPrivilegesOwnerAttr).(string)
+	schemaName := d.Get(defaultPrivilegesSchemaAttr).(string)
+	objectType := d.Get(defaultPrivilegesObjectTypeAttr).(string)
+
+	// The database-wide default privileges are stored with defaclnamespace 0,
+	// which doesn't match any schema.
+	rows, err := db.Query(`
+  SELECT array_to_string(acl.defaclacl, '|')
+  FROM pg_default_acl acl
+    JOIN pg_user u ON u.usesysid = acl.defacluser
+    LEFT JOIN pg_namespace nsp ON nsp.oid = acl.defaclnamespace
+  WHERE u.usename = $1 AND COALESCE(nsp.nspname, '') = $2 AND acl.defaclobjtype = $3
+`, ownerName, schemaName, defaultPrivilegesObjectTypesCodes[objectType])
+	if err != nil {
+		return fmt.Errorf("Error reading default privileges: %w", err)
+	}
+	defer rows.Close()
+
+	acls := []string{}
+	for rows.Next() {
+		var acl string
+		if err := rows.Scan(&acl); err != nil {
+			return err
+		}
+		acls = append(acls, acl)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(generateDefaultPrivilegesDataSourceID(ownerName, schemaName, objectType))
+	d.Set(defaultPrivilegesGranteesAttr, parseDefaultACL(strings.Join(acls, "|"), objectType))
+
+	return nil
+}
+
+// parseDefaultACL parses the aclitems of a default ACL joined with '|', e.g.
+// `"group sales"=r/root`, into a grantee block per user, group and, for
+// aclitems without a grantee, PUBLIC.
+func parseDefaultACL(acl string, objectType string) []map[string]interface{} {
+	privilegeNames := map[rune]string{}
+	for name, code := range grantPrivilegesACLCodes[objectType] {
+		privilegeNames[code] = name
+	}
+
+	grantees := []map[string]interface{}{}
+	byGrantee := map[string]map[string]interface{}{}
+	for _, item := range strings.Split(strings.ReplaceAll(acl, `"`, ""), "|") {
+		// The grantee comes before the last '=', as quoted names may contain one.
+		i := strings.LastIndex(item, "=")
+		if i < 0 {
+			continue
+		}
+		grantee, granteeType := item[:i], "user"
+		if grantee == "" {
+			granteeType = "public"
+		} else if groupName, isGroup := strings.CutPrefix(grantee, "group "); isGroup {
+			grantee, granteeType = groupName, "group"
+		}
+
+		key := granteeType + ":" + grantee
+		block, ok := byGrantee[key]
+		if !ok {
+			block = map[string]interface{}{
+				defaultPrivilegesGranteeAttr:     grantee,
+				defaultPrivilegesGranteeTypeAttr: granteeType,
+				defaultPrivilegesPrivilegesAttr:  []string{},
+			}
+			byGrantee[key] = block
+			grantees = append(grantees, block)
+		}
+
+		privileges := block[defaultPrivilegesPrivilegesAttr].([]string)
+		codes, _, _ := strings.Cut(item[i+1:], "/")
+		for _, code := range codes {
+			if name, ok := privilegeNames[code]; ok && !slices.Contains(privileges, name) {
+				privileges = append(privileges, name)
+			}
+		}
+		sort.Strings(privileges)
+		block[defaultPrivilegesPrivilegesAttr] = privileges
+	}
+
+	return grantees
+}
+
+func generateDefaultPrivilegesDataSourceID(ownerName, schemaName, objectType string) string {
+	schemaPart := "noschema"
+	if schemaName != "" {
+		schemaPart = fmt.Sprintf("sn:%s", schemaName)
+	}
+
+	return strings.Join([]string{
+		fmt.Sprintf("on:%s", ownerName), schemaPart, fmt.Sprintf("ot:%s", objectType),
+	}, "_")
+}
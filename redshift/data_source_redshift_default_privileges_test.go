@@ -0,0 +1,143 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftDefaultPrivileges_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_default_privileges"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_default_privileges"), "-", "_")
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_default_privileges"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_group" "group" {
+  name = %[3]q
+}
+
+resource "redshift_default_privileges" "global" {
+  user        = redshift_user.user.name
+  owner       = "root"
+  object_type = "table"
+  privileges  = ["select", "insert"]
+}
+
+resource "redshift_default_privileges" "schema" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  owner       = "root"
+  object_type = "table"
+  privileges  = ["select"]
+}
+
+data "redshift_default_privileges" "schema" {
+  owner       = "root"
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+
+  depends_on = [redshift_default_privileges.global, redshift_default_privileges.schema]
+}
+
+data "redshift_default_privileges" "global" {
+  owner       = "root"
+  object_type = "table"
+
+  depends_on = [redshift_default_privileges.global, redshift_default_privileges.schema]
+}
+
+data "redshift_default_privileges" "functions" {
+  owner       = "root"
+  schema      = redshift_schema.schema.name
+  object_type = "function"
+
+  depends_on = [redshift_default_privileges.global, redshift_default_privileges.schema]
+}
+`, schemaName, userName, groupName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.schema", "id", fmt.Sprintf("on:root_sn:%s_ot:table", schemaName)),
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.schema", "grantees.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.schema", "grantees.0.grantee", groupName),
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.schema", "grantees.0.grantee_type", "group"),
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.schema", "grantees.0.privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_default_privileges.schema", "grantees.0.privileges.*", "select"),
+
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.functions", "grantees.#", "0"),
+
+					// Other tests may define database-wide default privileges for root too.
+					resource.TestCheckResourceAttr("data.redshift_default_privileges.global", "id", "on:root_noschema_ot:table"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.redshift_default_privileges.global", "grantees.*", map[string]string{
+						"grantee":      userName,
+						"grantee_type": "user",
+						"privileges.#": "2",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDefaultACL(t *testing.T) {
+	tests := map[string]struct {
+		acl        string
+		objectType string
+		expected   []map[string]interface{}
+	}{
+		"empty": {
+			acl:        "",
+			objectType: "table",
+			expected:   []map[string]interface{}{},
+		},
+		"users, groups and public": {
+			acl:        `alice=rw/root|"group sales"=r/root|=x/root`,
+			objectType: "table",
+			expected: []map[string]interface{}{
+				{"grantee": "alice", "grantee_type": "user", "privileges": []string{"select", "update"}},
+				{"grantee": "sales", "grantee_type": "group", "privileges": []string{"select"}},
+				{"grantee": "", "grantee_type": "public", "privileges": []string{"references"}},
+			},
+		},
+		"merged grantors": {
+			acl:        `alice=r/root|alice=ra*/bob`,
+			objectType: "table",
+			expected: []map[string]interface{}{
+				{"grantee": "alice", "grantee_type": "user", "privileges": []string{"insert", "select"}},
+			},
+		},
+		"functions": {
+			acl:        `"group sales"=X/root`,
+			objectType: "function",
+			expected: []map[string]interface{}{
+				{"grantee": "sales", "grantee_type": "group", "privileges": []string{"execute"}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := parseDefaultACL(tc.acl, tc.objectType)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("parseDefaultACL() = %v, expected %v", result, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	privilegeObjectAttr    = "object"
+	privilegeGrantableAttr = "grantable"
+)
+
+var (
+	privilegeTablePrivileges  = []string{"select", "insert", "update", "delete", "drop", "references"}
+	privilegeSchemaPrivileges = []string{"create", "usage"}
+)
+
+func dataSourceRedshiftPrivilege() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source can be used to introspect the privileges a user, group or role currently holds on a schema or on a table. For users the privileges are the effective ones, including those inherited from groups, roles and ` + "`PUBLIC`" + `. For groups and roles only the privileges granted to them directly are returned. When the schema or the table doesn't exist, empty sets are returned.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftPrivilegeRead),
+		Schema: map[string]*schema.Schema{
+			grantSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the schema to introspect.",
+			},
+			privilegeObjectAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the table or view in `schema` to introspect. When not set, the privileges on the schema itself are returned.",
+			},
+			grantUserAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:  "The name of the user to introspect the privileges of. Exactly one of `user`, `group` or `role` parameters must be set.",
+			},
+			grantGroupAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:  "The name of the group to introspect the privileges of. Exactly one of `user`, `group` or `role` parameters must be set.",
+			},
+			grantRoleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:  "The name of the role to introspect the privileges of. Exactly one of `user`, `group` or `role` parameters must be set.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			grantPrivilegesAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "The list of privileges currently held on the object.",
+			},
+			privilegeGrantableAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "The list of privileges held on the object `WITH GRANT OPTION`.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftPrivilegeRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(grantSchemaAttr).(string)
+	objectName := d.Get(privilegeObjectAttr).(string)
+	identityType, identityName := privilegeIdentity(d)
+
+	privileges := []string{}
+	grantable := []string{}
+
+	exists, err := privilegeObjectExists(db, schemaName, objectName)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		if identityType == "user" {
+			privileges, err = readEffectiveUserPrivileges(db, identityName, schemaName, objectName)
+		} else {
+			privileges, err = readIdentityPrivileges(db, identityType, identityName, schemaName, objectName, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		grantable, err = readIdentityPrivileges(db, identityType, identityName, schemaName, objectName, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(generatePrivilegeID(identityType, identityName, schemaName, objectName))
+	d.Set(grantPrivilegesAttr, privileges)
+	d.Set(privilegeGrantableAttr, grantable)
+
+	return nil
+}
+
+func privilegeIdentity(d *schema.ResourceData) (string, string) {
+	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
+		return "group", groupName.(string)
+	}
+	if roleName, isRole := d.GetOk(grantRoleAttr); isRole {
+		return "role", strings.ToLower(roleName.(string))
+	}
+
+	return "user", d.Get(grantUserAttr).(string)
+}
+
+func privilegeObjectExists(db *DBConnection, schemaName, objectName string) (bool, error) {
+	var (
+		query     = "SELECT 1 FROM pg_namespace WHERE nspname = $1"
+		queryArgs = []interface{}{schemaName}
+		_rez      int
+	)
+
+	if objectName != "" {
+		query = `
+  SELECT 1
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2
+`
+		queryArgs = append(queryArgs, objectName)
+	}
+
+	err := db.QueryRow(query, queryArgs...).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error checking if the object exists: %w", err)
+	}
+
+	return true, nil
+}
+
+func readEffectiveUserPrivileges(db *DBConnection, userName, schemaName, objectName string) ([]string, error) {
+	checkFunc := "has_schema_privilege"
+	objectIdent := pq.QuoteIdentifier(schemaName)
+	candidates := privilegeSchemaPrivileges
+	if objectName != "" {
+		checkFunc = "has_table_privilege"
+		objectIdent = fmt.Sprintf("%s.%s", objectIdent, pq.QuoteIdentifier(objectName))
+		candidates = privilegeTablePrivileges
+	}
+
+	columns := []string{}
+	for _, privilege := range candidates {
+		columns = append(columns, fmt.Sprintf("%s($1, $2, '%s')", checkFunc, strings.ToUpper(privilege)))
+	}
+
+	held := make([]bool, len(candidates))
+	dest := []interface{}{}
+	for i := range held {
+		dest = append(dest, &held[i])
+	}
+
+	query := fmt.Sprintf("SELECT %s", strings.Join(columns, ", "))
+	if err := db.QueryRow(query, userName, objectIdent).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("Error reading privileges of user %s: %w", userName, err)
+	}
+
+	privileges := []string{}
+	for i, privilege := range candidates {
+		appendIfTrue(held[i], privilege, &privileges)
+	}
+
+	return privileges, nil
+}
+
+func readIdentityPrivileges(db *DBConnection, identityType, identityName, schemaName, objectName string, grantableOnly bool) ([]string, error) {
+	query := `
+  SELECT privilege_type
+  FROM svv_schema_privileges
+  WHERE identity_type = $1 AND identity_name = $2 AND namespace_name = $3
+`
+	queryArgs := []interface{}{identityType, identityName, schemaName}
+	if objectName != "" {
+		query = `
+  SELECT privilege_type
+  FROM svv_relation_privileges
+  WHERE identity_type = $1 AND identity_name = $2 AND namespace_name = $3 AND relation_name = $4
+`
+		queryArgs = append(queryArgs, objectName)
+	}
+	if grantableOnly {
+		query = query + "  AND admin_option\n"
+	}
+
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading privileges of %s %s: %w", identityType, identityName, err)
+	}
+	defer rows.Close()
+
+	privileges := []string{}
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, normalizeRolePrivilege(privilege))
+	}
+
+	return privileges, rows.Err()
+}
+
+func generatePrivilegeID(identityType, identityName, schemaName, objectName string) string {
+	parts := []string{
+		fmt.Sprintf("%sn:%s", identityType[:1], identityName),
+		fmt.Sprintf("sn:%s", schemaName),
+	}
+	if objectName != "" {
+		parts = append(parts, fmt.Sprintf("on:%s", objectName))
+	}
+
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,103 @@
+package redshift
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftPrivilege_Schema(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_privilege"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_privilege"), "-", "_")
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_privilege"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_group" "group" {
+  name  = %[3]q
+  users = [redshift_user.user.name]
+}
+
+resource "redshift_grant" "group" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage", "create"]
+}
+
+data "redshift_privilege" "user" {
+  schema = redshift_schema.schema.name
+  user   = redshift_user.user.name
+
+  depends_on = [redshift_grant.group]
+}
+
+data "redshift_privilege" "group" {
+  schema = redshift_schema.schema.name
+  group  = redshift_group.group.name
+
+  depends_on = [redshift_grant.group]
+}
+
+data "redshift_privilege" "missing" {
+  schema = redshift_schema.schema.name
+  object = "tf_acc_missing_table"
+  user   = redshift_user.user.name
+}
+`, schemaName, userName, groupName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_privilege.user", "id", fmt.Sprintf("un:%s_sn:%s", userName, schemaName)),
+					resource.TestCheckResourceAttr("data.redshift_privilege.user", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_privilege.user", "privileges.*", "usage"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_privilege.user", "privileges.*", "create"),
+					resource.TestCheckResourceAttr("data.redshift_privilege.user", "grantable.#", "0"),
+
+					resource.TestCheckResourceAttr("data.redshift_privilege.group", "id", fmt.Sprintf("gn:%s_sn:%s", groupName, schemaName)),
+					resource.TestCheckResourceAttr("data.redshift_privilege.group", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_privilege.group", "privileges.*", "usage"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_privilege.group", "privileges.*", "create"),
+
+					resource.TestCheckResourceAttr("data.redshift_privilege.missing", "privileges.#", "0"),
+					resource.TestCheckResourceAttr("data.redshift_privilege.missing", "grantable.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestGeneratePrivilegeID(t *testing.T) {
+	tests := []struct {
+		identityType string
+		identityName string
+		schemaName   string
+		objectName   string
+		expected     string
+	}{
+		{"user", "john", "reporting", "", "un:john_sn:reporting"},
+		{"group", "analysts", "reporting", "daily_sales", "gn:analysts_sn:reporting_on:daily_sales"},
+		{"role", "auditor", "public", "events", "rn:auditor_sn:public_on:events"},
+	}
+
+	for _, tc := range tests {
+		if id := generatePrivilegeID(tc.identityType, tc.identityName, tc.schemaName, tc.objectName); id != tc.expected {
+			t.Errorf("generatePrivilegeID(%q, %q, %q, %q) = %q, expected %q", tc.identityType, tc.identityName, tc.schemaName, tc.objectName, id, tc.expected)
+		}
+	}
+}
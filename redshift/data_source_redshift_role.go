@@ -0,0 +1,98 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	roleRoleIdAttr   = "role_id"
+	roleUsersAttr    = "users"
+	roleExternalAttr = "external"
+)
+
+func dataSourceRedshiftRole() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source can be used to fetch information about a specific role. Roles are named collections of privileges and other roles that can be granted to users and to other roles.
+`,
+		ReadContext: RedshiftResourceFunc(
+			RedshiftResourceRequireFeature(clusterFeatureRoles, "redshift_role", dataSourceRedshiftRoleRead),
+		),
+		Schema: map[string]*schema.Schema{
+			roleNameAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the role. The lookup is case-insensitive.",
+				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile("(?i)^"+systemRolePrefix), "Role names beginning with sys: are reserved for system-defined roles"),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			roleRoleIdAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the role.",
+			},
+			roleUsersAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "List of the user names who have been granted the role.",
+			},
+			roleRolesAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "List of the role names granted to the role.",
+			},
+			roleExternalAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the role was created externally, for example by IAM Identity Center federation.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	var (
+		roleId     int
+		externalId sql.NullString
+		roleUsers  []string
+		roleRoles  []string
+	)
+
+	roleName := strings.ToLower(d.Get(roleNameAttr).(string))
+
+	err := db.QueryRow("SELECT role_id, external_id FROM svv_roles WHERE LOWER(role_name) = $1", roleName).Scan(&roleId, &externalId)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("Role %q does not exist", roleName)
+	case err != nil:
+		return fmt.Errorf("Error reading Role: %w", err)
+	}
+
+	membersSQL := `SELECT ARRAY(SELECT user_name FROM svv_user_grants WHERE role_id = $1), ARRAY(SELECT granted_role_name FROM svv_role_grants WHERE role_id = $1)`
+	if err := db.QueryRow(membersSQL, roleId).Scan(pq.Array(&roleUsers), pq.Array(&roleRoles)); err != nil {
+		return fmt.Errorf("Error reading Role members: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", roleId))
+	d.Set(roleRoleIdAttr, roleId)
+	d.Set(roleUsersAttr, roleUsers)
+	d.Set(roleRolesAttr, roleRoles)
+	d.Set(roleExternalAttr, externalId.Valid && externalId.String != "")
+
+	return nil
+}
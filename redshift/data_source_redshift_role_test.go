@@ -0,0 +1,50 @@
+package redshift
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftRole_basic(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_basic"), "-", "_")
+	childRoleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_basic_child"), "-", "_")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRedshiftRoleConfig_basic(roleName, childRoleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_role.role", roleNameAttr, roleName),
+					resource.TestCheckResourceAttrSet("data.redshift_role.role", roleRoleIdAttr),
+					resource.TestCheckResourceAttrPair("data.redshift_role.role", roleRoleIdAttr, "redshift_role.role", "id"),
+					resource.TestCheckResourceAttr("data.redshift_role.role", fmt.Sprintf("%s.#", roleRolesAttr), "1"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_role.role", fmt.Sprintf("%s.*", roleRolesAttr), childRoleName),
+					resource.TestCheckResourceAttr("data.redshift_role.role", fmt.Sprintf("%s.#", roleUsersAttr), "0"),
+					resource.TestCheckResourceAttr("data.redshift_role.role", roleExternalAttr, "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRedshiftRoleConfig_basic(roleName string, childRoleName string) string {
+	return fmt.Sprintf(`
+resource "redshift_role" "child" {
+	%[1]s = %[2]q
+}
+resource "redshift_role" "role" {
+	%[1]s = %[3]q
+	%[4]s = [ redshift_role.child.%[1]s ]
+}
+
+data "redshift_role" "role" {
+	%[1]s = upper(redshift_role.role.%[1]s)
+}
+`, roleNameAttr, childRoleName, roleName, roleRolesAttr)
+}
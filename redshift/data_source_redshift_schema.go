@@ -1,12 +1,15 @@
 package redshift
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const schemaTypeAttr = "schema_type"
+
 func dataSourceRedshiftSchema() *schema.Resource {
 	return &schema.Resource{
 		Description: `
@@ -27,6 +30,11 @@ A database contains one or more named schemas. Each schema in a database contain
 				Computed:    true,
 				Description: "Name of the schema owner.",
 			},
+			schemaTypeAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the schema. Either `local` or `external`.",
+			},
 			schemaQuotaAttr: {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -265,6 +273,8 @@ A database contains one or more named schemas. Each schema in a database contain
 func dataSourceRedshiftSchemaRead(db *DBConnection, d *schema.ResourceData) error {
 	var schemaOwner, schemaId, schemaType string
 
+	schemaName := strings.ToLower(d.Get(schemaNameAttr).(string))
+
 	// Step 1: get basic schema info
 	err := db.QueryRow(`
 			SELECT
@@ -276,12 +286,16 @@ func dataSourceRedshiftSchemaRead(db *DBConnection, d *schema.ResourceData) erro
 	LEFT JOIN pg_user_info
 		ON (svv_all_schemas.database_name = $1 AND pg_user_info.usesysid = svv_all_schemas.schema_owner)
 	WHERE svv_all_schemas.database_name = $1
-	AND svv_all_schemas.schema_name = $2`, db.client.databaseName, d.Get(schemaNameAttr).(string)).Scan(&schemaId, &schemaOwner, &schemaType)
-	if err != nil {
-		return err
+	AND svv_all_schemas.schema_name = $2`, db.client.databaseName, schemaName).Scan(&schemaId, &schemaOwner, &schemaType)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("Schema %q does not exist in database %q", schemaName, db.client.databaseName)
+	case err != nil:
+		return fmt.Errorf("Error reading schema: %w", err)
 	}
 	d.SetId(schemaId)
 	d.Set(schemaOwnerAttr, schemaOwner)
+	d.Set(schemaTypeAttr, schemaType)
 
 	switch {
 	case schemaType == "local":
@@ -3,6 +3,7 @@ package redshift
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -23,12 +24,31 @@ func TestAccDataSourceRedshiftSchema_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.redshift_schema.schema", schemaNameAttr, schemaName),
 					resource.TestCheckResourceAttrSet("data.redshift_schema.schema", schemaOwnerAttr),
 					resource.TestCheckResourceAttrSet("data.redshift_schema.schema", schemaQuotaAttr),
+					resource.TestCheckResourceAttr("data.redshift_schema.schema", schemaTypeAttr, "local"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccDataSourceRedshiftSchema_NotFound(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_missing"), "-", "_")
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "redshift_schema" "schema" {
+	%[1]s = %[2]q
+}
+`, schemaNameAttr, schemaName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("Schema %q does not exist", schemaName)),
+			},
+		},
+	})
+}
+
 func testAccDataSourceRedshiftSchemaConfig_basic(schemaName string) string {
 	return fmt.Sprintf(`
 resource "redshift_schema" "schema" {
@@ -77,6 +97,7 @@ data "redshift_schema" "spectrum" {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRedshiftSchemaExists(schemaName),
 					resource.TestCheckResourceAttr("data.redshift_schema.spectrum", "name", schemaName),
+					resource.TestCheckResourceAttr("data.redshift_schema.spectrum", schemaTypeAttr, "external"),
 					resource.TestCheckResourceAttr("data.redshift_schema.spectrum", fmt.Sprintf("%s.#", schemaExternalSchemaAttr), "1"),
 					resource.TestCheckResourceAttr("data.redshift_schema.spectrum", fmt.Sprintf("%s.0.database_name", schemaExternalSchemaAttr), dbName),
 					resource.TestCheckResourceAttr("data.redshift_schema.spectrum", fmt.Sprintf("%s.0.data_catalog_source.#", schemaExternalSchemaAttr), "1"),
@@ -0,0 +1,127 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	schemasAttr         = "schemas"
+	schemasDatabaseAttr = "database"
+	schemasTypeAttr     = "type"
+)
+
+var schemasAllowedTypes = []string{"local", "external", "shared"}
+
+func dataSourceRedshiftSchemas() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source can be used to list the schemas of a database, e.g. to apply uniform grants on all of them with ` + "`for_each`" + `. The system schemas (` + "`information_schema`" + ` and those starting with ` + "`pg_`" + `) are not returned.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftSchemasRead),
+		Schema: map[string]*schema.Schema{
+			schemasDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The database to list the schemas of. Defaults to the database the provider connects to.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			schemaTypeAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "When set, only the schemas of this type are returned. One of `" + strings.Join(schemasAllowedTypes, "`, `") + "`.",
+				ValidateFunc: validation.StringInSlice(schemasAllowedTypes, false),
+			},
+			schemasAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The schemas, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						schemaNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the schema.",
+						},
+						schemaOwnerAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the schema owner.",
+						},
+						schemasTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the schema, `local`, `external` or `shared` (created from a datashare).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftSchemasRead(db *DBConnection, d *schema.ResourceData) error {
+	databaseName := d.Get(schemasDatabaseAttr).(string)
+	if databaseName == "" {
+		databaseName = db.client.databaseName
+	}
+	schemaType := d.Get(schemaTypeAttr).(string)
+
+	query, queryArgs := schemasQuery(strings.ToLower(databaseName), schemaType)
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("Error reading schemas: %w", err)
+	}
+	defer rows.Close()
+
+	schemas := []map[string]interface{}{}
+	for rows.Next() {
+		var name, schemaType string
+		var owner sql.NullString
+		if err := rows.Scan(&name, &owner, &schemaType); err != nil {
+			return err
+		}
+
+		schemas = append(schemas, map[string]interface{}{
+			schemaNameAttr:  name,
+			schemaOwnerAttr: owner.String,
+			schemasTypeAttr: schemaType,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("dn:%s", strings.ToLower(databaseName))
+	if schemaType != "" {
+		id = fmt.Sprintf("%s_st:%s", id, schemaType)
+	}
+	d.SetId(id)
+	d.Set(schemasAttr, schemas)
+
+	return nil
+}
+
+func schemasQuery(databaseName, schemaType string) (string, []interface{}) {
+	query := `
+  SELECT TRIM(s.schema_name), TRIM(u.usename), TRIM(s.schema_type)
+  FROM svv_all_schemas s
+    LEFT JOIN pg_user_info u ON u.usesysid = s.schema_owner
+  WHERE s.database_name = $1
+    AND s.schema_name <> 'information_schema'
+    AND LEFT(s.schema_name, 3) <> 'pg_'
+`
+	queryArgs := []interface{}{databaseName}
+	if schemaType != "" {
+		query += "    AND TRIM(s.schema_type) = $2\n"
+		queryArgs = append(queryArgs, schemaType)
+	}
+
+	return query + "  ORDER BY s.schema_name", queryArgs
+}
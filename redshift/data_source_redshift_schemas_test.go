@@ -0,0 +1,64 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftSchemas_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_schemas"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+data "redshift_schemas" "local" {
+  schema_type = "local"
+
+  depends_on = [redshift_schema.schema]
+}
+`, schemaName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.redshift_schemas.local", "schemas.*", map[string]string{
+						"name": schemaName,
+						"type": "local",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.redshift_schemas.local", "schemas.*", map[string]string{
+						"name": "public",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestSchemasQuery(t *testing.T) {
+	query, args := schemasQuery("dev", "external")
+	if !strings.Contains(query, "TRIM(s.schema_type) = $2") {
+		t.Errorf("Expected the query to filter on the schema type, got %q", query)
+	}
+	if expected := []interface{}{"dev", "external"}; !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected arguments %v, got %v", expected, args)
+	}
+
+	query, args = schemasQuery("dev", "")
+	if strings.Contains(query, "schema_type =") {
+		t.Errorf("Expected the query not to filter on the schema type, got %q", query)
+	}
+	if expected := []interface{}{"dev"}; !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected arguments %v, got %v", expected, args)
+	}
+}
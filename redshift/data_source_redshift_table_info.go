@@ -0,0 +1,202 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	tableInfoSchemaAttr        = "schema"
+	tableInfoTableAttr         = "table"
+	tableInfoDistStyleAttr     = "diststyle"
+	tableInfoDistKeyAttr       = "distkey"
+	tableInfoSortKeysAttr      = "sort_keys"
+	tableInfoInterleavedAttr   = "interleaved_sort_key"
+	tableInfoEncodedAttr       = "encoded"
+	tableInfoEstimatedRowsAttr = "estimated_rows"
+	tableInfoSizeAttr          = "size_mb"
+	tableInfoUnsortedAttr      = "unsorted_pct"
+	tableInfoStatsOffAttr      = "stats_off"
+	tableInfoSkewRowsAttr      = "skew_rows"
+)
+
+func dataSourceRedshiftTableInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source reads the layout and the statistics of a table, e.g. to decide whether it needs a ` + "`VACUUM`" + ` or an ` + "`ANALYZE`" + `. The layout is read from the catalog, the statistics from ` + "`svv_table_info`" + `, which doesn't list empty tables: their statistics are all zero.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftTableInfoRead),
+		Schema: map[string]*schema.Schema{
+			tableInfoSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the schema the table belongs to.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tableInfoTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the table.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tableInfoDistStyleAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distribution style of the table: `AUTO`, `EVEN`, `KEY` or `ALL`.",
+			},
+			tableInfoDistKeyAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The distribution key column. It is empty unless the distribution style is `KEY`.",
+			},
+			tableInfoSortKeysAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sort key columns, in order.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			tableInfoInterleavedAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the sort key is interleaved rather than compound.",
+			},
+			tableInfoEncodedAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether any column of the table has a compression encoding.",
+			},
+			tableInfoEstimatedRowsAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Estimated number of rows of the table, not counting the rows marked for deletion.",
+			},
+			tableInfoSizeAttr: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the table in 1 MB data blocks.",
+			},
+			tableInfoUnsortedAttr: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Percent of unsorted rows of the table.",
+			},
+			tableInfoStatsOffAttr: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "How stale the statistics of the table are, in percent. Zero means they are current.",
+			},
+			tableInfoSkewRowsAttr: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Ratio of the number of rows in the slice with the most rows to the number of rows in the slice with the fewest rows.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftTableInfoRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := strings.ToLower(d.Get(tableInfoSchemaAttr).(string))
+	tableName := strings.ToLower(d.Get(tableInfoTableAttr).(string))
+
+	var tableOID string
+	var distStyleCode int
+	err := db.QueryRow(`
+  SELECT cl.oid, cl.reldiststyle
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2 AND cl.relkind = 'r'
+`, schemaName, tableName).Scan(&tableOID, &distStyleCode)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("table %s.%s doesn't exist", schemaName, tableName)
+	case err != nil:
+		return fmt.Errorf("Error reading table %s.%s: %w", schemaName, tableName, err)
+	}
+
+	rows, err := db.Query(`
+  SELECT a.attname, a.attisdistkey, a.attsortkeyord, format_encoding(a.attencodingtype::integer)
+  FROM pg_attribute a
+  WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+  ORDER BY a.attnum
+`, tableOID)
+	if err != nil {
+		return fmt.Errorf("Error reading columns of table %s.%s: %w", schemaName, tableName, err)
+	}
+	defer rows.Close()
+
+	// Interleaved sort keys have negative positions.
+	sortKey := map[int]string{}
+	distKey := ""
+	interleaved, encoded := false, false
+	for rows.Next() {
+		var columnName, encoding string
+		var isDistKey bool
+		var sortKeyOrd int
+		if err := rows.Scan(&columnName, &isDistKey, &sortKeyOrd, &encoding); err != nil {
+			return err
+		}
+
+		if isDistKey {
+			distKey = columnName
+		}
+		if sortKeyOrd < 0 {
+			interleaved = true
+			sortKeyOrd = -sortKeyOrd
+		}
+		if sortKeyOrd > 0 {
+			sortKey[sortKeyOrd] = columnName
+		}
+		if normalizeColumnEncoding(encoding) != "raw" {
+			encoded = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	sortKeyColumns := []string{}
+	for i := 1; i <= len(sortKey); i++ {
+		sortKeyColumns = append(sortKeyColumns, sortKey[i])
+	}
+
+	var estimatedRows, size int64
+	var unsorted, statsOff, skewRows float64
+	err = db.QueryRow(`
+  SELECT
+    COALESCE(estimated_visible_rows, 0)::bigint,
+    COALESCE(size, 0),
+    COALESCE(unsorted, 0)::float8,
+    COALESCE(stats_off, 0)::float8,
+    COALESCE(skew_rows, 0)::float8
+  FROM svv_table_info
+  WHERE table_id = $1
+`, tableOID).Scan(&estimatedRows, &size, &unsorted, &statsOff, &skewRows)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("Error reading statistics of table %s.%s: %w", schemaName, tableName, err)
+	}
+
+	d.SetId(tableOID)
+	d.Set(tableInfoSchemaAttr, schemaName)
+	d.Set(tableInfoTableAttr, tableName)
+	d.Set(tableInfoDistStyleAttr, tableDistStyleFromCode(distStyleCode))
+	d.Set(tableInfoDistKeyAttr, distKey)
+	d.Set(tableInfoSortKeysAttr, sortKeyColumns)
+	d.Set(tableInfoInterleavedAttr, interleaved)
+	d.Set(tableInfoEncodedAttr, encoded)
+	d.Set(tableInfoEstimatedRowsAttr, estimatedRows)
+	d.Set(tableInfoSizeAttr, size)
+	d.Set(tableInfoUnsortedAttr, unsorted)
+	d.Set(tableInfoStatsOffAttr, statsOff)
+	d.Set(tableInfoSkewRowsAttr, skewRows)
+
+	return nil
+}
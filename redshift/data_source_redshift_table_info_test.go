@@ -0,0 +1,85 @@
+package redshift
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftTableInfo_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_table_info"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name      = "events"
+  schema    = redshift_schema.schema.name
+  diststyle = "KEY"
+  distkey   = "id"
+  sortkey   = ["created_at", "id"]
+
+  column {
+    name     = "id"
+    type     = "integer"
+    encoding = "az64"
+  }
+
+  column {
+    name = "created_at"
+    type = "timestamp"
+  }
+}
+
+data "redshift_table_info" "table" {
+  schema = redshift_schema.schema.name
+  table  = redshift_table.table.name
+}
+`, schemaName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "diststyle", "KEY"),
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "distkey", "id"),
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "sort_keys.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "sort_keys.0", "created_at"),
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "interleaved_sort_key", "false"),
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "encoded", "true"),
+					// The table is empty, so svv_table_info doesn't list it.
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "estimated_rows", "0"),
+					resource.TestCheckResourceAttr("data.redshift_table_info.table", "size_mb", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRedshiftTableInfo_Missing(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "redshift_table_info" "table" {
+  schema = "public"
+  table  = "tf_acc_missing_table"
+}
+`,
+				ExpectError: regexp.MustCompile("table public.tf_acc_missing_table doesn't exist"),
+			},
+		},
+	})
+}
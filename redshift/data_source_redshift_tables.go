@@ -0,0 +1,154 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	tablesAttr          = "tables"
+	tablesSchemaAttr    = "schema"
+	tablesDatabaseAttr  = "database"
+	tablesTableTypeAttr = "table_type"
+	tablesNameAttr      = "name"
+	tablesKindAttr      = "kind"
+	tablesOwnerAttr     = "owner"
+)
+
+var tablesAllowedKinds = []string{"table", "view", "external"}
+
+func dataSourceRedshiftTables() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source can be used to list the tables and views of a schema, e.g. to create grants on each of them with ` + "`for_each`" + `. An empty or missing schema results in an empty list.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftTablesRead),
+		Schema: map[string]*schema.Schema{
+			tablesSchemaAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The schema to list the tables of.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tablesDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The database the schema belongs to. Defaults to the database the provider connects to.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			tablesTableTypeAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "When set, only the relations of this kind are returned. One of `" + strings.Join(tablesAllowedKinds, "`, `") + "`.",
+				ValidateFunc: validation.StringInSlice(tablesAllowedKinds, false),
+			},
+			tablesAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tables and views, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tablesNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the relation.",
+						},
+						tablesKindAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of the relation, `table`, `view` or `external` (an external table of a Spectrum schema).",
+						},
+						tablesOwnerAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the owner. It is empty for external tables and for relations of another database.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftTablesRead(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := strings.ToLower(d.Get(tablesSchemaAttr).(string))
+	databaseName := d.Get(tablesDatabaseAttr).(string)
+	if databaseName == "" {
+		databaseName = db.client.databaseName
+	}
+	databaseName = strings.ToLower(databaseName)
+	tableType := d.Get(tablesTableTypeAttr).(string)
+
+	query, queryArgs := tablesQuery(databaseName, schemaName, tableType)
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("Error reading tables of schema %s: %w", schemaName, err)
+	}
+	defer rows.Close()
+
+	tables := []map[string]interface{}{}
+	for rows.Next() {
+		var name, kind string
+		var owner sql.NullString
+		if err := rows.Scan(&name, &kind, &owner); err != nil {
+			return err
+		}
+
+		tables = append(tables, map[string]interface{}{
+			tablesNameAttr:  name,
+			tablesKindAttr:  kind,
+			tablesOwnerAttr: owner.String,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("dn:%s_sn:%s", databaseName, schemaName)
+	if tableType != "" {
+		id = fmt.Sprintf("%s_tt:%s", id, tableType)
+	}
+	d.SetId(id)
+	d.Set(tablesAttr, tables)
+
+	return nil
+}
+
+// tablesQuery lists the relations of a schema from svv_all_tables, which
+// covers local, external and datashare relations. The owners are only known
+// for the relations of the current database, as pg_class doesn't span
+// databases.
+func tablesQuery(databaseName, schemaName, tableType string) (string, []interface{}) {
+	query := `
+  SELECT name, kind, owner FROM (
+    SELECT
+      TRIM(t.table_name) AS name,
+      CASE
+        WHEN t.table_type = 'VIEW' THEN 'view'
+        WHEN t.table_type = 'EXTERNAL TABLE' THEN 'external'
+        ELSE 'table'
+      END AS kind,
+      TRIM(u.usename) AS owner
+    FROM svv_all_tables t
+      LEFT JOIN pg_namespace n ON n.nspname = t.schema_name AND t.database_name = current_database()
+      LEFT JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = t.table_name
+      LEFT JOIN pg_user_info u ON u.usesysid = c.relowner
+    WHERE t.database_name = $1 AND t.schema_name = $2
+  ) relations
+`
+	queryArgs := []interface{}{databaseName, schemaName}
+	if tableType != "" {
+		query += "  WHERE kind = $3\n"
+		queryArgs = append(queryArgs, tableType)
+	}
+
+	return query + "  ORDER BY name", queryArgs
+}
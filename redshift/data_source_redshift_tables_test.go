@@ -0,0 +1,97 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftTables_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_tables"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "empty" {
+  name = "%[1]s_empty"
+}
+
+resource "redshift_table" "table" {
+  name   = "events"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_view" "view" {
+  name   = "events_view"
+  schema = redshift_schema.schema.name
+  query  = "SELECT id FROM ${redshift_schema.schema.name}.${redshift_table.table.name}"
+}
+
+data "redshift_tables" "all" {
+  schema = redshift_schema.schema.name
+
+  depends_on = [redshift_table.table, redshift_view.view]
+}
+
+data "redshift_tables" "views" {
+  schema     = redshift_schema.schema.name
+  table_type = "view"
+
+  depends_on = [redshift_table.table, redshift_view.view]
+}
+
+data "redshift_tables" "empty" {
+  schema = redshift_schema.empty.name
+}
+`, schemaName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.0.name", "events"),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.0.kind", "table"),
+					resource.TestCheckResourceAttrSet("data.redshift_tables.all", "tables.0.owner"),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.1.name", "events_view"),
+					resource.TestCheckResourceAttr("data.redshift_tables.all", "tables.1.kind", "view"),
+					resource.TestCheckResourceAttr("data.redshift_tables.views", "tables.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_tables.views", "tables.0.name", "events_view"),
+					resource.TestCheckResourceAttr("data.redshift_tables.empty", "tables.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestTablesQuery(t *testing.T) {
+	query, args := tablesQuery("dev", "public", "view")
+	if !strings.Contains(query, "WHERE kind = $3") {
+		t.Errorf("Expected the query to filter on the kind, got %q", query)
+	}
+	if expected := []interface{}{"dev", "public", "view"}; !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected arguments %v, got %v", expected, args)
+	}
+
+	query, args = tablesQuery("dev", "public", "")
+	if strings.Contains(query, "WHERE kind =") {
+		t.Errorf("Expected the query not to filter on the kind, got %q", query)
+	}
+	if expected := []interface{}{"dev", "public"}; !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected arguments %v, got %v", expected, args)
+	}
+}
@@ -0,0 +1,165 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	userGrantsUserAttr           = "user"
+	userGrantsMaxDepthAttr       = "max_depth"
+	userGrantsGroupsAttr         = "groups"
+	userGrantsRolesAttr          = "roles"
+	userGrantsEffectiveRolesAttr = "effective_roles"
+)
+
+func dataSourceRedshiftUserGrants() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source resolves the groups a user is a member of and the roles granted to it, including the roles granted to those roles, e.g. to audit the privileges the user effectively has. The nested roles are read from ` + "`svv_role_grants`" + ` one level at a time, down to ` + "`max_depth`" + ` levels. Redshift doesn't allow granting a role to itself through other roles, but a role met again below itself is skipped rather than resolved forever.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftUserGrantsRead),
+		Schema: map[string]*schema.Schema{
+			userGrantsUserAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the user.",
+			},
+			userGrantsMaxDepthAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of levels of nested roles resolved, the roles granted directly to the user being the first one. Reading fails when roles are nested deeper.",
+			},
+			userGrantsGroupsAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "The groups the user is a member of. Groups can't be nested.",
+			},
+			userGrantsRolesAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "The roles granted directly to the user. It's empty when the cluster doesn't support roles.",
+			},
+			userGrantsEffectiveRolesAttr: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "The roles granted directly to the user and the roles granted to them, at any level of nesting.",
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftUserGrantsRead(db *DBConnection, d *schema.ResourceData) error {
+	userName := d.Get(userGrantsUserAttr).(string)
+
+	var useSysID string
+	err := db.QueryRow("SELECT usesysid FROM pg_user_info WHERE usename = $1", userName).Scan(&useSysID)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("User %q does not exist", userName)
+	case err != nil:
+		return fmt.Errorf("Error reading User: %w", err)
+	}
+
+	groups, err := queryNames(db, "SELECT groname FROM pg_group WHERE $1 = ANY(grolist)", useSysID)
+	if err != nil {
+		return fmt.Errorf("Error reading User groups: %w", err)
+	}
+
+	supportsRoles, err := db.supports(clusterFeatureRoles)
+	if err != nil {
+		return err
+	}
+	roles, effectiveRoles := []string{}, []string{}
+	if supportsRoles {
+		if roles, err = queryNames(db, "SELECT role_name FROM svv_user_grants WHERE user_name = $1", userName); err != nil {
+			return fmt.Errorf("Error reading User roles: %w", err)
+		}
+
+		grantedRoles := func(role string) ([]string, error) {
+			return queryNames(db, "SELECT granted_role_name FROM svv_role_grants WHERE role_name = $1", role)
+		}
+		if effectiveRoles, err = resolveEffectiveRoles(userName, roles, grantedRoles, d.Get(userGrantsMaxDepthAttr).(int)); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(useSysID)
+	d.Set(userGrantsGroupsAttr, groups)
+	d.Set(userGrantsRolesAttr, roles)
+	d.Set(userGrantsEffectiveRolesAttr, effectiveRoles)
+
+	return nil
+}
+
+// resolveEffectiveRoles returns the roles granted to the user and, level by
+// level, the roles granted to them. Each role is only expanded once, so that
+// a cycle can't be followed forever, and a role granted through more than
+// maxDepth levels fails.
+func resolveEffectiveRoles(userName string, roles []string, grantedRoles func(role string) ([]string, error), maxDepth int) ([]string, error) {
+	// paths records the roles each role was reached through, to tell cycles
+	// from roles granted through several others.
+	paths := map[string][]string{}
+	level := []string{}
+	for _, role := range roles {
+		if _, ok := paths[role]; !ok {
+			paths[role] = []string{}
+			level = append(level, role)
+		}
+	}
+
+	for depth := 1; len(level) > 0; depth++ {
+		next := []string{}
+		for _, role := range level {
+			granted, err := grantedRoles(role)
+			if err != nil {
+				return nil, fmt.Errorf("Error reading roles granted to role %q: %w", role, err)
+			}
+
+			path := append(slices.Clone(paths[role]), role)
+			for _, grantedRole := range granted {
+				if slices.Contains(path, grantedRole) {
+					log.Printf("[WARN] role %q is granted to itself through %v, skipping it", grantedRole, path)
+					continue
+				}
+				if _, ok := paths[grantedRole]; ok {
+					continue
+				}
+				if depth >= maxDepth {
+					return nil, fmt.Errorf("role %q is granted to user %q through more than %d levels of nested roles (%v), raise %s to resolve it", grantedRole, userName, maxDepth, path, userGrantsMaxDepthAttr)
+				}
+				paths[grantedRole] = path
+				next = append(next, grantedRole)
+			}
+		}
+		level = next
+	}
+
+	effectiveRoles := make([]string, 0, len(paths))
+	for role := range paths {
+		effectiveRoles = append(effectiveRoles, role)
+	}
+	sort.Strings(effectiveRoles)
+
+	return effectiveRoles, nil
+}
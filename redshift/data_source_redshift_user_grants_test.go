@@ -0,0 +1,148 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftUserGrants_Basic(t *testing.T) {
+	prefix := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_user_grants"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_role" "nested" {
+  name = "%[1]s_nested"
+}
+
+resource "redshift_role" "direct" {
+  name  = "%[1]s_direct"
+  roles = [redshift_role.nested.name]
+}
+
+resource "redshift_user" "user" {
+  name     = "%[1]s_user"
+  in_roles = [redshift_role.direct.name]
+}
+
+resource "redshift_group" "group" {
+  name  = "%[1]s_group"
+  users = [redshift_user.user.name]
+}
+
+data "redshift_user_grants" "user" {
+  user = redshift_user.user.name
+
+  depends_on = [redshift_group.group]
+}
+`, prefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.redshift_user_grants.user", "id", "redshift_user.user", "id"),
+					resource.TestCheckResourceAttr("data.redshift_user_grants.user", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_user_grants.user", "groups.*", prefix+"_group"),
+					resource.TestCheckResourceAttr("data.redshift_user_grants.user", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_user_grants.user", "roles.*", prefix+"_direct"),
+					resource.TestCheckResourceAttr("data.redshift_user_grants.user", "effective_roles.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_user_grants.user", "effective_roles.*", prefix+"_direct"),
+					resource.TestCheckTypeSetElemAttr("data.redshift_user_grants.user", "effective_roles.*", prefix+"_nested"),
+				),
+			},
+		},
+	})
+}
+
+func TestResolveEffectiveRoles(t *testing.T) {
+	tests := map[string]struct {
+		roles    []string
+		grants   map[string][]string
+		maxDepth int
+		expected []string
+		err      string
+	}{
+		"no roles": {
+			roles:    []string{},
+			maxDepth: 10,
+			expected: []string{},
+		},
+		"nested roles": {
+			roles: []string{"analyst"},
+			grants: map[string][]string{
+				"analyst": {"reader"},
+				"reader":  {"public_reader"},
+			},
+			maxDepth: 10,
+			expected: []string{"analyst", "public_reader", "reader"},
+		},
+		"role granted through several roles": {
+			roles: []string{"analyst", "engineer"},
+			grants: map[string][]string{
+				"analyst":  {"reader"},
+				"engineer": {"reader", "writer"},
+				"writer":   {"reader"},
+			},
+			maxDepth: 10,
+			expected: []string{"analyst", "engineer", "reader", "writer"},
+		},
+		"cycle": {
+			roles: []string{"a"},
+			grants: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"a"},
+			},
+			maxDepth: 10,
+			expected: []string{"a", "b", "c"},
+		},
+		"maximum depth": {
+			roles: []string{"a"},
+			grants: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+			},
+			maxDepth: 3,
+			expected: []string{"a", "b", "c"},
+		},
+		"too deep": {
+			roles: []string{"a"},
+			grants: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"d"},
+			},
+			maxDepth: 3,
+			err:      `role "d" is granted to user "alice" through more than 3 levels of nested roles ([a b c]), raise max_depth to resolve it`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			grantedRoles := func(role string) ([]string, error) {
+				return tc.grants[role], nil
+			}
+
+			result, err := resolveEffectiveRoles("alice", tc.roles, grantedRoles, tc.maxDepth)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("resolveEffectiveRoles() = %v, expected %v", result, tc.expected)
+			}
+		})
+	}
+}
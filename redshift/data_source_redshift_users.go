@@ -0,0 +1,203 @@
+package redshift
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	usersAttr           = "users"
+	usersNamePrefixAttr = "name_prefix"
+	usersNameRegexAttr  = "name_regex"
+	usersIDAttr         = "id"
+)
+
+func dataSourceRedshiftUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source can be used to list the database users, e.g. to audit accounts in policy checks. The users can be filtered by their flags and their name.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftUsersRead),
+		Schema: map[string]*schema.Schema{
+			userSuperuserAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "When set, only the users which are (`true`) or aren't (`false`) superusers are returned.",
+			},
+			userCreateDBAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "When set, only the users which are (`true`) or aren't (`false`) allowed to create databases are returned.",
+			},
+			usersNamePrefixAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the users whose name starts with the prefix are returned.",
+			},
+			usersNameRegexAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only the users whose name matches the regular expression are returned. The expression uses the [Go syntax](https://pkg.go.dev/regexp/syntax).",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			usersAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users matching all the filters, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						usersIDAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user.",
+						},
+						userNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the user.",
+						},
+						userSuperuserAttr: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates whether the user is a superuser with all database privileges.",
+						},
+						userCreateDBAttr: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates whether the user is allowed to create new databases.",
+						},
+						userSyslogAccessAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The level of access that the user has to the Amazon Redshift system tables and views, `RESTRICTED` or `UNRESTRICTED`.",
+						},
+						userConnLimitAttr: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The maximum number of database connections the user is permitted to have open concurrently, -1 meaning no limit.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftUsersRead(db *DBConnection, d *schema.ResourceData) error {
+	var nameRegex *regexp.Regexp
+	if expression := d.Get(usersNameRegexAttr).(string); expression != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(expression); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", usersNameRegexAttr, expression, err)
+		}
+	}
+	namePrefix := d.Get(usersNamePrefixAttr).(string)
+	superuser := optionalBool(d, userSuperuserAttr)
+	createDB := optionalBool(d, userCreateDBAttr)
+
+	query, queryArgs := usersQuery(superuser, createDB)
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("Error reading users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []map[string]interface{}{}
+	for rows.Next() {
+		var userID, userName, userSyslogAccess, userConnLimit string
+		var userSuperuser, userCreateDB bool
+		if err := rows.Scan(&userID, &userName, &userSuperuser, &userCreateDB, &userSyslogAccess, &userConnLimit); err != nil {
+			return err
+		}
+
+		if !strings.HasPrefix(userName, namePrefix) || (nameRegex != nil && !nameRegex.MatchString(userName)) {
+			continue
+		}
+
+		userConnLimitNumber := -1
+		if userConnLimit != "UNLIMITED" {
+			if userConnLimitNumber, err = strconv.Atoi(userConnLimit); err != nil {
+				return err
+			}
+		}
+
+		users = append(users, map[string]interface{}{
+			usersIDAttr:          userID,
+			userNameAttr:         userName,
+			userSuperuserAttr:    userSuperuser,
+			userCreateDBAttr:     userCreateDB,
+			userSyslogAccessAttr: userSyslogAccess,
+			userConnLimitAttr:    userConnLimitNumber,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(generateUsersID(superuser, createDB, namePrefix, d.Get(usersNameRegexAttr).(string)))
+	d.Set(usersAttr, users)
+
+	return nil
+}
+
+// optionalBool returns the value of a boolean attribute, or nil when it isn't
+// set in the configuration.
+func optionalBool(d *schema.ResourceData, attr string) *bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || rawConfig.GetAttr(attr).IsNull() {
+		return nil
+	}
+
+	value := d.Get(attr).(bool)
+	return &value
+}
+
+// usersQuery returns the query listing the users, filtered by the flags which
+// are set. The name filters are applied to the results.
+func usersQuery(superuser, createDB *bool) (string, []interface{}) {
+	conditions := []string{}
+	queryArgs := []interface{}{}
+	for _, filter := range []struct {
+		value  *bool
+		column string
+	}{
+		{superuser, "superuser"},
+		{createDB, "createdb"},
+	} {
+		if filter.value == nil {
+			continue
+		}
+		queryArgs = append(queryArgs, *filter.value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", filter.column, len(queryArgs)))
+	}
+
+	query := "SELECT user_id, user_name, superuser, createdb, syslog_access, COALESCE(connection_limit::TEXT, 'UNLIMITED') FROM svv_user_info"
+	if len(conditions) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(conditions, " AND "))
+	}
+
+	return query + " ORDER BY user_name", queryArgs
+}
+
+func generateUsersID(superuser, createDB *bool, namePrefix, nameRegex string) string {
+	parts := []string{"users"}
+	if superuser != nil {
+		parts = append(parts, fmt.Sprintf("%s:%t", userSuperuserAttr, *superuser))
+	}
+	if createDB != nil {
+		parts = append(parts, fmt.Sprintf("%s:%t", userCreateDBAttr, *createDB))
+	}
+	if namePrefix != "" {
+		parts = append(parts, fmt.Sprintf("%s:%s", usersNamePrefixAttr, namePrefix))
+	}
+	if nameRegex != "" {
+		parts = append(parts, fmt.Sprintf("%s:%s", usersNameRegexAttr, nameRegex))
+	}
+
+	return strings.Join(parts, "_")
+}
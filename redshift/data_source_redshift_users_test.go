@@ -0,0 +1,123 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftUsers_Basic(t *testing.T) {
+	prefix := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_data_users"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_user" "regular" {
+  name = "%[1]s_regular"
+}
+
+resource "redshift_user" "creator" {
+  name            = "%[1]s_creator"
+  create_database = true
+}
+
+data "redshift_users" "prefix" {
+  name_prefix = %[1]q
+
+  depends_on = [redshift_user.regular, redshift_user.creator]
+}
+
+data "redshift_users" "creators" {
+  name_prefix     = %[1]q
+  create_database = true
+
+  depends_on = [redshift_user.regular, redshift_user.creator]
+}
+
+data "redshift_users" "regex" {
+  name_regex = "^%[1]s_reg"
+
+  depends_on = [redshift_user.regular, redshift_user.creator]
+}
+`, prefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.redshift_users.prefix", "users.#", "2"),
+					resource.TestCheckResourceAttr("data.redshift_users.prefix", "users.0.name", prefix+"_creator"),
+					resource.TestCheckResourceAttr("data.redshift_users.prefix", "users.1.name", prefix+"_regular"),
+					resource.TestCheckResourceAttrPair("data.redshift_users.prefix", "users.1.id", "redshift_user.regular", "id"),
+
+					resource.TestCheckResourceAttr("data.redshift_users.creators", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_users.creators", "users.0.name", prefix+"_creator"),
+					resource.TestCheckResourceAttr("data.redshift_users.creators", "users.0.create_database", "true"),
+
+					resource.TestCheckResourceAttr("data.redshift_users.regex", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.redshift_users.regex", "users.0.name", prefix+"_regular"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRedshiftUsers_InvalidRegex(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "redshift_users" "invalid" { name_regex = "(" }`,
+				ExpectError: regexp.MustCompile(`name_regex`),
+			},
+		},
+	})
+}
+
+func TestUsersQuery(t *testing.T) {
+	notSuperuser, createDB := false, true
+	tests := map[string]struct {
+		superuser     *bool
+		createDB      *bool
+		namePrefix    string
+		expectedQuery string
+		expectedArgs  []interface{}
+		expectedID    string
+	}{
+		"no filters": {
+			expectedQuery: "SELECT user_id, user_name, superuser, createdb, syslog_access, COALESCE(connection_limit::TEXT, 'UNLIMITED') FROM svv_user_info ORDER BY user_name",
+			expectedArgs:  []interface{}{},
+			expectedID:    "users",
+		},
+		"flags": {
+			superuser:     &notSuperuser,
+			createDB:      &createDB,
+			namePrefix:    "etl_",
+			expectedQuery: "SELECT user_id, user_name, superuser, createdb, syslog_access, COALESCE(connection_limit::TEXT, 'UNLIMITED') FROM svv_user_info WHERE superuser = $1 AND createdb = $2 ORDER BY user_name",
+			expectedArgs:  []interface{}{false, true},
+			expectedID:    "users_superuser:false_create_database:true_name_prefix:etl_",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, args := usersQuery(tt.superuser, tt.createDB)
+			if query != tt.expectedQuery {
+				t.Errorf("Expected query %q, got %q", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected arguments %v, got %v", tt.expectedArgs, args)
+			}
+			if id := generateUsersID(tt.superuser, tt.createDB, tt.namePrefix, ""); id != tt.expectedID {
+				t.Errorf("Expected ID %q, got %q", tt.expectedID, id)
+			}
+		})
+	}
+}
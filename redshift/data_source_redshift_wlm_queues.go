@@ -0,0 +1,142 @@
+package redshift
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	wlmQueuesAttr                  = "queues"
+	wlmQueueServiceClassAttr       = "service_class"
+	wlmQueueNameAttr               = "name"
+	wlmQueueSlotsAttr              = "slots"
+	wlmQueueWorkingMemoryAttr      = "working_memory"
+	wlmQueueMaxExecutionTimeAttr   = "max_execution_time"
+	wlmQueueUserGroupWildcardAttr  = "user_group_wildcard"
+	wlmQueueQueryGroupWildcardAttr = "query_group_wildcard"
+	wlmQueueConcurrencyScalingAttr = "concurrency_scaling"
+	wlmQueueQueryPriorityAttr      = "query_priority"
+)
+
+// wlmSuperuserServiceClass is the service class of the superuser queue, the
+// service classes below it are reserved for system use.
+const wlmSuperuserServiceClass = 5
+
+func dataSourceRedshiftWlmQueues() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+This data source reads the current configuration of the workload management (WLM) queues from ` + "`STV_WLM_SERVICE_CLASS_CONFIG`" + `, so that it can be referenced by other resources, e.g. to set the ` + "`query_group`" + ` of users. The configuration itself is managed through the parameter group of the cluster, as Redshift doesn't allow changing it with SQL. The superuser queue and the queues defined by the WLM configuration are returned, the queues reserved for system use are not.
+`,
+		ReadContext: RedshiftResourceFunc(dataSourceRedshiftWlmQueuesRead),
+		Schema: map[string]*schema.Schema{
+			wlmQueuesAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The WLM queues, ordered by service class.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						wlmQueueServiceClassAttr: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the service class of the queue. Service class 5 is the superuser queue, the queues defined by manual WLM start at 6 and those of automatic WLM at 100.",
+						},
+						wlmQueueNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the queue.",
+						},
+						wlmQueueSlotsAttr: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of queries which can run concurrently in the queue. Set to -1 when it is managed by automatic WLM.",
+						},
+						wlmQueueWorkingMemoryAttr: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The amount of memory in MB allocated to each slot of the queue. Set to -1 when it is managed by automatic WLM.",
+						},
+						wlmQueueMaxExecutionTimeAttr: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The time in milliseconds a query may run before it is canceled. Zero means no timeout.",
+						},
+						wlmQueueUserGroupWildcardAttr: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether wildcards are enabled in the user groups assigned to the queue.",
+						},
+						wlmQueueQueryGroupWildcardAttr: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether wildcards are enabled in the query groups assigned to the queue.",
+						},
+						wlmQueueConcurrencyScalingAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether concurrency scaling is `on` or `off` for the queue.",
+						},
+						wlmQueueQueryPriorityAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The priority of the queries running in the queue, e.g. `normal`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRedshiftWlmQueuesRead(db *DBConnection, d *schema.ResourceData) error {
+	rows, err := db.Query(`
+  SELECT
+    service_class,
+    TRIM(name),
+    num_query_tasks,
+    query_working_mem,
+    max_execution_time,
+    user_group_wild_card,
+    query_group_wild_card,
+    TRIM(concurrency_scaling),
+    TRIM(query_priority)
+  FROM stv_wlm_service_class_config
+  WHERE service_class >= $1
+  ORDER BY service_class
+`, wlmSuperuserServiceClass)
+	if err != nil {
+		return fmt.Errorf("Error reading WLM queues: %w", err)
+	}
+	defer rows.Close()
+
+	queues := []map[string]interface{}{}
+	for rows.Next() {
+		var serviceClass, slots, workingMemory, maxExecutionTime int
+		var name, concurrencyScaling, queryPriority string
+		var userGroupWildcard, queryGroupWildcard bool
+
+		if err := rows.Scan(&serviceClass, &name, &slots, &workingMemory, &maxExecutionTime, &userGroupWildcard, &queryGroupWildcard, &concurrencyScaling, &queryPriority); err != nil {
+			return err
+		}
+
+		queues = append(queues, map[string]interface{}{
+			wlmQueueServiceClassAttr:       serviceClass,
+			wlmQueueNameAttr:               name,
+			wlmQueueSlotsAttr:              slots,
+			wlmQueueWorkingMemoryAttr:      workingMemory,
+			wlmQueueMaxExecutionTimeAttr:   maxExecutionTime,
+			wlmQueueUserGroupWildcardAttr:  userGroupWildcard,
+			wlmQueueQueryGroupWildcardAttr: queryGroupWildcard,
+			wlmQueueConcurrencyScalingAttr: concurrencyScaling,
+			wlmQueueQueryPriorityAttr:      queryPriority,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.SetId(db.client.config.Host)
+	d.Set(wlmQueuesAttr, queues)
+
+	return nil
+}
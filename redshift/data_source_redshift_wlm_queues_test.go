@@ -0,0 +1,25 @@
+package redshift
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRedshiftWlmQueues_Basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "redshift_wlm_queues" "current" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.redshift_wlm_queues.current", "queues.#"),
+					// The superuser queue always comes first
+					resource.TestCheckResourceAttr("data.redshift_wlm_queues.current", "queues.0.service_class", "5"),
+					resource.TestCheckResourceAttrSet("data.redshift_wlm_queues.current", "queues.0.name"),
+				),
+			},
+		},
+	})
+}
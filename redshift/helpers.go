@@ -3,17 +3,27 @@ package redshift
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"log"
+	"net"
+	"regexp"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
 )
 
+// clusterWaitMaxDelay is the longest delay between two attempts to connect
+// while waiting for the cluster.
+const clusterWaitMaxDelay = time.Minute
+
 const (
 	pqErrorCodeConcurrent        = "XX000"
 	pqErrorCodeInvalidSchemaName = "3F000"
@@ -21,17 +31,143 @@ const (
 	pqErrorCodeFailedTransaction = "25P02"
 	pqErrorCodeDuplicateSchema   = "42P06"
 
+	pqErrorCodeDependentObjectsStillExist = "2BP01"
+
 	pgErrorCodeInsufficientPrivileges = "42501"
+	pqErrorCodeUndefinedTable         = "42P01"
+	pqErrorCodeSyntaxError            = "42601"
+
+	pqErrorCodeDuplicateDatabase = "42P04"
+	pqErrorCodeDuplicateTable    = "42P07"
+	pqErrorCodeDuplicateObject   = "42710"
+	pqErrorCodeDuplicateFunction = "42723"
+
+	pqErrorClassConnectionException = "08"
+	pqErrorCodeCannotConnectNow     = "57P03"
+	pqErrorCodeQueryCanceled        = "57014"
 )
 
-// startTransaction starts a new DB transaction on the specified database.
-// If the database is specified and different from the one configured in the provider,
-// it will create a new connection pool if needed.
-func startTransaction(client *Client, database string) (*sql.Tx, error) {
-	if database != "" && database != client.databaseName {
-		client = client.config.NewClient(database)
+// generatedSQLAttr is the computed attribute previewing the statements run to
+// apply the planned changes of a resource.
+const generatedSQLAttr = "generated_sql"
+
+// publicNameRegexp matches PUBLIC, the grantee including all the users, and
+// the public schema.
+var publicNameRegexp = regexp.MustCompile("^(?i)public$")
+
+// redactedPasswordRegexp matches the password literals of CREATE and ALTER USER
+// statements.
+var redactedPasswordRegexp = regexp.MustCompile(`(?i)PASSWORD\s+'(?:[^']|'')*'`)
+
+// resourceValues is satisfied by both *schema.ResourceData and
+// *schema.ResourceDiff, so that the statements of a resource can be rendered
+// when planning as well as when applying.
+type resourceValues interface {
+	Id() string
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+	HasChanges(keys ...string) bool
+}
+
+// sqlExecutor runs statements, either in a transaction or in a statementRecorder.
+type sqlExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// statementRecorder records the statements instead of running them, with the
+// passwords redacted.
+type statementRecorder struct {
+	statements []string
+}
+
+func (r *statementRecorder) Exec(query string, _ ...interface{}) (sql.Result, error) {
+	r.statements = append(r.statements, redactedPasswordRegexp.ReplaceAllString(query, "PASSWORD '***'"))
+	return driver.RowsAffected(0), nil
+}
+
+func (r *statementRecorder) String() string {
+	if len(r.statements) == 0 {
+		return ""
+	}
+	return strings.Join(r.statements, ";\n") + ";"
+}
+
+func generatedSQLSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The statements the provider runs to apply the planned changes, with passwords redacted. It's shown in the plan for review and is unknown when the statements depend on values known only after apply.",
+	}
+}
+
+// customizeGeneratedSQL sets generated_sql to the statements that generate
+// records for the planned changes. It's left as is when nothing changes.
+func customizeGeneratedSQL(generate func(tx sqlExecutor, d *schema.ResourceDiff, meta interface{}) error) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+			return nil
+		}
+		if rawConfig := d.GetRawConfig(); !rawConfig.IsWhollyKnown() {
+			return d.SetNewComputed(generatedSQLAttr)
+		}
+
+		recorder := &statementRecorder{}
+		if err := generate(recorder, d, meta); err != nil {
+			return err
+		}
+
+		return d.SetNew(generatedSQLAttr, recorder.String())
+	}
+}
+
+// validateSchemaReference checks when planning that the schema set in attr
+// exists, when the provider's validate_references is set. Unknown and empty
+// schemas, and schemas that don't change, aren't checked.
+func validateSchemaReference(attr string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown(attr) || (d.Id() != "" && !d.HasChange(attr)) {
+			return nil
+		}
+		client, ok := meta.(*Client)
+		if !ok {
+			return nil
+		}
+
+		return checkSchemaReference(ctx, client, attr, d.Get(attr).(string))
+	}
+}
+
+func checkSchemaReference(ctx context.Context, client *Client, attr, schemaName string) error {
+	if !client.config.ValidateReferences || schemaName == "" {
+		return nil
+	}
+
+	conn, err := client.Connect()
+	if err != nil {
+		return fmt.Errorf("could not connect to validate %s: %w", attr, err)
+	}
+	db := conn.withContext(ctx)
+
+	var exists bool
+	// Names are compared regardless of case, which is only about typos.
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE lower(nspname) = lower($1))", schemaName).Scan(&exists); err != nil {
+		return fmt.Errorf("could not validate %s: %w", attr, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: schema %q doesn't exist in database %s", attr, schemaName, client.databaseName)
 	}
-	db, err := client.Connect()
+
+	return nil
+}
+
+// startTransaction starts a new DB transaction on the specified database.
+// If the database is specified and different from the one db is connected to,
+// it will create a new connection pool if needed. The transaction is rolled
+// back and its statements are canceled when the context of db is done.
+func startTransaction(db *DBConnection, database string) (*DBTransaction, error) {
+	db, err := connectToDatabase(db, database)
 	if err != nil {
 		return nil, err
 	}
@@ -44,9 +180,25 @@ func startTransaction(client *Client, database string) (*sql.Tx, error) {
 	return txn, nil
 }
 
+// connectToDatabase returns a connection to the specified database, or db itself
+// when the database is empty or the one db is connected to.
+// The returned connection uses the context of db.
+func connectToDatabase(db *DBConnection, database string) (*DBConnection, error) {
+	if database == "" || database == db.client.databaseName {
+		return db, nil
+	}
+
+	conn, err := db.client.config.NewClient(database).Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.withContext(db.ctx), nil
+}
+
 // deferredRollback can be used to rollback a transaction in a defer.
 // It will log an error if it fails
-func deferredRollback(txn *sql.Tx) {
+func deferredRollback(txn *DBTransaction) {
 	err := txn.Rollback()
 	switch {
 	case err == sql.ErrTxDone:
@@ -67,26 +219,71 @@ func pqQuoteLiteral(in string) string {
 	return in
 }
 
-func getGroupIDFromName(tx *sql.Tx, group string) (groupID int, err error) {
+func getGroupIDFromName(tx *DBTransaction, group string) (groupID int, err error) {
 	err = tx.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", group).Scan(&groupID)
 	return
 }
 
-func getUserIDFromName(tx *sql.Tx, user string) (userID int, err error) {
+func getUserIDFromName(tx *DBTransaction, user string) (userID int, err error) {
 	err = tx.QueryRow("SELECT usesysid FROM pg_user WHERE usename = $1", user).Scan(&userID)
 	return
 }
 
-func getSchemaIDFromName(tx *sql.Tx, schema string) (schemaID int, err error) {
+// checkOwnerExists returns a clear error when the user about to become the
+// owner of an object doesn't exist, which usually means the user resource
+// isn't referenced and is created after the object.
+func checkOwnerExists(tx *DBTransaction, owner string) error {
+	_, err := getUserIDFromName(tx, strings.ToLower(owner))
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("User %q does not exist, create it before making it the owner, e.g. by referencing the redshift_user resource in the owner attribute", owner)
+	case err != nil:
+		return fmt.Errorf("Error reading user %s: %w", owner, err)
+	}
+
+	return nil
+}
+
+// runAsOwner sets the session authorization to owner until the end of the
+// transaction, so that the objects created in it are owned by owner from the
+// start. The authorization isn't reset explicitly, as RESET would drop the
+// assume_user of the session too.
+func runAsOwner(tx *DBTransaction, owner string) error {
+	if err := checkOwnerExists(tx, owner); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("SET LOCAL SESSION AUTHORIZATION '%s'", pqQuoteLiteral(owner))); err != nil {
+		if isPermissionDenied(err) {
+			return fmt.Errorf("could not create the object as %s, only superusers can set the session authorization: %w", owner, err)
+		}
+		return fmt.Errorf("could not set the session authorization to %s: %w", owner, err)
+	}
+
+	return nil
+}
+
+// validateNotPublic rejects PUBLIC as the name of a user, group or role: it's
+// the keyword granting privileges to all the users, so such a grantee could
+// never be told apart from it in GRANT and REVOKE statements.
+func validateNotPublic(kind string) schema.SchemaValidateFunc {
+	return validation.StringDoesNotMatch(publicNameRegexp, fmt.Sprintf("%s name cannot be 'public', it's reserved for the PUBLIC grantee, which includes all the users", kind))
+}
+
+// ownerDiffSuppress ignores differences in case between owner names, as
+// Redshift folds unquoted user names to lowercase.
+func ownerDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func getSchemaIDFromName(tx *DBTransaction, schema string) (schemaID int, err error) {
 	err = tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", schema).Scan(&schemaID)
 	return
 }
 
 func RedshiftResourceFunc(fn func(*DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
-	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-		client := meta.(*Client)
-
-		db, err := client.Connect()
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		db, err := connectWithRetries(ctx, meta.(*Client))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -103,7 +300,8 @@ func RedshiftResourceRetryOnPQErrors(fn func(*DBConnection, *schema.ResourceData
 				return nil
 			}
 
-			if pqErr, ok := err.(*pq.Error); !ok || !isRetryablePQError(string(pqErr.Code)) {
+			var pqErr *pq.Error
+			if !errors.As(err, &pqErr) || !isRetryablePQError(string(pqErr.Code)) {
 				return err
 			}
 
@@ -115,9 +313,8 @@ func RedshiftResourceRetryOnPQErrors(fn func(*DBConnection, *schema.ResourceData
 
 func RedshiftResourceExistsFunc(fn func(*DBConnection, *schema.ResourceData) (bool, error)) func(*schema.ResourceData, interface{}) (bool, error) {
 	return func(d *schema.ResourceData, meta interface{}) (bool, error) {
-		client := meta.(*Client)
-
-		db, err := client.Connect()
+		// Terraform doesn't pass the context of the operation to Exists.
+		db, err := connectWithRetries(context.Background(), meta.(*Client))
 		if err != nil {
 			return false, err
 		}
@@ -126,6 +323,100 @@ func RedshiftResourceExistsFunc(fn func(*DBConnection, *schema.ResourceData) (bo
 	}
 }
 
+// connectWithRetries returns the connection pool once Redshift accepts a
+// connection, running its statements with ctx. Only connecting is retried: the
+// statements run afterwards mustn't be, as they may have been applied before
+// the connection was lost.
+func connectWithRetries(ctx context.Context, client *Client) (*DBConnection, error) {
+	var db *DBConnection
+	err := retryOnConnectionErrors(client.config, func() error {
+		var err error
+		if db, err = client.Connect(); err != nil {
+			return err
+		}
+
+		return db.PingContext(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.withContext(ctx), nil
+}
+
+// retryOnConnectionErrors runs fn again with an exponential backoff when it
+// fails because Redshift can't be reached, e.g. while the cluster is resuming.
+// fn must be safe to run again, e.g. only connect.
+func retryOnConnectionErrors(config Config, fn func() error) error {
+	if config.WaitForCluster {
+		return waitForCluster(config, fn)
+	}
+
+	delay := config.ConnectionRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryableConnectionError(err) {
+			return err
+		}
+		if attempt >= config.MaxConnectionRetries {
+			return fmt.Errorf("could not reach Redshift at %s, the cluster may be paused or resuming. Set wait_for_cluster to wait until it accepts connections: %w", config.Host, err)
+		}
+
+		log.Printf("[WARN] Could not reach Redshift, retrying in %s: %v", delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// waitForCluster runs fn again until Redshift accepts connections or
+// WaitForClusterTimeout elapsed, doubling the delay between the attempts up to
+// clusterWaitMaxDelay.
+func waitForCluster(config Config, fn func() error) error {
+	start := time.Now()
+	delay := config.ConnectionRetryDelay
+	for {
+		err := fn()
+		if err == nil || !isRetryableConnectionError(err) {
+			return err
+		}
+
+		waited := time.Since(start)
+		if waited+delay > config.WaitForClusterTimeout {
+			return fmt.Errorf("Redshift at %s didn't accept connections within wait_for_cluster_timeout (%s): %w", config.Host, config.WaitForClusterTimeout, err)
+		}
+
+		log.Printf("[WARN] Waiting for Redshift at %s to accept connections for %s, retrying in %s: %v", config.Host, waited.Round(time.Second), delay, err)
+		time.Sleep(delay)
+		delay *= 2
+		if delay > clusterWaitMaxDelay {
+			delay = clusterWaitMaxDelay
+		}
+	}
+}
+
+// isRetryableConnectionError reports whether err means the connection to
+// Redshift failed, as opposed to an error returned for the statement itself.
+func isRetryableConnectionError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Class() == pqErrorClassConnectionException ||
+			pqErr.Code == pqErrorCodeCannotConnectNow ||
+			strings.Contains(strings.ToLower(pqErr.Message), "cluster is resuming")
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	// Connecting to a paused cluster times out.
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" && opErr.Timeout() {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "connection refused") || strings.Contains(message, "cluster is resuming")
+}
+
 func isRetryablePQError(code string) bool {
 	retryable := map[string]bool{
 		pqErrorCodeConcurrent:        true,
@@ -138,8 +429,86 @@ func isRetryablePQError(code string) bool {
 	return ok
 }
 
+// statementError is returned by DBConnection and DBTransaction when Redshift
+// rejects a statement, so that callers can tell the SQLSTATE code and the
+// statement apart from the message.
+type statementError struct {
+	err       *pq.Error
+	statement string
+}
+
+// newStatementError wraps the errors returned by Redshift, other errors are
+// returned as is. Passwords are redacted from the statement.
+func newStatementError(err error, statement string) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	return &statementError{
+		err:       pqErr,
+		statement: redactedPasswordRegexp.ReplaceAllString(statement, "PASSWORD '***'"),
+	}
+}
+
+func (e *statementError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statementError) Unwrap() error {
+	return e.err
+}
+
+// Code returns the SQLSTATE code of the error, e.g. 42501 for permission denied.
+func (e *statementError) Code() string {
+	return string(e.err.Code)
+}
+
+// Statement returns the statement rejected by Redshift.
+func (e *statementError) Statement() string {
+	return e.statement
+}
+
+// sqlState returns the SQLSTATE code of the error, or an empty string when it
+// wasn't returned by Redshift.
+func sqlState(err error) string {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return ""
+	}
+	return string(pqErr.Code)
+}
+
 func isPqErrorWithCode(err error, code string) bool {
-	return string(err.(*pq.Error).Code) == code
+	return err != nil && sqlState(err) == code
+}
+
+// isPermissionDenied reports whether the provider's user lacks the privileges
+// to run the statement.
+func isPermissionDenied(err error) bool {
+	return isPqErrorWithCode(err, pgErrorCodeInsufficientPrivileges)
+}
+
+// isDuplicate reports whether the statement failed because the object it
+// creates already exists.
+func isDuplicate(err error) bool {
+	switch sqlState(err) {
+	case pqErrorCodeDuplicateDatabase, pqErrorCodeDuplicateSchema, pqErrorCodeDuplicateTable, pqErrorCodeDuplicateObject, pqErrorCodeDuplicateFunction:
+		return true
+	}
+	return false
+}
+
+// createObjectError tells what to do when creating an object failed because it
+// already exists or because the provider's user isn't allowed to create it.
+func createObjectError(err error, resourceType string) error {
+	switch {
+	case isDuplicate(err):
+		return fmt.Errorf("%w, import it with `terraform import %s.<name> <id>` to manage it instead of creating it", err, resourceType)
+	case isPermissionDenied(err):
+		return fmt.Errorf("%w, the provider's user must be a superuser or be granted the privileges to create it", err)
+	}
+	return err
 }
 
 func splitCsvAndTrim(raw string) ([]string, error) {
@@ -161,6 +530,39 @@ func splitCsvAndTrim(raw string) ([]string, error) {
 	return result, nil
 }
 
+// storedIdentifiers returns the names Redshift stores the quoted identifiers
+// as in the session of the transaction. Redshift folds identifiers to
+// lowercase, quoted ones too unless enable_case_sensitive_identifier is on. The
+// provider quotes every identifier, so that objects keep the case of the
+// configuration when Redshift preserves it.
+func storedIdentifiers(tx *DBTransaction, names ...string) ([]string, error) {
+	var caseSensitive string
+	if err := tx.QueryRow("SELECT current_setting('enable_case_sensitive_identifier')").Scan(&caseSensitive); err != nil {
+		return nil, fmt.Errorf("could not read enable_case_sensitive_identifier: %w", err)
+	}
+
+	stored := make([]string, len(names))
+	for i, name := range names {
+		stored[i] = name
+		if caseSensitive != "on" {
+			stored[i] = strings.ToLower(name)
+		}
+	}
+	return stored, nil
+}
+
+// suppressIdentifierCaseDiff ignores changes of the case of a name only, as
+// Redshift reports the lowercased name unless it preserves case.
+func suppressIdentifierCaseDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// hashIdentifier hashes names regardless of their case, so that sets of names
+// match the names Redshift reports whether it folds them or not.
+func hashIdentifier(v interface{}) int {
+	return schema.HashString(strings.ToLower(v.(string)))
+}
+
 func validatePrivileges(privileges []string, objectType string) bool {
 	if objectType == "language" && len(privileges) == 0 {
 		return false
@@ -183,7 +585,7 @@ func validatePrivileges(privileges []string, objectType string) bool {
 			}
 		case "DATABASE":
 			switch strings.ToUpper(p) {
-			case "CREATE", "TEMPORARY":
+			case "CREATE", "TEMPORARY", "TEMP", "USAGE":
 				continue
 			default:
 				return false
@@ -248,7 +650,7 @@ func stripArgumentsFromCallablesDefinitions(defs *schema.Set) []string {
 		return strings.Split(name, "(")[0]
 	}
 
-	names := make([]string, defs.Len())
+	names := make([]string, 0, defs.Len())
 	for _, def := range defs.List() {
 		names = append(names, parser(def.(string)))
 	}
@@ -1,7 +1,22 @@
 package redshift
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestValidatePrivileges(t *testing.T) {
@@ -30,6 +45,16 @@ func TestValidatePrivileges(t *testing.T) {
 			objectType: "schema",
 			expected:   true,
 		},
+		"valid list for database": {
+			privileges: []string{"create", "temp", "usage"},
+			objectType: "database",
+			expected:   true,
+		},
+		"invalid list for database": {
+			privileges: []string{"create", "select"},
+			objectType: "database",
+			expected:   false,
+		},
 		"valid list for table": {
 			privileges: []string{"insert", "update", "delete", "select", "drop", "references", "rule", "trigger"},
 			objectType: "table",
@@ -112,3 +137,570 @@ func TestValidatePrivileges(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRetryableConnectionError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"connection refused":     {&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		"wrapped bad connection": {fmt.Errorf("could not start transaction: %w", driver.ErrBadConn), true},
+		"connection exception":   {&pq.Error{Code: "08006", Message: "connection failure"}, true},
+		"cluster is resuming":    {&pq.Error{Code: "XX000", Message: "The cluster is resuming"}, true},
+		"dial timeout":           {&net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}, true},
+		"syntax error":           {&pq.Error{Code: "42601", Message: "syntax error at or near \"SELEC\""}, false},
+		"permission denied":      {fmt.Errorf("Error reading View: %w", &pq.Error{Code: "42501", Message: "permission denied for relation t"}), false},
+		"other error":            {fmt.Errorf("Username is required"), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := isRetryableConnectionError(tc.err); result != tc.expected {
+				t.Errorf("isRetryableConnectionError(%v) = %t, expected %t", tc.err, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRetryOnConnectionErrors(t *testing.T) {
+	config := Config{MaxConnectionRetries: 3, ConnectionRetryDelay: time.Millisecond}
+
+	attempts := 0
+	err := retryOnConnectionErrors(config, func() error {
+		attempts++
+		if attempts < 3 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Errorf("Expected success after 3 attempts, got %d attempts and error %v", attempts, err)
+	}
+
+	attempts = 0
+	err = retryOnConnectionErrors(config, func() error {
+		attempts++
+		return driver.ErrBadConn
+	})
+	if err == nil || attempts != 4 {
+		t.Errorf("Expected error after 4 attempts, got %d attempts and error %v", attempts, err)
+	}
+
+	attempts = 0
+	err = retryOnConnectionErrors(config, func() error {
+		attempts++
+		return &pq.Error{Code: "42601", Message: "syntax error"}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected syntax error not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryOnConnectionErrorsSuggestsWaitForCluster(t *testing.T) {
+	config := Config{Host: "cluster.example.com", MaxConnectionRetries: 1, ConnectionRetryDelay: time.Millisecond}
+
+	err := retryOnConnectionErrors(config, func() error {
+		return driver.ErrBadConn
+	})
+	if err == nil || !strings.Contains(err.Error(), "Set wait_for_cluster") || !errors.Is(err, driver.ErrBadConn) {
+		t.Errorf("Expected the error to suggest wait_for_cluster and wrap the connection error, got %v", err)
+	}
+}
+
+func TestWaitForCluster(t *testing.T) {
+	config := Config{
+		MaxConnectionRetries:  1,
+		ConnectionRetryDelay:  time.Millisecond,
+		WaitForCluster:        true,
+		WaitForClusterTimeout: time.Second,
+	}
+
+	// The attempts aren't limited by MaxConnectionRetries.
+	attempts := 0
+	err := retryOnConnectionErrors(config, func() error {
+		attempts++
+		if attempts < 5 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	if err != nil || attempts != 5 {
+		t.Errorf("Expected success after 5 attempts, got %d attempts and error %v", attempts, err)
+	}
+
+	config.WaitForClusterTimeout = 10 * time.Millisecond
+	err = retryOnConnectionErrors(config, func() error {
+		return driver.ErrBadConn
+	})
+	if err == nil || !strings.Contains(err.Error(), "didn't accept connections within wait_for_cluster_timeout") {
+		t.Errorf("Expected a timeout error, got %v", err)
+	}
+
+	attempts = 0
+	err = retryOnConnectionErrors(config, func() error {
+		attempts++
+		return &pq.Error{Code: "42601", Message: "syntax error"}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected syntax error not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRedshiftResourceRetryOnPQErrorsUnwrapsErrors(t *testing.T) {
+	calls := 0
+	fn := RedshiftResourceRetryOnPQErrors(func(db *DBConnection, d *schema.ResourceData) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("could not revoke privileges: %w", &pq.Error{Code: pqErrorCodeDeadlock})
+		}
+		return nil
+	})
+
+	if err := fn(nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the wrapped deadlock to be retried once, got %d calls", calls)
+	}
+}
+
+// refusingConnector refuses the first connections, like a resuming cluster.
+type refusingConnector struct {
+	fakeConnector
+	refusals *int
+}
+
+func (c refusingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if *c.refusals > 0 {
+		*c.refusals--
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	}
+	return c.fakeConnector.Connect(ctx)
+}
+
+func TestRedshiftResourceFuncOnlyRetriesConnecting(t *testing.T) {
+	config := Config{Host: "retry.example.com", Port: 5439, Database: "redshift", MaxConnectionRetries: 3, ConnectionRetryDelay: time.Millisecond}
+	client := config.NewClient("redshift")
+
+	var statements []string
+	refusals := 2
+	dbRegistryLock.Lock()
+	key := config.connStr("redshift")
+	dbRegistry[key] = &DBConnection{
+		DB:     sql.OpenDB(refusingConnector{fakeConnector{statements: &statements}, &refusals}),
+		client: client,
+	}
+	dbRegistryLock.Unlock()
+	defer func() {
+		dbRegistryLock.Lock()
+		dbRegistry[key].Close()
+		delete(dbRegistry, key)
+		dbRegistryLock.Unlock()
+	}()
+
+	calls := 0
+	diags := RedshiftResourceFunc(func(db *DBConnection, d *schema.ResourceData) error {
+		calls++
+		if _, err := db.Exec("GRANT USAGE ON SCHEMA analytics TO analyst"); err != nil {
+			return err
+		}
+		return fmt.Errorf("connection lost: %w", syscall.ECONNRESET)
+	})(context.Background(), nil, client)
+
+	if refusals != 0 {
+		t.Errorf("Expected connecting to be retried until Redshift accepts connections")
+	}
+	if !diags.HasError() || calls != 1 || len(statements) != 1 {
+		t.Errorf("Expected the statements not to be run again after losing the connection, got %d calls running %v", calls, statements)
+	}
+}
+
+func TestRedshiftResourceFuncUsesOperationContext(t *testing.T) {
+	config := Config{Host: "context.example.com", Port: 5439, Database: "redshift", ConnectionRetryDelay: time.Millisecond}
+	client := config.NewClient("redshift")
+
+	var statements []string
+	dbRegistryLock.Lock()
+	key := config.connStr("redshift")
+	dbRegistry[key] = &DBConnection{DB: sql.OpenDB(fakeConnector{statements: &statements}), client: client}
+	dbRegistryLock.Unlock()
+	defer func() {
+		dbRegistryLock.Lock()
+		dbRegistry[key].Close()
+		delete(dbRegistry, key)
+		dbRegistryLock.Unlock()
+	}()
+
+	run := func(ctx context.Context, cancel context.CancelFunc) diag.Diagnostics {
+		return RedshiftResourceFunc(func(db *DBConnection, d *schema.ResourceData) error {
+			if cancel != nil {
+				cancel()
+			}
+			_, err := db.Exec("VACUUM")
+			return err
+		})(ctx, nil, client)
+	}
+
+	// Cancelling the operation cancels its statements.
+	ctx, cancel := context.WithCancel(context.Background())
+	if diags := run(ctx, cancel); !diags.HasError() || !strings.Contains(diags[0].Summary, context.Canceled.Error()) {
+		t.Errorf("Expected the statement to be canceled, got %v", diags)
+	}
+
+	// An operation canceled already doesn't run anything.
+	if diags := run(ctx, nil); !diags.HasError() {
+		t.Errorf("Expected an error for a canceled operation")
+	}
+	if len(statements) != 0 {
+		t.Errorf("Expected no statement to run with a canceled context, got %v", statements)
+	}
+}
+
+func TestStripArgumentsFromCallablesDefinitions(t *testing.T) {
+	defs := schema.NewSet(schema.HashString, []interface{}{"test_call(integer)"})
+
+	result := stripArgumentsFromCallablesDefinitions(defs)
+	if len(result) != 1 || result[0] != "test_call" {
+		t.Errorf("Expected [test_call], got %q", result)
+	}
+}
+
+func TestStatementRecorderRedactsPasswords(t *testing.T) {
+	recorder := &statementRecorder{}
+	for _, query := range []string{
+		`CREATE USER "foo" WITH PASSWORD 'it''s secret' CONNECTION LIMIT UNLIMITED`,
+		`ALTER USER "foo" password 'md5c4b4a0a6b9e03ad5e1d523fa0a3e6c7c'`,
+		`ALTER USER "foo" PASSWORD DISABLE`,
+	} {
+		if _, err := recorder.Exec(query); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	expected := `CREATE USER "foo" WITH PASSWORD '***' CONNECTION LIMIT UNLIMITED;
+ALTER USER "foo" PASSWORD '***';
+ALTER USER "foo" PASSWORD DISABLE;`
+	if recorder.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, recorder.String())
+	}
+}
+
+func TestCustomizeGeneratedSQL(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		userNameAttr:       "foo",
+		userPasswordAttr:   "Secret123",
+		userSearchPathAttr: []interface{}{"public"},
+	})
+
+	diff, err := redshiftUser().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `CREATE USER "foo" WITH PASSWORD '***' VALID UNTIL 'infinity' SYSLOG ACCESS RESTRICTED CONNECTION LIMIT UNLIMITED NOCREATEUSER NOCREATEDB;
+ALTER USER "foo" SET search_path TO 'public';`
+	if actual := diff.Attributes[generatedSQLAttr].New; actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+}
+
+func TestStatementError(t *testing.T) {
+	pqErr := &pq.Error{Code: pgErrorCodeInsufficientPrivileges, Message: "permission denied"}
+	err := newStatementError(pqErr, `CREATE USER "foo" WITH PASSWORD 'secret'`)
+
+	var stmtErr *statementError
+	if !errors.As(err, &stmtErr) {
+		t.Fatalf("Expected a statementError, got %T", err)
+	}
+	if stmtErr.Code() != pgErrorCodeInsufficientPrivileges {
+		t.Errorf("Expected code %s, got %s", pgErrorCodeInsufficientPrivileges, stmtErr.Code())
+	}
+	if expected := `CREATE USER "foo" WITH PASSWORD '***'`; stmtErr.Statement() != expected {
+		t.Errorf("Expected statement %q, got %q", expected, stmtErr.Statement())
+	}
+	if err.Error() != pqErr.Error() {
+		t.Errorf("Expected message %q, got %q", pqErr.Error(), err.Error())
+	}
+	if !errors.Is(err, pqErr) {
+		t.Errorf("Expected the error to wrap the pq error")
+	}
+
+	if err := newStatementError(driver.ErrBadConn, "SELECT 1"); err != driver.ErrBadConn {
+		t.Errorf("Expected other errors to be returned as is, got %v", err)
+	}
+	if err := newStatementError(nil, "SELECT 1"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestErrorCodeHelpers(t *testing.T) {
+	cases := map[string]struct {
+		err              error
+		permissionDenied bool
+		duplicate        bool
+	}{
+		"permission denied": {
+			err:              newStatementError(&pq.Error{Code: pgErrorCodeInsufficientPrivileges}, "CREATE SCHEMA foo"),
+			permissionDenied: true,
+		},
+		"duplicate object": {
+			err:       fmt.Errorf("could not create: %w", newStatementError(&pq.Error{Code: pqErrorCodeDuplicateObject}, "CREATE USER foo")),
+			duplicate: true,
+		},
+		"duplicate schema": {
+			err:       &pq.Error{Code: pqErrorCodeDuplicateSchema},
+			duplicate: true,
+		},
+		"other pq error": {
+			err: &pq.Error{Code: pqErrorCodeDeadlock},
+		},
+		"not a pq error": {
+			err: fmt.Errorf("connection refused"),
+		},
+		"no error": {},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := isPermissionDenied(c.err); actual != c.permissionDenied {
+				t.Errorf("Expected isPermissionDenied to be %t, got %t", c.permissionDenied, actual)
+			}
+			if actual := isDuplicate(c.err); actual != c.duplicate {
+				t.Errorf("Expected isDuplicate to be %t, got %t", c.duplicate, actual)
+			}
+		})
+	}
+}
+
+func TestCreateObjectError(t *testing.T) {
+	err := createObjectError(&pq.Error{Code: pqErrorCodeDuplicateObject, Message: `user "foo" already exists`}, "redshift_user")
+	if !strings.Contains(err.Error(), "terraform import redshift_user.") || !isDuplicate(err) {
+		t.Errorf("Expected a hint to import the user, got %q", err)
+	}
+
+	err = createObjectError(&pq.Error{Code: pgErrorCodeInsufficientPrivileges, Message: "permission denied"}, "redshift_user")
+	if !strings.Contains(err.Error(), "superuser") || !isPermissionDenied(err) {
+		t.Errorf("Expected a hint about privileges, got %q", err)
+	}
+
+	other := &pq.Error{Code: pqErrorCodeDeadlock}
+	if err := createObjectError(other, "redshift_user"); err != other {
+		t.Errorf("Expected other errors to be returned as is, got %v", err)
+	}
+}
+
+func TestPublicNameRejected(t *testing.T) {
+	tests := map[string]*schema.Schema{
+		"user":  redshiftUser().Schema[userNameAttr],
+		"group": redshiftGroup().Schema[groupNameAttr],
+		"role":  redshiftRole().Schema[roleNameAttr],
+	}
+
+	for name, s := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, value := range []string{"public", "PUBLIC", "Public"} {
+				if _, errs := s.ValidateFunc(value, "name"); len(errs) == 0 {
+					t.Errorf("expected %q to be rejected as a %s name", value, name)
+				}
+			}
+			for _, value := range []string{"public_readers", "not_public"} {
+				if _, errs := s.ValidateFunc(value, "name"); len(errs) != 0 {
+					t.Errorf("expected %q to be accepted as a %s name, got %v", value, name, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSchemaReferenceSkipped(t *testing.T) {
+	// The client can't connect, so any lookup would fail.
+	tests := map[string]struct {
+		validateReferences bool
+		schemaName         string
+	}{
+		"disabled":     {false, "sales"},
+		"empty schema": {true, ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &Client{config: Config{ValidateReferences: tc.validateReferences}}
+			if err := checkSchemaReference(context.Background(), client, "schema", tc.schemaName); err != nil {
+				t.Errorf("Expected the schema not to be looked up, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIdentifierCaseDiffSuppressed(t *testing.T) {
+	tests := map[string]struct {
+		resource *schema.Resource
+		state    map[string]interface{}
+		config   map[string]interface{}
+	}{
+		"table columns": {
+			resource: redshiftTable(),
+			state: map[string]interface{}{
+				tableNameAttr:    "mytable",
+				tableSchemaAttr:  "analytics",
+				tableDistKeyAttr: "customerid",
+				tableSortKeyAttr: []interface{}{"customerid"},
+				tableColumnAttr: []interface{}{
+					map[string]interface{}{tableColumnNameAttr: "customerid", tableColumnTypeAttr: "integer"},
+				},
+			},
+			config: map[string]interface{}{
+				tableNameAttr:    "MyTable",
+				tableSchemaAttr:  "Analytics",
+				tableDistKeyAttr: "CustomerId",
+				tableSortKeyAttr: []interface{}{"CustomerId"},
+				tableColumnAttr: []interface{}{
+					map[string]interface{}{tableColumnNameAttr: "CustomerId", tableColumnTypeAttr: "integer"},
+				},
+			},
+		},
+		"grant objects and columns": {
+			resource: redshiftGrant(),
+			state: map[string]interface{}{
+				grantGroupAttr:      "analysts",
+				grantSchemaAttr:     "analytics",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"mytable"},
+				grantColumnsAttr:    []interface{}{"customerid"},
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			config: map[string]interface{}{
+				grantGroupAttr:      "analysts",
+				grantSchemaAttr:     "analytics",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"MyTable"},
+				grantColumnsAttr:    []interface{}{"CustomerId"},
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+		},
+		"role": {
+			resource: redshiftRole(),
+			state:    map[string]interface{}{roleNameAttr: "analyst", roleRolesAttr: []interface{}{"reader"}},
+			config:   map[string]interface{}{roleNameAttr: "Analyst", roleRolesAttr: []interface{}{"Reader"}},
+		},
+		"group": {
+			resource: redshiftGroup(),
+			state:    map[string]interface{}{groupNameAttr: "analysts"},
+			config:   map[string]interface{}{groupNameAttr: "Analysts"},
+		},
+		"table as": {
+			resource: redshiftTableAs(),
+			state:    map[string]interface{}{tableAsNameAttr: "mytable", tableAsSchemaAttr: "analytics", tableAsQueryAttr: "SELECT 1 AS id"},
+			config:   map[string]interface{}{tableAsNameAttr: "MyTable", tableAsSchemaAttr: "Analytics", tableAsQueryAttr: "SELECT 1 AS id"},
+		},
+		"view": {
+			resource: redshiftView(),
+			state:    map[string]interface{}{viewNameAttr: "myview", viewSchemaAttr: "analytics", viewQueryAttr: "SELECT 1"},
+			config:   map[string]interface{}{viewNameAttr: "MyView", viewSchemaAttr: "Analytics", viewQueryAttr: "SELECT 1"},
+		},
+		"materialized view": {
+			resource: redshiftMaterializedView(),
+			state:    map[string]interface{}{materializedViewNameAttr: "myview", materializedViewSchemaAttr: "analytics", materializedViewQueryAttr: "SELECT 1"},
+			config:   map[string]interface{}{materializedViewNameAttr: "MyView", materializedViewSchemaAttr: "Analytics", materializedViewQueryAttr: "SELECT 1"},
+		},
+		"function": {
+			resource: redshiftFunction(),
+			state:    map[string]interface{}{functionNameAttr: "f_add", functionSchemaAttr: "analytics", functionReturnsAttr: "integer", functionBodyAttr: "SELECT 1"},
+			config:   map[string]interface{}{functionNameAttr: "F_Add", functionSchemaAttr: "Analytics", functionReturnsAttr: "integer", functionBodyAttr: "SELECT 1"},
+		},
+		"stored procedure": {
+			resource: redshiftStoredProcedure(),
+			state:    map[string]interface{}{storedProcedureNameAttr: "load", storedProcedureSchemaAttr: "analytics", storedProcedureBodyAttr: "BEGIN END;"},
+			config:   map[string]interface{}{storedProcedureNameAttr: "Load", storedProcedureSchemaAttr: "Analytics", storedProcedureBodyAttr: "BEGIN END;"},
+		},
+		"external table": {
+			resource: redshiftExternalTable(),
+			state: map[string]interface{}{
+				externalTableNameAttr:     "sales",
+				externalTableSchemaAttr:   "spectrum",
+				externalTableLocationAttr: "s3://bucket/sales/",
+				externalTableColumnAttr: []interface{}{
+					map[string]interface{}{externalTableColumnNameAttr: "customerid", externalTableColumnTypeAttr: "int"},
+				},
+			},
+			config: map[string]interface{}{
+				externalTableNameAttr:     "Sales",
+				externalTableSchemaAttr:   "Spectrum",
+				externalTableLocationAttr: "s3://bucket/sales/",
+				externalTableColumnAttr: []interface{}{
+					map[string]interface{}{externalTableColumnNameAttr: "CustomerId", externalTableColumnTypeAttr: "int"},
+				},
+			},
+		},
+		"external partition": {
+			resource: redshiftExternalPartition(),
+			state: map[string]interface{}{
+				externalPartitionSchemaAttr:   "spectrum",
+				externalPartitionTableAttr:    "sales",
+				externalPartitionValuesAttr:   map[string]interface{}{"year": "2024"},
+				externalPartitionLocationAttr: "s3://bucket/sales/year=2024/",
+			},
+			config: map[string]interface{}{
+				externalPartitionSchemaAttr:   "Spectrum",
+				externalPartitionTableAttr:    "Sales",
+				externalPartitionValuesAttr:   map[string]interface{}{"year": "2024"},
+				externalPartitionLocationAttr: "s3://bucket/sales/year=2024/",
+			},
+		},
+		"schema grants": {
+			resource: redshiftSchemaGrants(),
+			state:    map[string]interface{}{schemaGrantsSchemaAttr: "analytics"},
+			config:   map[string]interface{}{schemaGrantsSchemaAttr: "Analytics"},
+		},
+		"role granted to a user": {
+			resource: redshiftGrantRole(),
+			state:    map[string]interface{}{grantRoleRoleAttr: "analyst", grantRoleToUserAttr: "john"},
+			config:   map[string]interface{}{grantRoleRoleAttr: "Analyst", grantRoleToUserAttr: "John"},
+		},
+		"role granted to a role": {
+			resource: redshiftGrantRole(),
+			state:    map[string]interface{}{grantRoleRoleAttr: "analyst", grantRoleToRoleAttr: "reporting"},
+			config:   map[string]interface{}{grantRoleRoleAttr: "Analyst", grantRoleToRoleAttr: "Reporting"},
+		},
+		"comment": {
+			resource: redshiftComment(),
+			state:    map[string]interface{}{commentObjectTypeAttr: "column", commentObjectNameAttr: "public.sales", commentColumnAttr: "customerid", commentCommentAttr: "Customer"},
+			config:   map[string]interface{}{commentObjectTypeAttr: "column", commentObjectNameAttr: "Public.Sales", commentColumnAttr: "CustomerId", commentCommentAttr: "Customer"},
+		},
+		"user memberships": {
+			resource: redshiftUser(),
+			state: map[string]interface{}{
+				userNameAttr:            "john",
+				userInGroupsAttr:        []interface{}{"analysts"},
+				userInRolesAttr:         []interface{}{"reader"},
+				userReassignOwnedToAttr: "admin",
+			},
+			config: map[string]interface{}{
+				userNameAttr:            "john",
+				userInGroupsAttr:        []interface{}{"Analysts"},
+				userInRolesAttr:         []interface{}{"Reader"},
+				userReassignOwnedToAttr: "Admin",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			old := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.state)
+			old.SetId("1")
+
+			diff, err := schema.InternalMap(tc.resource.Schema).Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(tc.config), nil, nil, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if diff == nil {
+				return
+			}
+			for key, attr := range diff.Attributes {
+				if !attr.NewComputed {
+					t.Errorf("Unexpected diff of %s: %q => %q", key, attr.Old, attr.New)
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package redshift
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"log"
+	"os"
+	"os/exec"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -19,6 +23,9 @@ import (
 
 const (
 	defaultProviderMaxOpenConnections                      = 20
+	defaultProviderMaxConnectionRetries                    = 3
+	defaultProviderConnectionRetryDelayInSeconds           = 1
+	defaultProviderWaitForClusterTimeoutInSeconds          = 1800
 	defaultTemporaryCredentialsAssumeRoleDurationInSeconds = 900
 )
 
@@ -27,8 +34,8 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
-				Description: "Name of Redshift server address to connect to.",
-				Required:    true,
+				Description: "Name of Redshift server address to connect to. Any host name resolving to the cluster or workgroup can be used, e.g. the endpoint of a Redshift-managed VPC endpoint, a PrivateLink endpoint or a custom DNS name. It's required unless it's read from `secret_arn`.",
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_HOST", ""),
 			},
 			"username": {
@@ -45,14 +52,67 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				ConflictsWith: []string{
 					"temporary_credentials",
+					"password_command",
+					"password_file",
+					"secret_arn",
+				},
+			},
+			"password_command": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A command printing the password on its standard output, e.g. `[\"vault\", \"kv\", \"get\", \"-field=password\", \"secret/redshift\"]`. The first element is the executable, the others are its arguments: the command isn't run in a shell. Trailing newlines are removed from the output.",
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				ConflictsWith: []string{
+					"password",
+					"password_file",
+					"secret_arn",
+					"temporary_credentials",
 				},
 			},
+			"password_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a file containing the password, e.g. a secret mounted by the orchestrator. Trailing newlines are removed from the content.",
+				ConflictsWith: []string{
+					"password",
+					"password_command",
+					"secret_arn",
+					"temporary_credentials",
+				},
+			},
+			"secret_arn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ARN or the name of an AWS Secrets Manager secret to read the connection settings from, in the JSON format of the secrets Redshift creates: `{\"username\": ..., \"password\": ..., \"host\": ..., \"port\": ..., \"dbname\": ...}`. Only the password is required: the values found in the secret take precedence over `username`, `host`, `port` and `database`, which are used for the keys the secret doesn't have. The secret is read once, when the provider is configured, with the AWS credentials of the environment.",
+				ConflictsWith: []string{
+					"password",
+					"password_command",
+					"password_file",
+					"temporary_credentials",
+				},
+			},
+			"secret_region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The AWS region of `secret_arn`. The default is the region of the ARN, or the region of the environment when `secret_arn` is a name.",
+				RequiredWith: []string{"secret_arn"},
+			},
 			"port": {
 				Type:        schema.TypeInt,
 				Description: "The Redshift port number to connect to at the server host.",
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_PORT", 5439),
 			},
+			"serverless": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Declares that `host` is a Redshift Serverless workgroup, e.g. when it's reached through a VPC endpoint or a custom DNS name. The provider then doesn't query `SYS_SERVERLESS_USAGE` to find it out, which requires privileges the user may not have, and `temporary_credentials` must use `workgroup_name` rather than `cluster_identifier`. When it's `false`, Redshift Serverless is detected after connecting. Workgroups listen on port 5439 by default, like clusters.",
+			},
 			"sslmode": {
 				Type:        schema.TypeString,
 				Description: "This option determines whether or with what priority a secure SSL TCP/IP connection will be negotiated with the Redshift server. Valid values are `require` (default, always SSL, also skip verification), `verify-ca` (always SSL, verify that the certificate presented by the server was signed by a trusted CA), `verify-full` (always SSL, verify that the certification presented by the server was signed by a trusted CA and the server host name matches the one in the certificate), `disable` (no SSL).",
@@ -65,6 +125,12 @@ func Provider() *schema.Provider {
 					"verify-full",
 				}, false),
 			},
+			"sslrootcert": {
+				Type:        schema.TypeString,
+				Description: "Path to a file containing the SSL certificate authority (CA) bundle used to verify the certificate of the Redshift server. Required when `sslmode` is `verify-ca` or `verify-full`.",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("REDSHIFT_SSLROOTCERT", ""),
+			},
 			"database": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -75,24 +141,171 @@ func Provider() *schema.Provider {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      defaultProviderMaxOpenConnections,
-				Description:  "Maximum number of connections to establish to the database. Zero means unlimited.",
+				Description:  "Maximum number of connections to establish to the database. Zero means unlimited. Terraform runs up to `-parallelism` operations at once, 10 by default, each using a connection, so a lower limit makes operations wait for a free connection.",
 				ValidateFunc: validation.IntAtLeast(-1),
 			},
+			"max_idle_connections": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of idle connections kept open to `database` for reuse. The default of zero closes every connection once it's released. Connections to other databases are never kept, so that they can be dropped. Keeping up to the `-parallelism` of Terraform avoids reconnecting on large plans.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"conn_max_lifetime": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum time in seconds a connection may be reused before it's closed. Zero, the default, reuses connections forever.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_connection_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderMaxConnectionRetries,
+				Description:  "Maximum number of times connecting is retried when Redshift can't be reached, e.g. while the cluster is resuming or failing over. Statements are never retried, as they may have been applied before the connection was lost.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"connection_retry_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderConnectionRetryDelayInSeconds,
+				Description:  "Delay in seconds before the first retry of an operation that failed to reach Redshift. The delay doubles with every retry.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"wait_for_cluster": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for a paused or resuming cluster to accept connections instead of failing after `max_connection_retries`, e.g. when the cluster is resumed by the same apply. The connection is retried with a backoff growing up to a minute until `wait_for_cluster_timeout` elapses, and the progress is logged.",
+			},
+			"wait_for_cluster_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultProviderWaitForClusterTimeoutInSeconds,
+				Description:  "Maximum time in seconds to wait for the cluster to accept connections when `wait_for_cluster` is set.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"validate_references": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check when planning that the schemas referenced by `redshift_grant`, `redshift_table` and `redshift_default_privileges` exist, to catch typos before applying. It requires Redshift to be reachable when planning. Schemas created by the same apply don't exist yet when planning and are reported as missing, so only enable it when the referenced schemas are managed elsewhere.",
+			},
+			"statement_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum time in milliseconds a statement run by the provider may take before Redshift aborts it. Zero, the default, uses the `statement_timeout` configured for the user or the cluster.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "UTC",
+				Description: "Time zone of the sessions of the provider, set with `SET TimeZone` after connecting, e.g. `UTC` or `Europe/Warsaw`. It's used to interpret and display timestamps such as the `valid_until` of users, so the default of `UTC` keeps them the same whatever the time zone of the user or the cluster is. Redshift validates the name when connecting. An empty string keeps the time zone of the user or the cluster.",
+			},
+			"client_encoding": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Character set encoding of the sessions of the provider, set with `SET client_encoding` after connecting. The provider exchanges text as UTF-8, so only `UTF8` and its alias `UNICODE` are accepted. It keeps the encoding of the user or the cluster when it's not set.",
+				ValidateFunc: validation.StringInSlice([]string{"UTF8", "UNICODE"}, true),
+			},
+			"query_group": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Name of a WLM query group every session of the provider is assigned to, using `SET query_group` after connecting, so that the statements run in the queue matching this query group instead of competing with other workloads.",
+				ValidateFunc: validateQueryGroup,
+			},
+			"search_path": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The schemas searched, in order, for objects referenced without a schema by the statements of the provider, e.g. `[\"public\"]`. It's set with `SET search_path` on every session, so it takes precedence over the `search_path` set for the user with `ALTER USER` and over the default of the cluster, which apply when it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.All(
+						validation.StringIsNotWhiteSpace,
+						validation.StringLenBetween(1, 127),
+					),
+				},
+			},
+			"assume_user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of a user the provider runs all its statements as, using `SET SESSION AUTHORIZATION` after connecting. The objects the provider creates are then owned by this user. Only superusers can set the session authorization.",
+			},
+			"bastion_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Host name or IP address of a bastion host the connections to Redshift are forwarded through with SSH, for clusters which aren't reachable directly.",
+			},
+			"bastion_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      22,
+				Description:  "The SSH port of the bastion host.",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"bastion_user": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The user to log into the bastion host as.",
+				RequiredWith: []string{"bastion_host"},
+			},
+			"bastion_private_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The PEM encoded private key to log into the bastion host with. Keys protected by a passphrase aren't supported.",
+				RequiredWith: []string{"bastion_host"},
+			},
+			"bastion_host_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The public key of the bastion host in the `authorized_keys` format, e.g. `ssh-ed25519 AAAA...`. It's required when `bastion_host` is set, unless `bastion_insecure_ignore_host_key` is.",
+			},
+			"bastion_insecure_ignore_host_key": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Connect to the bastion host without verifying its identity, when `bastion_host_key` isn't known. This is insecure: anyone able to intercept the connection to the bastion host can impersonate it and read the credentials and the traffic sent to Redshift.",
+				ConflictsWith: []string{"bastion_host_key"},
+			},
 			"temporary_credentials": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Configuration for obtaining a temporary password using redshift:GetClusterCredentials",
+				Description: "Configuration for obtaining a temporary password using redshift:GetClusterCredentials, or redshift-serverless:GetCredentials for Redshift Serverless. The credentials are requested once and shared by all the resources, which reuse them until 5 minutes before they expire, when they're requested again.",
 				MaxItems:    1,
 				ConflictsWith: []string{
 					"password",
+					"password_command",
+					"password_file",
+					"secret_arn",
 				},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"cluster_identifier": {
 							Type:         schema.TypeString,
-							Required:     true,
+							Optional:     true,
 							Description:  "The unique identifier of the cluster that contains the database for which you are requesting credentials. This parameter is case sensitive.",
 							ValidateFunc: validation.StringLenBetween(1, 2147483647),
+							ExactlyOneOf: []string{
+								"temporary_credentials.0.cluster_identifier",
+								"temporary_credentials.0.workgroup_name",
+							},
+						},
+						"workgroup_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The name of the Redshift Serverless workgroup that contains the database for which you are requesting credentials. The credentials are obtained using redshift-serverless:GetCredentials and the database user is derived from the IAM identity, so `username`, `auto_create_user` and `db_groups` are ignored.",
+							ValidateFunc: validation.StringLenBetween(3, 64),
+							ExactlyOneOf: []string{
+								"temporary_credentials.0.cluster_identifier",
+								"temporary_credentials.0.workgroup_name",
+							},
+							ConflictsWith: []string{
+								"temporary_credentials.0.auto_create_user",
+								"temporary_credentials.0.db_groups",
+							},
 						},
 						"region": {
 							Type:        schema.TypeString,
@@ -131,70 +344,260 @@ func Provider() *schema.Provider {
 			"redshift_user":                redshiftUser(),
 			"redshift_group":               redshiftGroup(),
 			"redshift_schema":              redshiftSchema(),
+			"redshift_schema_grants":       redshiftSchemaGrants(),
 			"redshift_default_privileges":  redshiftDefaultPrivileges(),
 			"redshift_grant":               redshiftGrant(),
 			"redshift_database":            redshiftDatabase(),
 			"redshift_datashare":           redshiftDatashare(),
 			"redshift_datashare_privilege": redshiftDatasharePrivilege(),
+			"redshift_role":                redshiftRole(),
+			"redshift_grant_role":          redshiftGrantRole(),
+			"redshift_table":               redshiftTable(),
+			"redshift_external_table":      redshiftExternalTable(),
+			"redshift_external_partition":  redshiftExternalPartition(),
+			"redshift_view":                redshiftView(),
+			"redshift_materialized_view":   redshiftMaterializedView(),
+			"redshift_table_as":            redshiftTableAs(),
+			"redshift_stored_procedure":    redshiftStoredProcedure(),
+			"redshift_function":            redshiftFunction(),
+			"redshift_comment":             redshiftComment(),
+			"redshift_maintenance":         redshiftMaintenance(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"redshift_user":      dataSourceRedshiftUser(),
-			"redshift_group":     dataSourceRedshiftGroup(),
-			"redshift_schema":    dataSourceRedshiftSchema(),
-			"redshift_database":  dataSourceRedshiftDatabase(),
-			"redshift_namespace": dataSourceRedshiftNamespace(),
+			"redshift_user":               dataSourceRedshiftUser(),
+			"redshift_users":              dataSourceRedshiftUsers(),
+			"redshift_group":              dataSourceRedshiftGroup(),
+			"redshift_schema":             dataSourceRedshiftSchema(),
+			"redshift_schemas":            dataSourceRedshiftSchemas(),
+			"redshift_tables":             dataSourceRedshiftTables(),
+			"redshift_database":           dataSourceRedshiftDatabase(),
+			"redshift_namespace":          dataSourceRedshiftNamespace(),
+			"redshift_role":               dataSourceRedshiftRole(),
+			"redshift_privilege":          dataSourceRedshiftPrivilege(),
+			"redshift_wlm_queues":         dataSourceRedshiftWlmQueues(),
+			"redshift_cluster_info":       dataSourceRedshiftClusterInfo(),
+			"redshift_table_info":         dataSourceRedshiftTableInfo(),
+			"redshift_default_privileges": dataSourceRedshiftDefaultPrivileges(),
+			"redshift_user_grants":        dataSourceRedshiftUserGrants(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	username, password, err := resolveCredentials(d)
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	sslMode := d.Get("sslmode").(string)
+	sslRootCert := d.Get("sslrootcert").(string)
+	if err := validateSSLConfig(sslMode, sslRootCert); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	serverless := d.Get("serverless").(bool)
+	if err := validateServerlessConfig(serverless, d.Get("temporary_credentials.0.cluster_identifier").(string)); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	username, password, expiration, err := resolveCredentials(d)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 	config := Config{
-		Host:     d.Get("host").(string),
-		Port:     d.Get("port").(int),
-		Username: username,
-		Password: password,
-		Database: d.Get("database").(string),
-		SSLMode:  d.Get("sslmode").(string),
-		MaxConns: d.Get("max_connections").(int),
+		Host:        d.Get("host").(string),
+		Port:        d.Get("port").(int),
+		Username:    username,
+		Password:    password,
+		Database:    d.Get("database").(string),
+		SSLMode:     sslMode,
+		SSLRootCert: sslRootCert,
+		MaxConns:    d.Get("max_connections").(int),
+
+		MaxIdleConns:    d.Get("max_idle_connections").(int),
+		ConnMaxLifetime: time.Duration(d.Get("conn_max_lifetime").(int)) * time.Second,
+
+		StatementTimeout: d.Get("statement_timeout").(int),
+		Timezone:         d.Get("timezone").(string),
+		ClientEncoding:   strings.ToUpper(d.Get("client_encoding").(string)),
+		QueryGroup:       d.Get("query_group").(string),
+		SearchPath:       providerSearchPath(d),
+		AssumeUser:       d.Get("assume_user").(string),
+
+		MaxConnectionRetries: d.Get("max_connection_retries").(int),
+		ConnectionRetryDelay: time.Duration(d.Get("connection_retry_delay").(int)) * time.Second,
+
+		WaitForCluster:        d.Get("wait_for_cluster").(bool),
+		WaitForClusterTimeout: time.Duration(d.Get("wait_for_cluster_timeout").(int)) * time.Second,
+
+		ValidateReferences: d.Get("validate_references").(bool),
+	}
+	if secretARN, ok := d.GetOk("secret_arn"); ok {
+		secret, err := secretsManagerSecretValue(d, secretARN.(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		secret.apply(&config)
+	}
+	if config.Host == "" {
+		return nil, diag.Errorf("host is required")
+	}
+	if serverless {
+		config.isServerless, config.checkedForServerless = true, true
+	}
+	if bastionHost, ok := d.GetOk("bastion_host"); ok {
+		if d.Get("bastion_user").(string) == "" || d.Get("bastion_private_key").(string) == "" {
+			return nil, diag.Errorf("bastion_user and bastion_private_key are required when bastion_host is set")
+		}
+		tunnel, err := newSSHTunnel(
+			bastionHost.(string),
+			d.Get("bastion_port").(int),
+			d.Get("bastion_user").(string),
+			d.Get("bastion_private_key").(string),
+			d.Get("bastion_host_key").(string),
+			d.Get("bastion_insecure_ignore_host_key").(bool),
+		)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		config.Tunnel = tunnel
+
+		// Close the tunnel when Terraform stops the provider. Otherwise it's
+		// closed with the provider process.
+		if stopCtx, ok := schema.StopContext(ctx); ok {
+			go func() {
+				<-stopCtx.Done()
+				tunnel.Close()
+			}()
+		}
+	}
+	if _, useTemporaryCredentials := d.GetOk("temporary_credentials"); useTemporaryCredentials {
+		config.credentials = newCredentialsCache(username, password, expiration, func() (string, string, time.Time, error) {
+			return resolveCredentials(d)
+		})
 	}
 
 	log.Println("[DEBUG] creating database client")
-	client := config.NewClient(d.Get("database").(string))
+	client := config.NewClient(config.Database)
 	log.Println("[DEBUG] created database client")
 	return client, nil
 }
 
-func resolveCredentials(d *schema.ResourceData) (string, string, error) {
+// validateSSLConfig checks that a CA bundle is provided for the modes verifying
+// the server certificate. verify-full additionally makes lib/pq check that the
+// host name matches the certificate.
+func validateSSLConfig(sslMode, sslRootCert string) error {
+	if (sslMode == "verify-ca" || sslMode == "verify-full") && sslRootCert == "" {
+		return fmt.Errorf("sslrootcert is required when sslmode is %s", sslMode)
+	}
+	return nil
+}
+
+// validateServerlessConfig checks that temporary credentials of a workgroup
+// aren't requested for a cluster.
+func validateServerlessConfig(serverless bool, clusterIdentifier string) error {
+	if serverless && clusterIdentifier != "" {
+		return fmt.Errorf("temporary_credentials.cluster_identifier can't be set when serverless is true, set temporary_credentials.workgroup_name instead")
+	}
+	return nil
+}
+
+func providerSearchPath(d *schema.ResourceData) []string {
+	searchPath := []string{}
+	for _, schema := range d.Get("search_path").([]interface{}) {
+		searchPath = append(searchPath, schema.(string))
+	}
+	return searchPath
+}
+
+// validateQueryGroup checks that a query group is a label WLM can match, made
+// of letters, digits, underscores, dots and dashes.
+var validateQueryGroup = validation.All(
+	validation.StringLenBetween(1, 127),
+	validation.StringMatch(regexp.MustCompile(`^[\w.\-]+$`), "must only contain letters, digits, underscores, dots and dashes"),
+)
+
+// resolveCredentials returns the user name and password to connect with. The
+// returned expiration is zero unless temporary credentials are used.
+func resolveCredentials(d *schema.ResourceData) (string, string, time.Time, error) {
 	username, ok := d.GetOk("username")
 	if (!ok) || username == nil {
-		return "", "", fmt.Errorf("Username is required")
+		return "", "", time.Time{}, fmt.Errorf("Username is required")
 	}
 	if _, useTemporaryCredentials := d.GetOk("temporary_credentials"); useTemporaryCredentials {
 		log.Println("[DEBUG] using temporary credentials authentication")
-		dbUser, dbPassword, err := temporaryCredentials(username.(string), d)
-		log.Printf("[DEBUG] got temporary credentials with username %s\n", dbUser)
-		return dbUser, dbPassword, err
+		dbUser, dbPassword, expiration, err := temporaryCredentials(username.(string), d)
+		log.Printf("[DEBUG] got temporary credentials with username %s expiring at %s\n", dbUser, expiration)
+		return dbUser, dbPassword, expiration, err
+	}
+
+	if command, ok := d.GetOk("password_command"); ok {
+		log.Println("[DEBUG] using password authentication with password_command")
+		args := []string{}
+		for _, arg := range command.([]interface{}) {
+			args = append(args, arg.(string))
+		}
+		password, err := passwordFromCommand(args)
+		return username.(string), password, time.Time{}, err
+	}
+	if path, ok := d.GetOk("password_file"); ok {
+		log.Println("[DEBUG] using password authentication with password_file")
+		password, err := passwordFromFile(path.(string))
+		return username.(string), password, time.Time{}, err
 	}
 
 	password, _ := d.GetOk("password")
 	log.Println("[DEBUG] using password authentication")
-	return username.(string), password.(string), nil
+	return username.(string), password.(string), time.Time{}, nil
 }
 
-// temporaryCredentials gets temporary credentials using GetClusterCredentials
-func temporaryCredentials(username string, d *schema.ResourceData) (string, string, error) {
+// passwordFromCommand runs the command and returns its standard output
+// without the trailing newlines. The output is never logged nor added to the
+// errors, only the standard error is.
+func passwordFromCommand(command []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("password_command %s failed: %w: %s", command[0], err, msg)
+		}
+		return "", fmt.Errorf("password_command %s failed: %w", command[0], err)
+	}
+
+	password := strings.TrimRight(stdout.String(), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password_command %s didn't print a password", command[0])
+	}
+	return password, nil
+}
+
+// passwordFromFile returns the content of the file without the trailing
+// newlines.
+func passwordFromFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read password_file: %w", err)
+	}
+
+	password := strings.TrimRight(string(content), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password_file %s is empty", path)
+	}
+	return password, nil
+}
+
+// temporaryCredentials gets temporary credentials using GetClusterCredentials,
+// or GetCredentials when a Redshift Serverless workgroup is configured.
+func temporaryCredentials(username string, d *schema.ResourceData) (string, string, time.Time, error) {
+	if _, ok := d.GetOk("temporary_credentials.0.workgroup_name"); ok {
+		return serverlessTemporaryCredentials(d)
+	}
+
 	sdkClient, err := redshiftSdkClient(d)
 	if err != nil {
-		return "", "", err
+		return "", "", time.Time{}, err
 	}
 	clusterIdentifier, clusterIdentifierIsSet := d.GetOk("temporary_credentials.0.cluster_identifier")
 	if !clusterIdentifierIsSet {
-		return "", "", fmt.Errorf("temporary_credentials not configured")
+		return "", "", time.Time{}, fmt.Errorf("temporary_credentials not configured")
 	}
 	input := &redshift.GetClusterCredentialsInput{
 		ClusterIdentifier: aws.String(clusterIdentifier.(string)),
@@ -227,16 +630,25 @@ func temporaryCredentials(username string, d *schema.ResourceData) (string, stri
 	log.Println("[DEBUG] making GetClusterCredentials request")
 	response, err := sdkClient.GetClusterCredentials(context.TODO(), input)
 	if err != nil {
-		return "", "", err
+		return "", "", time.Time{}, err
 	}
-	return aws.ToString(response.DbUser), aws.ToString(response.DbPassword), nil
+	return aws.ToString(response.DbUser), aws.ToString(response.DbPassword), aws.ToTime(response.Expiration), nil
 }
 
 func redshiftSdkClient(d *schema.ResourceData) (*redshift.Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := awsConfig(d)
 	if err != nil {
 		return nil, err
 	}
+	return redshift.NewFromConfig(cfg), nil
+}
+
+// awsConfig loads the AWS SDK configuration used to obtain temporary credentials.
+func awsConfig(d *schema.ResourceData) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		return aws.Config{}, err
+	}
 
 	if region := d.Get("temporary_credentials.0.region").(string); region != "" {
 		cfg.Region = region
@@ -260,7 +672,7 @@ func redshiftSdkClient(d *schema.ResourceData) (*redshift.Client, error) {
 		stsClient := sts.NewFromConfig(cfg)
 		cfg.Credentials = stscreds.NewAssumeRoleProvider(stsClient, parsedRoleArn, opts)
 	}
-	return redshift.NewFromConfig(cfg), nil
+	return cfg, nil
 }
 
 func assumeRoleSchema() *schema.Schema {
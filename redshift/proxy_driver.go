@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
+	"fmt"
 	"net"
 	"time"
 
@@ -13,13 +15,20 @@ import (
 
 const proxyDriverName = "postgresql-proxy"
 
-type proxyDriver struct{}
+// proxyDriver dials through the SSH tunnel when there is one, and otherwise
+// through the proxy configured in the environment.
+type proxyDriver struct {
+	tunnel *sshTunnel
+}
 
 func (d proxyDriver) Open(name string) (driver.Conn, error) {
 	return pq.DialOpen(d, name)
 }
 
 func (d proxyDriver) Dial(network, address string) (net.Conn, error) {
+	if d.tunnel != nil {
+		return d.tunnel.DialContext(context.Background(), network, address)
+	}
 	dialer := proxy.FromEnvironment()
 	return dialer.Dial(network, address)
 }
@@ -27,9 +36,135 @@ func (d proxyDriver) Dial(network, address string) (net.Conn, error) {
 func (d proxyDriver) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
 	defer cancel()
+	if d.tunnel != nil {
+		return d.tunnel.DialContext(ctx, network, address)
+	}
 	return proxy.Dial(ctx, network, address)
 }
 
 func init() {
 	sql.Register(proxyDriverName, proxyDriver{})
 }
+
+// sessionConnector configures every session opened by the connection pool.
+type sessionConnector struct {
+	driver.Connector
+
+	statementTimeout int
+	timezone         string
+	clientEncoding   string
+	queryGroup       string
+	searchPath       []string
+	assumeUser       string
+}
+
+func (c sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return conn, err
+	}
+
+	if c.statementTimeout != 0 {
+		statement := fmt.Sprintf("SET statement_timeout TO %d", c.statementTimeout)
+		if _, err := conn.(driver.ExecerContext).ExecContext(ctx, statement, nil); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("could not set statement_timeout: %w", err)
+		}
+	}
+
+	if c.timezone != "" {
+		statement := fmt.Sprintf("SET TimeZone TO '%s'", pqQuoteLiteral(c.timezone))
+		if _, err := conn.(driver.ExecerContext).ExecContext(ctx, statement, nil); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("could not set TimeZone to %s: %w", c.timezone, err)
+		}
+	}
+
+	if c.clientEncoding != "" {
+		statement := fmt.Sprintf("SET client_encoding TO '%s'", pqQuoteLiteral(c.clientEncoding))
+		if _, err := conn.(driver.ExecerContext).ExecContext(ctx, statement, nil); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("could not set client_encoding to %s: %w", c.clientEncoding, err)
+		}
+	}
+
+	if c.queryGroup != "" {
+		statement := fmt.Sprintf("SET query_group TO '%s'", pqQuoteLiteral(c.queryGroup))
+		if _, err := conn.(driver.ExecerContext).ExecContext(ctx, statement, nil); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("could not set query_group: %w", err)
+		}
+	}
+
+	if len(c.searchPath) > 0 {
+		statement := fmt.Sprintf("SET search_path TO %s", searchPathSQL(c.searchPath))
+		if _, err := conn.(driver.ExecerContext).ExecContext(ctx, statement, nil); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("could not set search_path: %w", err)
+		}
+	}
+
+	// The authorization lasts until the session ends, which happens when the
+	// pool closes the connection.
+	if c.assumeUser != "" {
+		statement := fmt.Sprintf("SET SESSION AUTHORIZATION '%s'", pqQuoteLiteral(c.assumeUser))
+		if _, err := conn.(driver.ExecerContext).ExecContext(ctx, statement, nil); err != nil {
+			conn.Close()
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == pgErrorCodeInsufficientPrivileges {
+				return nil, fmt.Errorf("could not assume user %s, only superusers can set the session authorization: %w", c.assumeUser, err)
+			}
+			return nil, fmt.Errorf("could not assume user %s: %w", c.assumeUser, err)
+		}
+	}
+
+	return conn, nil
+}
+
+// credentialsConnector connects with the current credentials of the config, so
+// that a pool keeps opening connections once its temporary credentials were
+// refreshed. The connections already open stay valid after they expire.
+type credentialsConnector struct {
+	config   Config
+	database string
+}
+
+func (c credentialsConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.config.currentConnStr(c.database)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	connector.Dialer(proxyDriver{tunnel: c.config.Tunnel})
+
+	return connector.Connect(ctx)
+}
+
+func (c credentialsConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// openDB opens a connection pool to the database dialing through the tunnel of
+// the config when there is one, and otherwise through the proxy configured in
+// the environment. Every session gets the session settings of the config which
+// aren't empty.
+func openDB(config *Config, database string) (*sql.DB, error) {
+	// The DSN is only parsed here to report an invalid configuration early.
+	if _, err := pq.NewConnector(config.connStr(database)); err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(sessionConnector{
+		Connector:        credentialsConnector{config: *config, database: database},
+		statementTimeout: config.StatementTimeout,
+		timezone:         config.Timezone,
+		clientEncoding:   config.ClientEncoding,
+		queryGroup:       config.QueryGroup,
+		searchPath:       config.SearchPath,
+		assumeUser:       config.AssumeUser,
+	}), nil
+}
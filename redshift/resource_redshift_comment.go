@@ -0,0 +1,228 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	commentObjectTypeAttr = "object_type"
+	commentObjectNameAttr = "object_name"
+	commentColumnAttr     = "column"
+	commentCommentAttr    = "comment"
+
+	commentImportIDFormat = "<object_type>:<object_name> or column:<schema>.<table>:<column>"
+
+	pqErrorCodeUndefinedColumn   = "42703"
+	pqErrorCodeUndefinedDatabase = "3D000"
+)
+
+// commentRelationKinds are the pg_class kinds of the objects whose names are
+// qualified with their schema.
+var commentRelationKinds = map[string]string{
+	"table":  "r",
+	"view":   "v",
+	"column": "r",
+}
+
+func redshiftComment() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the comment of a table, view, column, schema or database with ` + "`COMMENT ON`" + `. Comments are read back from ` + "`pg_description`" + `, e.g. by data catalogs documenting the objects. Destroying the resource removes the comment, the object itself is left untouched.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftCommentCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftCommentRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftCommentUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftCommentDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftCommentImport,
+		},
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			return validateCommentObject(d.Get(commentObjectTypeAttr).(string), d.Get(commentObjectNameAttr).(string), d.Get(commentColumnAttr).(string))
+		},
+		Schema: map[string]*schema.Schema{
+			commentObjectTypeAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the object commented on: `table`, `view`, `column`, `schema` or `database`.",
+				ValidateFunc: validation.StringInSlice([]string{"table", "view", "column", "schema", "database"}, false),
+			},
+			commentObjectNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the object commented on. Tables, views and the tables of columns are qualified with their schema, e.g. `public.sales`.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			commentColumnAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Name of the column commented on. It's required when `object_type` is `column`, and can't be set otherwise.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			commentCommentAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The comment. An empty string removes the comment with `COMMENT ON ... IS NULL`.",
+			},
+		},
+	}
+}
+
+// validateCommentObject checks that the name of the object matches its type.
+func validateCommentObject(objectType, objectName, column string) error {
+	if _, ok := commentRelationKinds[objectType]; ok && strings.Count(objectName, ".") != 1 {
+		return fmt.Errorf("%s of a %s must be qualified with its schema, e.g. public.sales, got %q", commentObjectNameAttr, objectType, objectName)
+	}
+	if objectType == "column" && column == "" {
+		return fmt.Errorf("%s is required when %s is column", commentColumnAttr, commentObjectTypeAttr)
+	}
+	if objectType != "column" && column != "" {
+		return fmt.Errorf("%s can only be set when %s is column", commentColumnAttr, commentObjectTypeAttr)
+	}
+	return nil
+}
+
+func generateCommentID(d *schema.ResourceData) string {
+	id := fmt.Sprintf("%s:%s", d.Get(commentObjectTypeAttr).(string), d.Get(commentObjectNameAttr).(string))
+	if column := d.Get(commentColumnAttr).(string); column != "" {
+		id = fmt.Sprintf("%s:%s", id, column)
+	}
+	return id
+}
+
+func resourceRedshiftCommentImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid comment import ID %q, expected %s", d.Id(), commentImportIDFormat)
+	}
+	objectType, objectName, column := parts[0], parts[1], ""
+	if len(parts) == 3 {
+		column = parts[2]
+	}
+	if err := validateCommentObject(objectType, objectName, column); err != nil {
+		return nil, fmt.Errorf("invalid comment import ID %q, expected %s: %w", d.Id(), commentImportIDFormat, err)
+	}
+
+	d.Set(commentObjectTypeAttr, objectType)
+	d.Set(commentObjectNameAttr, objectName)
+	d.Set(commentColumnAttr, column)
+	d.SetId(generateCommentID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// commentTarget returns the object of the COMMENT ON statement, e.g.
+// COLUMN "public"."sales"."id".
+func commentTarget(d *schema.ResourceData) string {
+	objectType := d.Get(commentObjectTypeAttr).(string)
+	objectName := d.Get(commentObjectNameAttr).(string)
+
+	target := pq.QuoteIdentifier(objectName)
+	if _, ok := commentRelationKinds[objectType]; ok {
+		schemaName, tableName, _ := strings.Cut(objectName, ".")
+		target = fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(tableName))
+	}
+	if objectType == "column" {
+		target = fmt.Sprintf("%s.%s", target, pq.QuoteIdentifier(d.Get(commentColumnAttr).(string)))
+	}
+
+	return fmt.Sprintf("%s %s", strings.ToUpper(objectType), target)
+}
+
+func setCommentQuery(d *schema.ResourceData, comment string) string {
+	if comment == "" {
+		return fmt.Sprintf("COMMENT ON %s IS NULL", commentTarget(d))
+	}
+	return fmt.Sprintf("COMMENT ON %s IS '%s'", commentTarget(d), pqQuoteLiteral(comment))
+}
+
+func resourceRedshiftCommentRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftCommentReadImpl(db, d)
+}
+
+func resourceRedshiftCommentReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	objectType := d.Get(commentObjectTypeAttr).(string)
+	objectName := d.Get(commentObjectNameAttr).(string)
+	schemaName, tableName, _ := strings.Cut(objectName, ".")
+
+	// The names keep the configured case, while Redshift folds them unless
+	// enable_case_sensitive_identifier is on.
+	var row *sql.Row
+	switch objectType {
+	case "table", "view":
+		row = db.QueryRow(`
+  SELECT obj_description(cl.oid, 'pg_class')
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE lower(nsp.nspname) = lower($1) AND lower(cl.relname) = lower($2) AND cl.relkind = $3
+`, schemaName, tableName, commentRelationKinds[objectType])
+	case "column":
+		row = db.QueryRow(`
+  SELECT col_description(cl.oid, att.attnum)
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+    JOIN pg_attribute att ON att.attrelid = cl.oid
+  WHERE lower(nsp.nspname) = lower($1) AND lower(cl.relname) = lower($2) AND lower(att.attname) = lower($3) AND att.attnum > 0
+`, schemaName, tableName, d.Get(commentColumnAttr).(string))
+	case "schema":
+		row = db.QueryRow("SELECT obj_description(oid, 'pg_namespace') FROM pg_namespace WHERE lower(nspname) = lower($1)", objectName)
+	case "database":
+		row = db.QueryRow("SELECT obj_description(oid, 'pg_database') FROM pg_database WHERE lower(datname) = lower($1)", objectName)
+	}
+
+	var comment sql.NullString
+	switch err := row.Scan(&comment); {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift Comment (%s) not found, the object doesn't exist", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Comment: %w", err)
+	}
+
+	d.Set(commentCommentAttr, comment.String)
+	return nil
+}
+
+func resourceRedshiftCommentCreate(db *DBConnection, d *schema.ResourceData) error {
+	if _, err := db.Exec(setCommentQuery(d, d.Get(commentCommentAttr).(string))); err != nil {
+		return fmt.Errorf("Could not create redshift comment: %w", err)
+	}
+
+	d.SetId(generateCommentID(d))
+
+	return resourceRedshiftCommentReadImpl(db, d)
+}
+
+func resourceRedshiftCommentUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if d.HasChange(commentCommentAttr) {
+		if _, err := db.Exec(setCommentQuery(d, d.Get(commentCommentAttr).(string))); err != nil {
+			return fmt.Errorf("Error updating Comment: %w", err)
+		}
+	}
+
+	return resourceRedshiftCommentReadImpl(db, d)
+}
+
+func resourceRedshiftCommentDelete(db *DBConnection, d *schema.ResourceData) error {
+	_, err := db.Exec(setCommentQuery(d, ""))
+	// Dropping the object drops its comment.
+	for _, code := range []string{pqErrorCodeUndefinedTable, pqErrorCodeUndefinedColumn, pqErrorCodeInvalidSchemaName, pqErrorCodeUndefinedDatabase} {
+		if isPqErrorWithCode(err, code) {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftComment_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_comment_schema"), "-", "_")
+	config := func(tableComment, columnComment string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name   = "sales"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_comment" "schema" {
+  object_type = "schema"
+  object_name = redshift_schema.schema.name
+  comment     = "Sales data"
+}
+
+resource "redshift_comment" "table" {
+  object_type = "table"
+  object_name = "${redshift_schema.schema.name}.${redshift_table.table.name}"
+  comment     = %[2]q
+}
+
+resource "redshift_comment" "column" {
+  object_type = "column"
+  object_name = "${redshift_schema.schema.name}.${redshift_table.table.name}"
+  column      = "id"
+  comment     = %[3]q
+}
+`, schemaName, tableComment, columnComment)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config("All the sales", "Identifier of the sale"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_comment.schema", "id", "schema:"+schemaName),
+					resource.TestCheckResourceAttr("redshift_comment.schema", "comment", "Sales data"),
+					resource.TestCheckResourceAttr("redshift_comment.table", "comment", "All the sales"),
+					resource.TestCheckResourceAttr("redshift_comment.column", "id", fmt.Sprintf("column:%s.sales:id", schemaName)),
+					resource.TestCheckResourceAttr("redshift_comment.column", "comment", "Identifier of the sale"),
+				),
+			},
+			{
+				Config: config("The sale's records", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_comment.table", "comment", "The sale's records"),
+					resource.TestCheckResourceAttr("redshift_comment.column", "comment", ""),
+				),
+			},
+			{
+				ResourceName:      "redshift_comment.table",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSetCommentQuery(t *testing.T) {
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		"table": {
+			raw: map[string]interface{}{
+				commentObjectTypeAttr: "table",
+				commentObjectNameAttr: "public.sales",
+				commentCommentAttr:    "The sale's records",
+			},
+			expected: `COMMENT ON TABLE "public"."sales" IS 'The sale''s records'`,
+		},
+		"column": {
+			raw: map[string]interface{}{
+				commentObjectTypeAttr: "column",
+				commentObjectNameAttr: "public.sales",
+				commentColumnAttr:     "id",
+				commentCommentAttr:    "Identifier",
+			},
+			expected: `COMMENT ON COLUMN "public"."sales"."id" IS 'Identifier'`,
+		},
+		"empty database comment": {
+			raw: map[string]interface{}{
+				commentObjectTypeAttr: "database",
+				commentObjectNameAttr: "dev",
+				commentCommentAttr:    "",
+			},
+			expected: `COMMENT ON DATABASE "dev" IS NULL`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftComment().Schema, tt.raw)
+			if query := setCommentQuery(d, d.Get(commentCommentAttr).(string)); query != tt.expected {
+				t.Errorf("Expected query %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestCommentImportID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftComment().Schema, map[string]interface{}{})
+	d.SetId("column:Public.Sales:ID")
+
+	if _, err := resourceRedshiftCommentImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if id := d.Id(); id != "column:Public.Sales:ID" {
+		t.Errorf("Expected ID column:Public.Sales:ID, got %q", id)
+	}
+	if column := d.Get(commentColumnAttr).(string); column != "ID" {
+		t.Errorf("Expected column ID, got %q", column)
+	}
+
+	for _, invalid := range []string{"table", "table:sales", "column:public.sales", "schema:public:id"} {
+		d.SetId(invalid)
+		if _, err := resourceRedshiftCommentImport(context.Background(), d, nil); err == nil {
+			t.Errorf("Expected import ID %q to be rejected", invalid)
+		}
+	}
+}
@@ -15,11 +15,15 @@ import (
 const databaseNameAttr = "name"
 const databaseOwnerAttr = "owner"
 const databaseConnLimitAttr = "connection_limit"
+const databaseIsolationLevelAttr = "isolation_level"
 const databaseDatashareSourceAttr = "datashare_source"
 const databaseDatashareSourceShareNameAttr = "share_name"
 const databaseDatashareSourceNamespaceAttr = "namespace"
 const databaseDatashareSourceAccountAttr = "account_id"
 const databaseDatashareSourceWithPermissions = "with_permissions"
+const databaseIfNotExistsAttr = "if_not_exists"
+
+const pqErrorCodeObjectInUse = "55006"
 
 func redshiftDatabase() *schema.Resource {
 	return &schema.Resource{
@@ -34,6 +38,11 @@ func redshiftDatabase() *schema.Resource {
 		},
 		CustomizeDiff: forceNewIfListSizeChanged(databaseDatashareSourceAttr),
 		Schema: map[string]*schema.Schema{
+			databaseIfNotExistsAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Adopts an existing database with the same name into the state instead of failing to create it. Redshift has no `CREATE DATABASE IF NOT EXISTS`, so the database is looked up before creating it. Terraform then manages the existing database as if it had created it: the attributes which differ from the configuration are changed by the next apply, and destroying the resource drops the database. It's only used when the database is created.",
+			},
 			databaseNameAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -43,10 +52,11 @@ func redshiftDatabase() *schema.Resource {
 				},
 			},
 			databaseOwnerAttr: {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Owner of the database, usually the user who created it",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Owner of the database, usually the user who created it. Changing it transfers the ownership of the database with `ALTER DATABASE ... OWNER TO`.",
+				DiffSuppressFunc: ownerDiffSuppress,
 			},
 			databaseConnLimitAttr: {
 				Type:         schema.TypeInt,
@@ -55,6 +65,19 @@ func redshiftDatabase() *schema.Resource {
 				Default:      -1,
 				ValidateFunc: validation.IntAtLeast(-1),
 			},
+			databaseIsolationLevelAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The isolation level of the database, either `SNAPSHOT` or `SERIALIZABLE`. Changing the isolation level requires that no other sessions are connected to the database.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+				ValidateFunc: validation.StringInSlice([]string{
+					"SNAPSHOT",
+					"SERIALIZABLE",
+				}, true),
+			},
 			databaseDatashareSourceAttr: {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -79,6 +102,7 @@ func redshiftDatabase() *schema.Resource {
 							StateFunc: func(val interface{}) string {
 								return strings.ToLower(val.(string))
 							},
+							ValidateFunc: validation.StringMatch(uuidRegex, "Producer namespace must be a guid"),
 						},
 						databaseDatashareSourceAccountAttr: {
 							Type:         schema.TypeString,
@@ -119,6 +143,19 @@ func resourceRedshiftDatabaseExists(db *DBConnection, d *schema.ResourceData) (b
 }
 
 func resourceRedshiftDatabaseCreate(db *DBConnection, d *schema.ResourceData) error {
+	if d.Get(databaseIfNotExistsAttr).(bool) {
+		var oid string
+		err := db.QueryRow("SELECT oid FROM pg_database WHERE datname = $1", strings.ToLower(d.Get(databaseNameAttr).(string))).Scan(&oid)
+		switch {
+		case err == nil:
+			log.Printf("[WARN] Adopting existing database %s (%s) into the state", d.Get(databaseNameAttr).(string), oid)
+			d.SetId(oid)
+			return resourceRedshiftDatabaseRead(db, d)
+		case err != sql.ErrNoRows:
+			return err
+		}
+	}
+
 	if _, isDataShare := d.GetOk(fmt.Sprintf("%s.0.%s", databaseDatashareSourceAttr, databaseDatashareSourceShareNameAttr)); isDataShare {
 		return resourceRedshiftDatabaseCreateFromDatashare(db, d)
 	}
@@ -143,7 +180,7 @@ func resourceRedshiftDatabaseCreateFromDatashare(db *DBConnection, d *schema.Res
 	query = fmt.Sprintf("%s NAMESPACE '%s'", query, pqQuoteLiteral(namespace.(string)))
 
 	if _, err := db.Exec(query); err != nil {
-		return err
+		return createObjectError(err, "redshift_database")
 	}
 
 	// eagerly get the resource ID in case the below statements fail for some reason
@@ -157,30 +194,27 @@ func resourceRedshiftDatabaseCreateFromDatashare(db *DBConnection, d *schema.Res
 
 	// CREATE DATABASE isn't allowed to run inside a transaction, however ALTER DATABASE
 	// can be
-	tx, err := startTransaction(db.client, "")
-	if err != nil {
-		return err
-	}
-	defer deferredRollback(tx)
-
-	// CREATE DATABASE FROM DATASHARE... doesn't allow you to specify an owner in the create statement,
-	// so we need to set the owner after creation using ALTER DATABASE...
-	owner, ownerIsSet := d.GetOk(databaseOwnerAttr)
-	if ownerIsSet {
-		if _, err = tx.Exec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(dbName), pq.QuoteIdentifier(owner.(string)))); err != nil {
-			return err
+	err := db.WithTx(func(tx *DBTransaction) error {
+		// CREATE DATABASE FROM DATASHARE... doesn't allow you to specify an owner in the create statement,
+		// so we need to set the owner after creation using ALTER DATABASE...
+		owner, ownerIsSet := d.GetOk(databaseOwnerAttr)
+		if ownerIsSet {
+			if _, err := tx.Exec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(dbName), pq.QuoteIdentifier(owner.(string)))); err != nil {
+				return err
+			}
 		}
-	}
 
-	// CREATE DATABASE FROM DATASHARE... doesn't allow you to specify the connection limit in the create statement,
-	// so we need to set the owner after creation using ALTER DATABASE...
-	connLimit, connLimitIsSet := d.GetOk(databaseConnLimitAttr)
-	if connLimitIsSet {
-		if _, err = tx.Exec(fmt.Sprintf("ALTER DATABASE %s CONNECTION LIMIT %d", pq.QuoteIdentifier(dbName), connLimit.(int))); err != nil {
-			return err
+		// CREATE DATABASE FROM DATASHARE... doesn't allow you to specify the connection limit in the create statement,
+		// so we need to set the owner after creation using ALTER DATABASE...
+		connLimit, connLimitIsSet := d.GetOk(databaseConnLimitAttr)
+		if connLimitIsSet {
+			if _, err := tx.Exec(fmt.Sprintf("ALTER DATABASE %s CONNECTION LIMIT %d", pq.QuoteIdentifier(dbName), connLimit.(int))); err != nil {
+				return err
+			}
 		}
-	}
-	if err = tx.Commit(); err != nil {
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
@@ -197,9 +231,12 @@ func resourceRedshiftDatabaseCreateInternal(db *DBConnection, d *schema.Resource
 	if v, ok := d.GetOk(databaseConnLimitAttr); ok {
 		query = fmt.Sprintf("%s CONNECTION LIMIT %d", query, v.(int))
 	}
+	if v, ok := d.GetOk(databaseIsolationLevelAttr); ok {
+		query = fmt.Sprintf("%s ISOLATION LEVEL %s", query, strings.ToUpper(v.(string)))
+	}
 	log.Printf("[DEBUG] create database %s: %s\n", dbName, query)
 	if _, err := db.Exec(query); err != nil {
-		return err
+		return createObjectError(err, "redshift_database")
 	}
 
 	var oid string
@@ -215,13 +252,14 @@ func resourceRedshiftDatabaseCreateInternal(db *DBConnection, d *schema.Resource
 }
 
 func resourceRedshiftDatabaseRead(db *DBConnection, d *schema.ResourceData) error {
-	var name, owner, connLimit, databaseType, shareName, producerAccount, producerNamespace string
+	var name, owner, connLimit, databaseType, isolationLevel, shareName, producerAccount, producerNamespace string
 
 	query := `SELECT
   TRIM(svv_redshift_databases.database_name),
   TRIM(pg_user_info.usename),
   COALESCE(pg_database_info.datconnlimit::text, 'UNLIMITED'),
 	svv_redshift_databases.database_type,
+  COALESCE(svv_redshift_databases.database_isolation_level, ''),
   TRIM(COALESCE(svv_datashares.share_name, '')),
   TRIM(COALESCE(svv_datashares.producer_account, '')),
   TRIM(COALESCE(svv_datashares.producer_namespace, ''))
@@ -236,7 +274,7 @@ LEFT JOIN svv_datashares
 WHERE pg_database_info.datid = $1
 `
 	log.Printf("[DEBUG] read database: %s\n", query)
-	err := db.QueryRow(query, d.Id()).Scan(&name, &owner, &connLimit, &databaseType, &shareName, &producerAccount, &producerNamespace)
+	err := db.QueryRow(query, d.Id()).Scan(&name, &owner, &connLimit, &databaseType, &isolationLevel, &shareName, &producerAccount, &producerNamespace)
 
 	if err != nil {
 		return err
@@ -252,6 +290,7 @@ WHERE pg_database_info.datid = $1
 	d.Set(databaseNameAttr, name)
 	d.Set(databaseOwnerAttr, owner)
 	d.Set(databaseConnLimitAttr, connLimitNumber)
+	d.Set(databaseIsolationLevelAttr, databaseIsolationLevelFromInfo(isolationLevel))
 
 	dataShareConfiguration := make([]map[string]interface{}, 0, 1)
 	if databaseType == "shared" {
@@ -267,7 +306,7 @@ WHERE pg_database_info.datid = $1
 }
 
 func resourceRedshiftDatabaseUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -289,10 +328,27 @@ func resourceRedshiftDatabaseUpdate(db *DBConnection, d *schema.ResourceData) er
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
 
+	if err := setDatabaseIsolationLevel(db, d); err != nil {
+		return err
+	}
+
 	return resourceRedshiftDatabaseRead(db, d)
 }
 
-func setDatabaseName(tx *sql.Tx, d *schema.ResourceData) error {
+// databaseIsolationLevelFromInfo maps the isolation level reported by
+// svv_redshift_databases, e.g. "Snapshot Isolation", to the one used in SQL.
+func databaseIsolationLevelFromInfo(isolationLevel string) string {
+	switch {
+	case isolationLevel == "":
+		return ""
+	case strings.Contains(strings.ToLower(isolationLevel), "snapshot"):
+		return "SNAPSHOT"
+	default:
+		return "SERIALIZABLE"
+	}
+}
+
+func setDatabaseName(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(databaseNameAttr) {
 		return nil
 	}
@@ -314,7 +370,7 @@ func setDatabaseName(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setDatabaseOwner(tx *sql.Tx, d *schema.ResourceData) error {
+func setDatabaseOwner(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(databaseOwnerAttr) {
 		return nil
 	}
@@ -322,13 +378,17 @@ func setDatabaseOwner(tx *sql.Tx, d *schema.ResourceData) error {
 	databaseName := d.Get(databaseNameAttr).(string)
 	databaseOwner := d.Get(databaseOwnerAttr).(string)
 
+	if err := checkOwnerExists(tx, databaseOwner); err != nil {
+		return err
+	}
+
 	query := fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(databaseOwner))
 	log.Printf("[DEBUG] changing database owner: %s\n", query)
 	_, err := tx.Exec(query)
 	return err
 }
 
-func setDatabaseConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
+func setDatabaseConnLimit(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(databaseConnLimitAttr) {
 		return nil
 	}
@@ -341,11 +401,31 @@ func setDatabaseConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
 	return err
 }
 
+// setDatabaseIsolationLevel is run outside of the update transaction, as
+// ALTER DATABASE ... ISOLATION LEVEL can't be run inside a transaction block.
+func setDatabaseIsolationLevel(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(databaseIsolationLevelAttr) {
+		return nil
+	}
+
+	databaseName := d.Get(databaseNameAttr).(string)
+	isolationLevel := strings.ToUpper(d.Get(databaseIsolationLevelAttr).(string))
+	query := fmt.Sprintf("ALTER DATABASE %s ISOLATION LEVEL %s", pq.QuoteIdentifier(databaseName), isolationLevel)
+	log.Printf("[DEBUG] changing database isolation level: %s\n", query)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Error updating database ISOLATION LEVEL: %w", err)
+	}
+	return nil
+}
+
 func resourceRedshiftDatabaseDelete(db *DBConnection, d *schema.ResourceData) error {
 	databaseName := d.Get(databaseNameAttr).(string)
 
 	query := fmt.Sprintf("DROP DATABASE %s", pqQuoteLiteral(databaseName))
 	log.Printf("[DEBUG] dropping database %s: %s\n", databaseName, query)
 	_, err := db.Exec(query)
+	if isPqErrorWithCode(err, pqErrorCodeObjectInUse) {
+		return fmt.Errorf("Could not drop database %s, as it has open sessions. They can be listed with `SELECT process, user_name FROM stv_sessions WHERE db_name = '%s'` and closed with pg_terminate_backend: %w", databaseName, pqQuoteLiteral(databaseName), err)
+	}
 	return err
 }
@@ -3,6 +3,7 @@ package redshift
 import (
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -61,12 +62,13 @@ resource "redshift_database" "db" {
 	%[1]s = %[2]q
 	%[3]s = redshift_user.user.%[4]s
 	%[5]s = 0
+	%[7]s = "serializable"
 }
 
 resource "redshift_user" "user" {
 	%[4]s = %[6]q
 }
-	`, databaseNameAttr, dbNameNew, databaseOwnerAttr, userNameAttr, databaseConnLimitAttr, userName)
+	`, databaseNameAttr, dbNameNew, databaseOwnerAttr, userNameAttr, databaseConnLimitAttr, userName, databaseIsolationLevelAttr)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -80,6 +82,7 @@ resource "redshift_user" "user" {
 					resource.TestCheckResourceAttr("redshift_database.db", databaseNameAttr, dbNameOriginal),
 					resource.TestCheckResourceAttrSet("redshift_database.db", databaseOwnerAttr),
 					resource.TestCheckResourceAttrSet("redshift_database.db", databaseConnLimitAttr),
+					resource.TestCheckResourceAttrSet("redshift_database.db", databaseIsolationLevelAttr),
 				),
 			},
 			{
@@ -89,12 +92,27 @@ resource "redshift_user" "user" {
 					resource.TestCheckResourceAttr("redshift_database.db", databaseNameAttr, dbNameNew),
 					resource.TestCheckResourceAttr("redshift_database.db", databaseOwnerAttr, userName),
 					resource.TestCheckResourceAttr("redshift_database.db", databaseConnLimitAttr, "0"),
+					resource.TestCheckResourceAttr("redshift_database.db", databaseIsolationLevelAttr, "SERIALIZABLE"),
 				),
 			},
 		},
 	})
 }
 
+func TestDatabaseIsolationLevelFromInfo(t *testing.T) {
+	tests := map[string]string{
+		"Snapshot Isolation": "SNAPSHOT",
+		"Serializable":       "SERIALIZABLE",
+		"":                   "",
+	}
+
+	for isolationLevel, expected := range tests {
+		if result := databaseIsolationLevelFromInfo(isolationLevel); result != expected {
+			t.Errorf("Expected isolation level %q for %q, got %q", expected, isolationLevel, result)
+		}
+	}
+}
+
 func testAccResourceRedshiftDatabaseConfig_basic(dbName string) string {
 	return fmt.Sprintf(`
 resource "redshift_database" "db" {
@@ -158,3 +176,29 @@ func checkDatabaseExists(client *Client, database string) (bool, error) {
 
 	return true, nil
 }
+
+func TestAccResourceRedshiftDatabase_DatashareSourceInvalidNamespace(t *testing.T) {
+	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_resource_datashare"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_database" "db" {
+	%[1]s = %[2]q
+
+	%[3]s {
+		%[4]s = "test_share"
+		%[5]s = "not-a-guid"
+	}
+}
+`, databaseNameAttr, dbName, databaseDatashareSourceAttr, databaseDatashareSourceShareNameAttr, databaseDatashareSourceNamespaceAttr)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Producer namespace must be a guid"),
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package redshift
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -32,8 +33,10 @@ The redshift_datashare resource should be defined on the producer cluster.
 Note: Data sharing is only supported on certain Redshift instance families,
 such as RA3.
 `,
-		Exists:        RedshiftResourceExistsFunc(resourceRedshiftDatashareExists),
-		CreateContext: RedshiftResourceFunc(resourceRedshiftDatashareCreate),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftDatashareExists),
+		CreateContext: RedshiftResourceFunc(
+			RedshiftResourceRequireFeature(clusterFeatureDatashares, "redshift_datashare", resourceRedshiftDatashareCreate),
+		),
 		ReadContext:   RedshiftResourceFunc(resourceRedshiftDatashareRead),
 		UpdateContext: RedshiftResourceFunc(resourceRedshiftDatashareUpdate),
 		DeleteContext: RedshiftResourceFunc(resourceRedshiftDatashareDelete),
@@ -113,7 +116,7 @@ func resourceRedshiftDatashareExists(db *DBConnection, d *schema.ResourceData) (
 }
 
 func resourceRedshiftDatashareCreate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -124,7 +127,7 @@ func resourceRedshiftDatashareCreate(db *DBConnection, d *schema.ResourceData) e
 	query := fmt.Sprintf("CREATE DATASHARE %s SET PUBLICACCESSIBLE = %t", pq.QuoteIdentifier(shareName), d.Get(dataSharePublicAccessibleAttr).(bool))
 	log.Printf("[DEBUG] %s\n", query)
 	if _, err := tx.Exec(query); err != nil {
-		return err
+		return createObjectError(err, "redshift_datashare")
 	}
 
 	var shareId string
@@ -159,7 +162,7 @@ func resourceRedshiftDatashareCreate(db *DBConnection, d *schema.ResourceData) e
 	return resourceRedshiftDatashareRead(db, d)
 }
 
-func addSchemaToDatashare(tx *sql.Tx, shareName string, schemaName string) error {
+func addSchemaToDatashare(tx *DBTransaction, shareName string, schemaName string) error {
 	err := resourceRedshiftDatashareAddSchema(tx, shareName, schemaName)
 	if err != nil {
 		return err
@@ -172,13 +175,14 @@ func addSchemaToDatashare(tx *sql.Tx, shareName string, schemaName string) error
 	return err
 }
 
-func resourceRedshiftDatashareAddSchema(tx *sql.Tx, shareName string, schemaName string) error {
+func resourceRedshiftDatashareAddSchema(tx *DBTransaction, shareName string, schemaName string) error {
 	query := fmt.Sprintf("ALTER DATASHARE %s ADD SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
 	log.Printf("[DEBUG] %s\n", query)
 	_, err := tx.Exec(query)
 	if err != nil {
 		// if the schema is already in the datashare we get a "duplicate schema" error code. This is fine.
-		if pqErr, ok := err.(*pq.Error); ok {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
 			if string(pqErr.Code) == pqErrorCodeDuplicateSchema {
 				log.Printf("[WARN] Schema %s already exists in datashare %s\n", schemaName, shareName)
 			} else {
@@ -194,21 +198,21 @@ func resourceRedshiftDatashareAddSchema(tx *sql.Tx, shareName string, schemaName
 	return err
 }
 
-func resourceRedshiftDatashareAddAllFunctions(tx *sql.Tx, shareName string, schemaName string) error {
+func resourceRedshiftDatashareAddAllFunctions(tx *DBTransaction, shareName string, schemaName string) error {
 	query := fmt.Sprintf("ALTER DATASHARE %s ADD ALL FUNCTIONS IN SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
 	log.Printf("[DEBUG] %s", query)
 	_, err := tx.Exec(query)
 	return err
 }
 
-func resourceRedshiftDatashareAddAllTables(tx *sql.Tx, shareName string, schemaName string) error {
+func resourceRedshiftDatashareAddAllTables(tx *DBTransaction, shareName string, schemaName string) error {
 	query := fmt.Sprintf("ALTER DATASHARE %s ADD ALL TABLES IN SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
 	log.Printf("[DEBUG] %s\n", query)
 	_, err := tx.Exec(query)
 	return err
 }
 
-func removeSchemaFromDatashare(tx *sql.Tx, shareName string, schemaName string) error {
+func removeSchemaFromDatashare(tx *DBTransaction, shareName string, schemaName string) error {
 	err := resourceRedshiftDatashareRemoveAllFunctions(tx, shareName, schemaName)
 	if err != nil {
 		return err
@@ -221,27 +225,28 @@ func removeSchemaFromDatashare(tx *sql.Tx, shareName string, schemaName string)
 	return err
 }
 
-func resourceRedshiftDatashareRemoveAllFunctions(tx *sql.Tx, shareName string, schemaName string) error {
+func resourceRedshiftDatashareRemoveAllFunctions(tx *DBTransaction, shareName string, schemaName string) error {
 	query := fmt.Sprintf("ALTER DATASHARE %s REMOVE ALL FUNCTIONS IN SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
 	log.Printf("[DEBUG] %s\n", query)
 	_, err := tx.Exec(query)
 	return err
 }
 
-func resourceRedshiftDatashareRemoveAllTables(tx *sql.Tx, shareName string, schemaName string) error {
+func resourceRedshiftDatashareRemoveAllTables(tx *DBTransaction, shareName string, schemaName string) error {
 	query := fmt.Sprintf("ALTER DATASHARE %s REMOVE ALL TABLES IN SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
 	log.Printf("[DEBUG] %s\n", query)
 	_, err := tx.Exec(query)
 	return err
 }
 
-func resourceRedshiftDatashareRemoveSchema(tx *sql.Tx, shareName string, schemaName string) error {
+func resourceRedshiftDatashareRemoveSchema(tx *DBTransaction, shareName string, schemaName string) error {
 	query := fmt.Sprintf("ALTER DATASHARE %s REMOVE SCHEMA %s", pq.QuoteIdentifier(shareName), pq.QuoteIdentifier(schemaName))
 	log.Printf("[DEBUG] %s\n", query)
 	_, err := tx.Exec(query)
 	if err != nil {
 		// if the schema is not already in the datashare we get a "datashare does not contain schema" error code. This is fine.
-		if pqErr, ok := err.(*pq.Error); ok {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
 			if string(pqErr.Code) == pqErrorCodeInvalidSchemaName {
 				log.Printf("[WARN] Schema %s does not exist in datashare %s\n", schemaName, shareName)
 			} else {
@@ -258,7 +263,7 @@ func resourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) err
 	var shareName, owner, producerAccount, producerNamespace, created string
 	var publicAccessible bool
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -278,7 +283,13 @@ func resourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) err
 	AND share_id = $1`
 	log.Printf("[DEBUG] %s, $1=%s\n", query, d.Id())
 	err = tx.QueryRow(query, d.Id()).Scan(&shareName, &owner, &publicAccessible, &producerAccount, &producerNamespace, &created)
-	if err != nil {
+	switch {
+	case err == sql.ErrNoRows:
+		// the datashare was dropped outside of terraform, remove it from the state so it gets recreated
+		log.Printf("[WARN] Redshift datashare (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
 		return err
 	}
 
@@ -300,7 +311,7 @@ func resourceRedshiftDatashareRead(db *DBConnection, d *schema.ResourceData) err
 	return nil
 }
 
-func readDatashareSchemas(tx *sql.Tx, shareName string, d *schema.ResourceData) error {
+func readDatashareSchemas(tx *DBTransaction, shareName string, d *schema.ResourceData) error {
 	query := `
 	SELECT
 		object_name
@@ -329,7 +340,7 @@ func readDatashareSchemas(tx *sql.Tx, shareName string, d *schema.ResourceData)
 }
 
 func resourceRedshiftDatashareUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -354,7 +365,7 @@ func resourceRedshiftDatashareUpdate(db *DBConnection, d *schema.ResourceData) e
 	return resourceRedshiftDatashareRead(db, d)
 }
 
-func setDatashareOwner(tx *sql.Tx, d *schema.ResourceData) error {
+func setDatashareOwner(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(dataShareOwnerAttr) {
 		return nil
 	}
@@ -375,7 +386,7 @@ func setDatashareOwner(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setDatasharePubliclyAccessble(tx *sql.Tx, d *schema.ResourceData) error {
+func setDatasharePubliclyAccessble(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(dataSharePublicAccessibleAttr) {
 		return nil
 	}
@@ -390,7 +401,7 @@ func setDatasharePubliclyAccessble(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setDatashareSchemas(tx *sql.Tx, d *schema.ResourceData) error {
+func setDatashareSchemas(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(dataShareSchemasAttr) {
 		return nil
 	}
@@ -421,7 +432,7 @@ func setDatashareSchemas(tx *sql.Tx, d *schema.ResourceData) error {
 }
 
 func resourceRedshiftDatashareDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
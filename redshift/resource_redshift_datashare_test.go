@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftDatashare_Basic(t *testing.T) {
@@ -107,6 +108,50 @@ resource "redshift_datashare" "basic" {
 	})
 }
 
+func TestAccRedshiftDatashare_DroppedOutOfBand(t *testing.T) {
+	_ = getEnvOrSkip("REDSHIFT_DATASHARE_SUPPORTED", t)
+	shareName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_datashare_dropped"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_datashare" "dropped" {
+	%[1]s = %[2]q
+}
+`, dataShareNameAttr, shareName)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftDatashareDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftDatashareExists(shareName),
+					testAccDropRedshiftDatashare(shareName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftDatashareExists(shareName),
+				),
+			},
+		},
+	})
+}
+
+func testAccDropRedshiftDatashare(shareName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(fmt.Sprintf("DROP DATASHARE %s", pq.QuoteIdentifier(shareName)))
+		return err
+	}
+}
+
 func testAccCheckRedshiftDatashareExists(shareName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*Client)
@@ -1,11 +1,13 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
@@ -14,6 +16,7 @@ import (
 const (
 	defaultPrivilegesUserAttr       = "user"
 	defaultPrivilegesGroupAttr      = "group"
+	defaultPrivilegesRoleAttr       = "role"
 	defaultPrivilegesOwnerAttr      = "owner"
 	defaultPrivilegesSchemaAttr     = "schema"
 	defaultPrivilegesPrivilegesAttr = "privileges"
@@ -24,10 +27,21 @@ const (
 
 var defaultPrivilegesAllowedObjectTypes = []string{
 	"table",
+	"function",
+	"procedure",
 }
 
 var defaultPrivilegesObjectTypesCodes = map[string]string{
-	"table": "r",
+	"table":     "r",
+	"function":  "f",
+	"procedure": "p",
+}
+
+// object types as reported by the svv_default_privileges view
+var defaultPrivilegesObjectTypesNames = map[string]string{
+	"table":     "RELATION",
+	"function":  "FUNCTION",
+	"procedure": "PROCEDURE",
 }
 
 func redshiftDefaultPrivileges() *schema.Resource {
@@ -44,6 +58,10 @@ func redshiftDefaultPrivileges() *schema.Resource {
 		UpdateContext: RedshiftResourceFunc(
 			RedshiftResourceRetryOnPQErrors(resourceRedshiftDefaultPrivilegesCreate),
 		),
+		CustomizeDiff: customdiff.All(
+			validateDefaultPrivileges,
+			validateSchemaReference(defaultPrivilegesSchemaAttr),
+		),
 
 		Schema: map[string]*schema.Schema{
 			defaultPrivilegesSchemaAttr: {
@@ -56,16 +74,26 @@ func redshiftDefaultPrivileges() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr},
+				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr, defaultPrivilegesRoleAttr},
 				Description:  "The name of the  group to which the specified default privileges are applied.",
 			},
 			defaultPrivilegesUserAttr: {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr},
+				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr, defaultPrivilegesRoleAttr},
 				Description:  "The name of the user to which the specified default privileges are applied.",
 			},
+			defaultPrivilegesRoleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{defaultPrivilegesGroupAttr, defaultPrivilegesUserAttr, defaultPrivilegesRoleAttr},
+				Description:  "The name of the role to which the specified default privileges are applied.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
 			defaultPrivilegesOwnerAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -95,10 +123,27 @@ func redshiftDefaultPrivileges() *schema.Resource {
 	}
 }
 
+// validateDefaultPrivileges rejects the privileges the object type doesn't
+// have when planning, e.g. EXECUTE on tables, rather than when applying.
+func validateDefaultPrivileges(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(defaultPrivilegesPrivilegesAttr) || !d.NewValueKnown(defaultPrivilegesObjectTypeAttr) {
+		return nil
+	}
+
+	objectType := d.Get(defaultPrivilegesObjectTypeAttr).(string)
+	for _, privilege := range d.Get(defaultPrivilegesPrivilegesAttr).(*schema.Set).List() {
+		if !validatePrivileges([]string{privilege.(string)}, objectType) {
+			return fmt.Errorf("privilege %q can't be granted by default on objects of type %s", privilege, objectType)
+		}
+	}
+
+	return nil
+}
+
 func resourceRedshiftDefaultPrivilegesDelete(db *DBConnection, d *schema.ResourceData) error {
 	revokeAlterDefaultQuery := createAlterDefaultsRevokeQuery(d)
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -124,7 +169,7 @@ func resourceRedshiftDefaultPrivilegesCreate(db *DBConnection, d *schema.Resourc
 		return fmt.Errorf("Invalid privileges list '%v' for object type '%s'", privileges, objectType)
 	}
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -161,12 +206,25 @@ func resourceRedshiftDefaultPrivilegesReadImpl(db *DBConnection, d *schema.Resou
 	schemaName, schemaNameSet := d.GetOk(defaultPrivilegesSchemaAttr)
 	ownerName := d.Get(defaultPrivilegesOwnerAttr).(string)
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
+	if roleName, roleNameSet := d.GetOk(defaultPrivilegesRoleAttr); roleNameSet {
+		log.Println("[DEBUG] reading role default privileges")
+		if err := readRoleDefaultPrivileges(tx, d, roleName.(string)); err != nil {
+			return fmt.Errorf("failed to read role default privileges: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("could not commit transaction: %w", err)
+		}
+
+		return nil
+	}
+
 	schemaID := defaultPrivilegesAllSchemasID
 	if schemaNameSet {
 		log.Printf("[DEBUG] getting ID for schema %s\n", schemaName)
@@ -204,6 +262,11 @@ func resourceRedshiftDefaultPrivilegesReadImpl(db *DBConnection, d *schema.Resou
 		if err := readGroupTableDefaultPrivileges(tx, d, entityID, schemaID, ownerID, entityIsUser); err != nil {
 			return fmt.Errorf("failed to read table privileges: %w", err)
 		}
+	case "FUNCTION", "PROCEDURE":
+		log.Println("[DEBUG] reading callable default privileges")
+		if err := readCallableDefaultPrivileges(tx, d, entityID, schemaID, ownerID, entityIsUser); err != nil {
+			return fmt.Errorf("failed to read %s privileges: %w", d.Get(defaultPrivilegesObjectTypeAttr).(string), err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -213,7 +276,7 @@ func resourceRedshiftDefaultPrivilegesReadImpl(db *DBConnection, d *schema.Resou
 	return nil
 }
 
-func readGroupTableDefaultPrivileges(tx *sql.Tx, d *schema.ResourceData, entityID, schemaID, ownerID int, entityIsUser bool) error {
+func readGroupTableDefaultPrivileges(tx *DBTransaction, d *schema.ResourceData, entityID, schemaID, ownerID int, entityIsUser bool) error {
 	var tableSelect, tableUpdate, tableInsert, tableDelete, tableDrop, tableReferences, tableRule, tableTrigger bool
 	var query string
 
@@ -286,6 +349,94 @@ func readGroupTableDefaultPrivileges(tx *sql.Tx, d *schema.ResourceData, entityI
 	return nil
 }
 
+func readCallableDefaultPrivileges(tx *DBTransaction, d *schema.ResourceData, entityID, schemaID, ownerID int, entityIsUser bool) error {
+	var callableExecute bool
+	var query string
+
+	if entityIsUser {
+		query = `
+	      SELECT
+		decode(charindex('X',split_part(split_part(regexp_replace(replace(array_to_string(defaclacl, '|'), '"', ''), 'group '||u.usename), u.usename||'=', 2) ,'/',1)),0,0,1) AS EXECUTE
+	      FROM pg_user u, pg_default_acl acl
+	      WHERE
+		acl.defaclnamespace = $1
+		AND regexp_replace(replace(array_to_string(acl.defaclacl, '|'), '"', ''), 'group '||u.usename) LIKE '%' || u.usename || '=%'
+		AND u.usesysid = $2
+		AND acl.defaclobjtype = $3
+		AND acl.defacluser = $4
+		`
+	} else {
+		query = `
+	      SELECT
+		decode(charindex('X',split_part(split_part(replace(array_to_string(defaclacl, '|'), '"', ''),'group ' || gr.groname,2 ) ,'/',1)),0,0,1) AS EXECUTE
+	      FROM pg_group gr, pg_default_acl acl
+	      WHERE
+		acl.defaclnamespace = $1
+		AND replace(array_to_string(acl.defaclacl, '|'), '"', '') LIKE '%' || 'group ' || gr.groname || '=%'
+		AND gr.grosysid = $2
+		AND acl.defaclobjtype = $3
+		AND acl.defacluser = $4
+		`
+	}
+
+	objectType := d.Get(defaultPrivilegesObjectTypeAttr).(string)
+	if err := tx.QueryRow(query, schemaID, entityID, defaultPrivilegesObjectTypesCodes[objectType], ownerID).Scan(&callableExecute); err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to collect privileges: %w", err)
+	}
+
+	privileges := []string{}
+	appendIfTrue(callableExecute, "execute", &privileges)
+
+	log.Printf("[DEBUG] Collected privileges for ID %d: %v\n", entityID, privileges)
+
+	d.Set(defaultPrivilegesPrivilegesAttr, privileges)
+
+	return nil
+}
+
+// readRoleDefaultPrivileges reads default privileges granted to a role. Roles don't show up
+// by name in pg_default_acl, so they are read from svv_default_privileges instead.
+func readRoleDefaultPrivileges(tx *DBTransaction, d *schema.ResourceData, roleName string) error {
+	schemaName := d.Get(defaultPrivilegesSchemaAttr).(string)
+	ownerName := d.Get(defaultPrivilegesOwnerAttr).(string)
+	objectType := d.Get(defaultPrivilegesObjectTypeAttr).(string)
+
+	query := `
+	      SELECT privilege_type
+	      FROM svv_default_privileges
+	      WHERE
+		grantee_type = 'role'
+		AND grantee_name = $1
+		AND owner_name = $2
+		AND object_type = $3
+		AND COALESCE(schema_name, '') = $4
+		`
+
+	rows, err := tx.Query(query, roleName, ownerName, defaultPrivilegesObjectTypesNames[objectType], schemaName)
+	if err != nil {
+		return fmt.Errorf("failed to collect privileges: %w", err)
+	}
+	defer rows.Close()
+
+	privileges := []string{}
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return err
+		}
+		privileges = append(privileges, strings.ToLower(privilege))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Collected privileges for role %s: %v\n", roleName, privileges)
+
+	d.Set(defaultPrivilegesPrivilegesAttr, privileges)
+
+	return nil
+}
+
 func generateDefaultPrivilegesID(d *schema.ResourceData) string {
 	var entityName, schemaName string
 
@@ -293,6 +444,8 @@ func generateDefaultPrivilegesID(d *schema.ResourceData) string {
 		entityName = fmt.Sprintf("gn:%s", groupName.(string))
 	} else if userName, isUser := d.GetOk(defaultPrivilegesUserAttr); isUser {
 		entityName = fmt.Sprintf("un:%s", userName.(string))
+	} else if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		entityName = fmt.Sprintf("rn:%s", roleName.(string))
 	}
 
 	if schemaNameRaw, schemaNameSet := d.GetOk(defaultPrivilegesSchemaAttr); schemaNameSet {
@@ -320,6 +473,9 @@ func createAlterDefaultsGrantQuery(d *schema.ResourceData, privileges []string)
 		toWhomIndicator = "GROUP"
 	} else if userName, isUser := d.GetOk(defaultPrivilegesUserAttr); isUser {
 		entityName = userName.(string)
+	} else if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		entityName = roleName.(string)
+		toWhomIndicator = "ROLE"
 	}
 
 	alterQuery := fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR USER %s", pq.QuoteIdentifier(ownerName))
@@ -349,6 +505,9 @@ func createAlterDefaultsRevokeQuery(d *schema.ResourceData) string {
 		fromWhomIndicator = "GROUP"
 	} else if userName, isUser := d.GetOk(defaultPrivilegesUserAttr); isUser {
 		entityName = userName.(string)
+	} else if roleName, isRole := d.GetOk(defaultPrivilegesRoleAttr); isRole {
+		entityName = roleName.(string)
+		fromWhomIndicator = "ROLE"
 	}
 
 	alterQuery := fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR USER %s", pq.QuoteIdentifier(ownerName))
@@ -203,6 +203,130 @@ func TestAccRedshiftDefaultPrivileges_UpdateToRevoke(t *testing.T) {
 	}
 }
 
+func TestAccRedshiftDefaultPrivileges_Role(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_role"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema"), "-", "_")
+
+	config := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+
+resource "redshift_default_privileges" "table" {
+  role        = redshift_role.role.name
+  schema      = redshift_schema.schema.name
+  owner       = "root"
+  object_type = "table"
+  privileges  = ["select", "insert"]
+}
+
+resource "redshift_default_privileges" "function" {
+  role        = redshift_role.role.name
+  schema      = redshift_schema.schema.name
+  owner       = "root"
+  object_type = "function"
+  privileges  = ["execute"]
+}
+`, roleName, schemaName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_default_privileges.table", "id", fmt.Sprintf("rn:%s_sn:%s_on:root_ot:table", roleName, schemaName)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.table", "role", roleName),
+					resource.TestCheckResourceAttr("redshift_default_privileges.table", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.table", "privileges.*", "select"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.table", "privileges.*", "insert"),
+
+					resource.TestCheckResourceAttr("redshift_default_privileges.function", "id", fmt.Sprintf("rn:%s_sn:%s_on:root_ot:function", roleName, schemaName)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.function", "object_type", "function"),
+					resource.TestCheckResourceAttr("redshift_default_privileges.function", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.function", "privileges.*", "execute"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDefaultPrivileges_Callables(t *testing.T) {
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_")
+
+	config := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_default_privileges" "function" {
+  group       = redshift_group.group.name
+  owner       = "root"
+  object_type = "function"
+  privileges  = ["execute"]
+}
+
+resource "redshift_default_privileges" "procedure" {
+  user        = redshift_user.user.name
+  owner       = "root"
+  object_type = "procedure"
+  privileges  = ["execute"]
+}
+`, groupName, userName)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDefaultPrivilegesDestory(defaultPrivilegesAllSchemasID, 100, "f", groupName),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_default_privileges.function", "id", fmt.Sprintf("gn:%s_noschema_on:root_ot:function", groupName)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.function", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.function", "privileges.*", "execute"),
+
+					resource.TestCheckResourceAttr("redshift_default_privileges.procedure", "id", fmt.Sprintf("un:%s_noschema_on:root_ot:procedure", userName)),
+					resource.TestCheckResourceAttr("redshift_default_privileges.procedure", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_default_privileges.procedure", "privileges.*", "execute"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftDefaultPrivileges_InvalidPrivilegeError(t *testing.T) {
+	config := `
+resource "redshift_default_privileges" "role" {
+  role = "test_role"
+
+  owner       = "root"
+  object_type = "table"
+  privileges  = ["select", "execute"]
+}
+`
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`privilege "execute" can't be granted by default on objects of type table`),
+			},
+		},
+	})
+}
+
 func TestAccRedshiftDefaultPrivileges_BothUserGroupError(t *testing.T) {
 	config := `
 resource "redshift_default_privileges" "both" {
@@ -220,7 +344,7 @@ resource "redshift_default_privileges" "both" {
 		Steps: []resource.TestStep{
 			{
 				Config:      config,
-				ExpectError: regexp.MustCompile("only one of `group,user` can be specified"),
+				ExpectError: regexp.MustCompile("only one of `group,role,user` can be specified"),
 			},
 		},
 	})
@@ -240,7 +364,7 @@ resource "redshift_default_privileges" "none" {
 		Steps: []resource.TestStep{
 			{
 				Config:      config,
-				ExpectError: regexp.MustCompile("one of `group,user` must be specified"),
+				ExpectError: regexp.MustCompile("one of `group,role,user` must be specified"),
 			},
 		},
 	})
@@ -0,0 +1,249 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	externalPartitionSchemaAttr   = "schema"
+	externalPartitionTableAttr    = "table"
+	externalPartitionValuesAttr   = externalTablePartitionValuesAttr
+	externalPartitionLocationAttr = externalTablePartitionLocationAttr
+)
+
+func redshiftExternalPartition() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a single partition of an external table with ` + "`ALTER TABLE ... ADD PARTITION`" + `, for tables whose partitions are added as new S3 prefixes appear, e.g. with ` + "`for_each`" + `. Changing the ` + "`location`" + ` moves the partition in place. Don't manage the same partition with a ` + "`partition`" + ` block of ` + "`redshift_external_table`" + ` as well.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftExternalPartitionCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftExternalPartitionRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftExternalPartitionUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftExternalPartitionDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftExternalPartitionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			externalPartitionSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the external schema the table belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			externalPartitionTableAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the external table.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			externalPartitionValuesAttr: {
+				Type:        schema.TypeMap,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Values of the partition, keyed by the names of the partition columns of the table.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			externalPartitionLocationAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The S3 folder holding the data of the partition.",
+				ValidateFunc: validation.StringMatch(
+					externalLocationRegexp, "must be an s3:// URI",
+				),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return externalLocationsEqual(old, new)
+				},
+			},
+		},
+	}
+}
+
+func externalPartitionTableIdent(d *schema.ResourceData) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(externalPartitionSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(externalPartitionTableAttr).(string)))
+}
+
+// externalPartitionColumns reads the partition columns of the table and checks
+// that the partition has a value for each of them.
+func externalPartitionColumns(db *DBConnection, d *schema.ResourceData) ([]string, error) {
+	schemaName, tableName := d.Get(externalPartitionSchemaAttr).(string), d.Get(externalPartitionTableAttr).(string)
+	partitionColumns, err := readExternalTablePartitionColumns(db, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(partitionColumns) == 0 {
+		return nil, fmt.Errorf("external table %s.%s doesn't exist or isn't partitioned", schemaName, tableName)
+	}
+
+	values := d.Get(externalPartitionValuesAttr).(map[string]interface{})
+	for _, column := range partitionColumns {
+		if _, ok := values[column]; !ok || len(values) != len(partitionColumns) {
+			return nil, fmt.Errorf("partition %v must have a value for each of the partition columns %v", values, partitionColumns)
+		}
+	}
+
+	return partitionColumns, nil
+}
+
+// generateExternalPartitionID returns <schema>.<table>/<column>=<value>/...,
+// like the S3 prefixes of Hive partitions.
+func generateExternalPartitionID(d *schema.ResourceData, partitionColumns []string) string {
+	values := d.Get(externalPartitionValuesAttr).(map[string]interface{})
+	parts := []string{fmt.Sprintf("%s.%s", d.Get(externalPartitionSchemaAttr).(string), d.Get(externalPartitionTableAttr).(string))}
+	for _, column := range partitionColumns {
+		parts = append(parts, fmt.Sprintf("%s=%s", column, values[column]))
+	}
+	return strings.Join(parts, "/")
+}
+
+func resourceRedshiftExternalPartitionImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	table := strings.SplitN(parts[0], ".", 2)
+	if len(parts) < 2 || len(table) != 2 || table[0] == "" || table[1] == "" {
+		return nil, fmt.Errorf("invalid external partition import ID %q, expected <schema>.<table>/<column>=<value>[/<column>=<value>...]", d.Id())
+	}
+
+	values := map[string]interface{}{}
+	for _, part := range parts[1:] {
+		column, value, ok := strings.Cut(part, "=")
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid external partition import ID %q, expected <schema>.<table>/<column>=<value>[/<column>=<value>...]", d.Id())
+		}
+		values[strings.ToLower(column)] = value
+	}
+
+	d.Set(externalPartitionSchemaAttr, table[0])
+	d.Set(externalPartitionTableAttr, table[1])
+	d.Set(externalPartitionValuesAttr, values)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftExternalPartitionRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftExternalPartitionReadImpl(db, d)
+}
+
+func resourceRedshiftExternalPartitionReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	schemaName, tableName := d.Get(externalPartitionSchemaAttr).(string), d.Get(externalPartitionTableAttr).(string)
+	partitionColumns, err := readExternalTablePartitionColumns(db, schemaName, tableName)
+	if err != nil {
+		return err
+	}
+	if len(partitionColumns) == 0 {
+		log.Printf("[WARN] Redshift External Table (%s.%s) not found", schemaName, tableName)
+		d.SetId("")
+		return nil
+	}
+
+	key := externalPartitionKey(partitionColumns, d.Get(externalPartitionValuesAttr).(map[string]interface{}))
+
+	// The values are usually reported exactly as the key is formatted, which
+	// avoids reading all the partitions of the table.
+	var location string
+	err = db.QueryRow(`
+  SELECT location
+  FROM svv_external_partitions
+  WHERE lower(schemaname) = lower($1) AND lower(tablename) = lower($2) AND values = $3
+`, schemaName, tableName, key).Scan(&location)
+	switch {
+	case err == sql.ErrNoRows:
+		found := false
+		err = forEachExternalPartition(db, schemaName, tableName, func(partitionKey, partitionLocation string) bool {
+			if partitionKey != key {
+				return true
+			}
+			found, location = true, partitionLocation
+			return false
+		})
+		if err != nil {
+			return err
+		}
+		if !found {
+			log.Printf("[WARN] Redshift External Partition (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+	case err != nil:
+		return fmt.Errorf("Error reading External Partition: %w", err)
+	}
+
+	d.SetId(generateExternalPartitionID(d, partitionColumns))
+	if !externalLocationsEqual(location, d.Get(externalPartitionLocationAttr).(string)) {
+		d.Set(externalPartitionLocationAttr, location)
+	}
+
+	return nil
+}
+
+// The ALTER TABLE statements of external tables can't run inside a transaction
+// block, so the statements below aren't run in one.
+
+func resourceRedshiftExternalPartitionCreate(db *DBConnection, d *schema.ResourceData) error {
+	partitionColumns, err := externalPartitionColumns(db, d)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		"ALTER TABLE %s ADD PARTITION %s LOCATION '%s'",
+		externalPartitionTableIdent(d),
+		externalPartitionSpec(partitionColumns, d.Get(externalPartitionValuesAttr).(map[string]interface{})),
+		pqQuoteLiteral(d.Get(externalPartitionLocationAttr).(string)),
+	)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Could not create redshift external partition: %w", createObjectError(err, "redshift_external_partition"))
+	}
+
+	d.SetId(generateExternalPartitionID(d, partitionColumns))
+
+	return resourceRedshiftExternalPartitionReadImpl(db, d)
+}
+
+func resourceRedshiftExternalPartitionUpdate(db *DBConnection, d *schema.ResourceData) error {
+	partitionColumns, err := externalPartitionColumns(db, d)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange(externalPartitionLocationAttr) {
+		partition := map[string]interface{}{
+			externalTablePartitionValuesAttr:   d.Get(externalPartitionValuesAttr),
+			externalTablePartitionLocationAttr: d.Get(externalPartitionLocationAttr),
+		}
+		if _, err := db.Exec(setExternalPartitionLocationQuery(externalPartitionTableIdent(d), partitionColumns, partition)); err != nil {
+			return fmt.Errorf("Error updating External Partition LOCATION: %w", err)
+		}
+	}
+
+	return resourceRedshiftExternalPartitionReadImpl(db, d)
+}
+
+func resourceRedshiftExternalPartitionDelete(db *DBConnection, d *schema.ResourceData) error {
+	partitionColumns, err := readExternalTablePartitionColumns(db, d.Get(externalPartitionSchemaAttr).(string), d.Get(externalPartitionTableAttr).(string))
+	if err != nil {
+		return err
+	}
+	// Dropping the table drops its partitions.
+	if len(partitionColumns) == 0 {
+		return nil
+	}
+
+	partition := map[string]interface{}{
+		externalTablePartitionValuesAttr: d.Get(externalPartitionValuesAttr),
+	}
+	_, err = db.Exec(dropExternalPartitionQuery(externalPartitionTableIdent(d), partitionColumns, partition))
+	return err
+}
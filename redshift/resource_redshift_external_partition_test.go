@@ -0,0 +1,128 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Acceptance test for partitions of external tables in an AWS Glue Data Catalog external schema
+// The following environment variables must be set, otherwise the test will be skipped:
+//
+//	REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_DATABASE - source database name
+//	REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_IAM_ROLE_ARNS - comma-separated list of ARNs to use
+//	REDSHIFT_EXTERNAL_TABLE_LOCATION - S3 prefix the IAM roles can read, e.g. s3://bucket/prefix/
+func TestAccRedshiftExternalPartition_Basic(t *testing.T) {
+	dbName := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_DATABASE", t)
+	iamRoleArnsRaw := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_IAM_ROLE_ARNS", t)
+	location := strings.TrimSuffix(getEnvOrSkip("REDSHIFT_EXTERNAL_TABLE_LOCATION", t), "/")
+	iamRoleArns, err := splitCsvAndTrim(iamRoleArnsRaw)
+	if err != nil {
+		t.Errorf("REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_IAM_ROLE_ARNS could not be parsed: %v", err)
+	}
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_external_partition_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_external_partition"), "-", "_")
+	config := func(partitionPrefix string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "spectrum" {
+  name = %[1]q
+  external_schema {
+    database_name = %[2]q
+    data_catalog_source {
+      iam_role_arns = %[3]s
+    }
+  }
+}
+
+resource "redshift_external_table" "table" {
+  name     = %[4]q
+  schema   = redshift_schema.spectrum.name
+  location = "%[5]s/%[4]s/"
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+
+  partition_by {
+    name = "year"
+    type = "integer"
+  }
+
+  partition_by {
+    name = "month"
+    type = "varchar(2)"
+  }
+}
+
+resource "redshift_external_partition" "partition" {
+  schema   = redshift_external_table.table.schema
+  table    = redshift_external_table.table.name
+  values   = { year = "2024", month = "01" }
+  location = "%[5]s/%[4]s/%[6]s/"
+}
+`, schemaName, dbName, tfArray(iamRoleArns), tableName, location, partitionPrefix)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config("year=2024/month=01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_external_partition.partition", "id", fmt.Sprintf("%s.%s/year=2024/month=01", schemaName, tableName)),
+					resource.TestCheckResourceAttr("redshift_external_partition.partition", "values.year", "2024"),
+				),
+			},
+			{
+				Config: config("moved/year=2024/month=01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_external_partition.partition", "location", fmt.Sprintf("%s/%s/moved/year=2024/month=01/", location, tableName)),
+				),
+			},
+			{
+				ResourceName:      "redshift_external_partition.partition",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestExternalPartitionImportID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftExternalPartition().Schema, map[string]interface{}{})
+	d.SetId("Spectrum.Sales/Year=2024/month=01")
+
+	if _, err := resourceRedshiftExternalPartitionImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if schemaName := d.Get(externalPartitionSchemaAttr).(string); schemaName != "Spectrum" {
+		t.Errorf("Expected schema Spectrum, got %q", schemaName)
+	}
+	if tableName := d.Get(externalPartitionTableAttr).(string); tableName != "Sales" {
+		t.Errorf("Expected table Sales, got %q", tableName)
+	}
+	expectedValues := map[string]interface{}{"year": "2024", "month": "01"}
+	if values := d.Get(externalPartitionValuesAttr).(map[string]interface{}); !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("Expected values %v, got %v", expectedValues, values)
+	}
+	if id := generateExternalPartitionID(d, []string{"year", "month"}); id != "Spectrum.Sales/year=2024/month=01" {
+		t.Errorf("Expected ID Spectrum.Sales/year=2024/month=01, got %q", id)
+	}
+
+	for _, invalid := range []string{"spectrum.sales", "sales/year=2024", "spectrum.sales/2024"} {
+		d.SetId(invalid)
+		if _, err := resourceRedshiftExternalPartitionImport(context.Background(), d, nil); err == nil {
+			t.Errorf("Expected import ID %q to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,711 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	externalTableNameAttr              = "name"
+	externalTableSchemaAttr            = "schema"
+	externalTableColumnAttr            = "column"
+	externalTableColumnNameAttr        = "name"
+	externalTableColumnTypeAttr        = "type"
+	externalTablePartitionByAttr       = "partition_by"
+	externalTableLocationAttr          = "location"
+	externalTableFileFormatAttr        = "file_format"
+	externalTableRowFormatAttr         = "row_format"
+	externalTableTablePropertiesAttr   = "table_properties"
+	externalTablePartitionAttr         = "partition"
+	externalTablePartitionValuesAttr   = "values"
+	externalTablePartitionLocationAttr = "location"
+)
+
+// externalTableInputFormats maps the input formats reported by
+// svv_external_tables to the file formats accepted by STORED AS.
+var externalTableInputFormats = map[string]string{
+	"org.apache.hadoop.hive.ql.io.parquet.MapredParquetInputFormat": "PARQUET",
+	"org.apache.hadoop.hive.ql.io.orc.OrcInputFormat":               "ORC",
+	"org.apache.hadoop.hive.ql.io.RCFileInputFormat":                "RCFILE",
+	"org.apache.hadoop.mapred.SequenceFileInputFormat":              "SEQUENCEFILE",
+	"org.apache.hadoop.mapred.TextInputFormat":                      "TEXTFILE",
+	"org.apache.hadoop.hive.ql.io.avro.AvroContainerInputFormat":    "AVRO",
+}
+
+// externalPartitionsPageSize is the number of partitions read from the catalog at once.
+const externalPartitionsPageSize = 1000
+
+var externalLocationRegexp = regexp.MustCompile(`^s3://.+`)
+
+var externalTableFileFormats = []string{"PARQUET", "ORC", "RCFILE", "SEQUENCEFILE", "TEXTFILE", "AVRO"}
+
+// externalColumnTypeAliases maps the type names accepted by CREATE EXTERNAL
+// TABLE to the names reported by svv_external_columns.
+var externalColumnTypeAliases = map[string]string{
+	"integer":           "int",
+	"int4":              "int",
+	"int2":              "smallint",
+	"int8":              "bigint",
+	"real":              "float",
+	"float4":            "float",
+	"double precision":  "double",
+	"float8":            "double",
+	"bool":              "boolean",
+	"character varying": "varchar",
+	"character":         "char",
+	"numeric":           "decimal",
+}
+
+func redshiftExternalTable() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages an external table of a Redshift Spectrum external schema, reading its data from Amazon S3. Changing the columns, the partition columns or ` + "`row_format`" + ` forces the table to be recreated, which only drops its definition from the catalog, the data in S3 is left untouched.
+
+The partitions listed in ` + "`partition`" + ` blocks are added with ` + "`ALTER TABLE ... ADD PARTITION`" + ` and dropped with ` + "`ALTER TABLE ... DROP PARTITION`" + `. Partitions added outside of Terraform, e.g. by a Glue crawler or with ` + "`redshift_external_partition`" + ` resources, are left alone.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftExternalTableCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftExternalTableRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftExternalTableUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftExternalTableDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftExternalTableImport,
+		},
+		CustomizeDiff: validateExternalTablePartitions,
+		Schema: map[string]*schema.Schema{
+			externalTableNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the external table.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			externalTableSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the external schema the table belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			externalTableColumnAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Columns of the table, in order, without the partition columns.",
+				Elem:        externalTableColumnResource(),
+			},
+			externalTablePartitionByAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Partition columns of the table, in order.",
+				Elem:        externalTableColumnResource(),
+			},
+			externalTableLocationAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The S3 folder or manifest file holding the data of the table, e.g. `s3://bucket/prefix/`.",
+				ValidateFunc: validation.StringMatch(
+					externalLocationRegexp, "must be an s3:// URI",
+				),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return externalLocationsEqual(old, new)
+				},
+			},
+			externalTableFileFormatAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PARQUET",
+				Description:  "Format of the data files (one of: " + strings.Join(externalTableFileFormats, ", ") + ").",
+				ValidateFunc: validation.StringInSlice(externalTableFileFormats, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+			},
+			externalTableRowFormatAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The clause following `ROW FORMAT`, e.g. `DELIMITED FIELDS TERMINATED BY ','` or `SERDE 'org.openx.data.jsonserde.JsonSerDe'`. It isn't read back from Redshift.",
+			},
+			externalTableTablePropertiesAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Table properties, e.g. `numRows` or `skip.header.line.count`. Only the configured properties are read back, and Redshift can't unset a property, so removed properties keep their last value.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			externalTablePartitionAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Partitions of the table.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						externalTablePartitionValuesAttr: {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Description: "Values of the partition, keyed by the names of the `partition_by` columns.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						externalTablePartitionLocationAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The S3 folder holding the data of the partition.",
+							ValidateFunc: validation.StringMatch(
+								externalLocationRegexp, "must be an s3:// URI",
+							),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func externalTableColumnResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			externalTableColumnNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the column.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			externalTableColumnTypeAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Data type of the column, e.g. `integer`, `varchar(256)` or `timestamp`.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeExternalColumnType(old) == normalizeExternalColumnType(new)
+				},
+			},
+		},
+	}
+}
+
+func normalizeExternalColumnType(columnType string) string {
+	columnType = strings.Join(strings.Fields(strings.ToLower(columnType)), " ")
+
+	matches := tableColumnTypeRegexp.FindStringSubmatch(columnType)
+	if matches == nil {
+		return columnType
+	}
+
+	name, modifier := matches[1], strings.ReplaceAll(matches[2], " ", "")
+	if alias, ok := externalColumnTypeAliases[name]; ok {
+		name = alias
+	}
+
+	return name + modifier
+}
+
+func externalLocationsEqual(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+func validateExternalTablePartitions(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(externalTablePartitionAttr) || !d.NewValueKnown(externalTablePartitionByAttr) {
+		return nil
+	}
+
+	partitionColumns := externalTablePartitionColumns(d.Get(externalTablePartitionByAttr).([]interface{}))
+	for _, raw := range d.Get(externalTablePartitionAttr).(*schema.Set).List() {
+		values := raw.(map[string]interface{})[externalTablePartitionValuesAttr].(map[string]interface{})
+		if len(values) != len(partitionColumns) {
+			return fmt.Errorf("partition %v must have a value for each of the partition columns %v", values, partitionColumns)
+		}
+		for _, column := range partitionColumns {
+			if _, ok := values[column]; !ok {
+				return fmt.Errorf("partition %v must have a value for each of the partition columns %v", values, partitionColumns)
+			}
+		}
+	}
+
+	return nil
+}
+
+func externalTablePartitionColumns(partitionBy []interface{}) []string {
+	columns := []string{}
+	for _, raw := range partitionBy {
+		columns = append(columns, strings.ToLower(raw.(map[string]interface{})[externalTableColumnNameAttr].(string)))
+	}
+	return columns
+}
+
+func externalTableIdent(d resourceValues) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(externalTableSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(externalTableNameAttr).(string)))
+}
+
+func externalTableColumnDefinitions(columns []interface{}) string {
+	definitions := []string{}
+	for _, raw := range columns {
+		column := raw.(map[string]interface{})
+		definitions = append(definitions, fmt.Sprintf("%s %s", pq.QuoteIdentifier(column[externalTableColumnNameAttr].(string)), column[externalTableColumnTypeAttr].(string)))
+	}
+	return strings.Join(definitions, ", ")
+}
+
+func externalTablePropertiesSQL(properties map[string]interface{}) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("'%s'='%s'", pqQuoteLiteral(key), pqQuoteLiteral(properties[key].(string))))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func createExternalTableQuery(d resourceValues) string {
+	query := fmt.Sprintf(
+		"CREATE EXTERNAL TABLE %s (%s)",
+		externalTableIdent(d),
+		externalTableColumnDefinitions(d.Get(externalTableColumnAttr).([]interface{})),
+	)
+
+	if partitionBy := d.Get(externalTablePartitionByAttr).([]interface{}); len(partitionBy) > 0 {
+		query = fmt.Sprintf("%s PARTITIONED BY (%s)", query, externalTableColumnDefinitions(partitionBy))
+	}
+
+	if rowFormat, ok := d.GetOk(externalTableRowFormatAttr); ok {
+		query = fmt.Sprintf("%s ROW FORMAT %s", query, rowFormat.(string))
+	}
+
+	query = fmt.Sprintf(
+		"%s STORED AS %s LOCATION '%s'",
+		query,
+		strings.ToUpper(d.Get(externalTableFileFormatAttr).(string)),
+		pqQuoteLiteral(d.Get(externalTableLocationAttr).(string)),
+	)
+
+	if properties := d.Get(externalTableTablePropertiesAttr).(map[string]interface{}); len(properties) > 0 {
+		query = fmt.Sprintf("%s TABLE PROPERTIES (%s)", query, externalTablePropertiesSQL(properties))
+	}
+
+	return query
+}
+
+// externalPartitionSpec renders the values of a partition in the order of the
+// partition columns, e.g. (year='2024', month='01').
+func externalPartitionSpec(partitionColumns []string, values map[string]interface{}) string {
+	pairs := []string{}
+	for _, column := range partitionColumns {
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", pq.QuoteIdentifier(column), pqQuoteLiteral(values[column].(string))))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(pairs, ", "))
+}
+
+func addExternalPartitionQuery(tableIdent string, partitionColumns []string, partition map[string]interface{}) string {
+	return fmt.Sprintf(
+		"ALTER TABLE %s ADD IF NOT EXISTS PARTITION %s LOCATION '%s'",
+		tableIdent,
+		externalPartitionSpec(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{})),
+		pqQuoteLiteral(partition[externalTablePartitionLocationAttr].(string)),
+	)
+}
+
+func dropExternalPartitionQuery(tableIdent string, partitionColumns []string, partition map[string]interface{}) string {
+	return fmt.Sprintf(
+		"ALTER TABLE %s DROP PARTITION %s",
+		tableIdent,
+		externalPartitionSpec(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{})),
+	)
+}
+
+func setExternalPartitionLocationQuery(tableIdent string, partitionColumns []string, partition map[string]interface{}) string {
+	return fmt.Sprintf(
+		"ALTER TABLE %s PARTITION %s SET LOCATION '%s'",
+		tableIdent,
+		externalPartitionSpec(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{})),
+		pqQuoteLiteral(partition[externalTablePartitionLocationAttr].(string)),
+	)
+}
+
+// externalPartitionKey identifies a partition by its values, in the order of
+// the partition columns.
+func externalPartitionKey(partitionColumns []string, values map[string]interface{}) string {
+	ordered := []string{}
+	for _, column := range partitionColumns {
+		value, _ := values[column].(string)
+		ordered = append(ordered, value)
+	}
+	key, _ := json.Marshal(ordered)
+	return string(key)
+}
+
+func generateExternalTableID(d resourceValues) string {
+	return fmt.Sprintf("%s.%s", d.Get(externalTableSchemaAttr).(string), d.Get(externalTableNameAttr).(string))
+}
+
+func resourceRedshiftExternalTableImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid external table import ID %q, expected <schema>.<name>", d.Id())
+	}
+
+	d.Set(externalTableSchemaAttr, parts[0])
+	d.Set(externalTableNameAttr, parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftExternalTableRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftExternalTableReadImpl(db, d)
+}
+
+func resourceRedshiftExternalTableReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(externalTableSchemaAttr).(string)
+	tableName := d.Get(externalTableNameAttr).(string)
+
+	var location, inputFormat, parameters string
+	err := db.QueryRow(`
+  SELECT location, COALESCE(input_format, ''), COALESCE(parameters, '')
+  FROM svv_external_tables
+  WHERE lower(schemaname) = lower($1) AND lower(tablename) = lower($2)
+`, schemaName, tableName).Scan(&location, &inputFormat, &parameters)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift External Table (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading External Table: %w", err)
+	}
+
+	rows, err := db.Query(`
+  SELECT columnname, external_type, part_key
+  FROM svv_external_columns
+  WHERE lower(schemaname) = lower($1) AND lower(tablename) = lower($2)
+  ORDER BY part_key, columnnum
+`, schemaName, tableName)
+	if err != nil {
+		return fmt.Errorf("Error reading External Table columns: %w", err)
+	}
+	defer rows.Close()
+
+	configuredColumns := map[string]map[string]interface{}{}
+	for _, attr := range []string{externalTableColumnAttr, externalTablePartitionByAttr} {
+		for _, raw := range d.Get(attr).([]interface{}) {
+			column := raw.(map[string]interface{})
+			configuredColumns[strings.ToLower(column[externalTableColumnNameAttr].(string))] = column
+		}
+	}
+
+	columns := []map[string]interface{}{}
+	partitionBy := []map[string]interface{}{}
+	for rows.Next() {
+		var columnName, columnType string
+		var partKey int
+		if err := rows.Scan(&columnName, &columnType, &partKey); err != nil {
+			return err
+		}
+
+		// Keep the configured case of the name and spelling of equivalent
+		// types to avoid spurious diffs.
+		if configured, ok := configuredColumns[strings.ToLower(columnName)]; ok {
+			columnName = configured[externalTableColumnNameAttr].(string)
+			if configuredType := configured[externalTableColumnTypeAttr].(string); normalizeExternalColumnType(configuredType) == normalizeExternalColumnType(columnType) {
+				columnType = configuredType
+			}
+		}
+
+		column := map[string]interface{}{
+			externalTableColumnNameAttr: columnName,
+			externalTableColumnTypeAttr: columnType,
+		}
+		if partKey > 0 {
+			partitionBy = append(partitionBy, column)
+		} else {
+			columns = append(columns, column)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	partitions, err := readExternalTablePartitions(db, d, externalTablePartitionColumns(toInterfaceSlice(partitionBy)))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(generateExternalTableID(d))
+	d.Set(externalTableColumnAttr, columns)
+	d.Set(externalTablePartitionByAttr, partitionBy)
+	if !externalLocationsEqual(location, d.Get(externalTableLocationAttr).(string)) {
+		d.Set(externalTableLocationAttr, location)
+	}
+	if fileFormat, ok := externalTableInputFormats[inputFormat]; ok {
+		d.Set(externalTableFileFormatAttr, fileFormat)
+	}
+	d.Set(externalTableTablePropertiesAttr, externalTableConfiguredProperties(parameters, d.Get(externalTableTablePropertiesAttr).(map[string]interface{})))
+	d.Set(externalTablePartitionAttr, partitions)
+
+	return nil
+}
+
+func toInterfaceSlice(values []map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+// externalTableConfiguredProperties returns the configured table properties
+// found in the parameters reported by svv_external_tables. Redshift sets
+// several properties itself, which aren't managed.
+func externalTableConfiguredProperties(parameters string, configured map[string]interface{}) map[string]interface{} {
+	actual := map[string]string{}
+	if err := json.Unmarshal([]byte(parameters), &actual); err != nil {
+		log.Printf("[WARN] could not parse external table parameters %q: %v", parameters, err)
+	}
+
+	properties := map[string]interface{}{}
+	for key := range configured {
+		if value, ok := actual[key]; ok {
+			properties[key] = value
+		}
+	}
+	return properties
+}
+
+// readExternalTablePartitions returns the partitions of the state which still
+// exist, with their actual location.
+func readExternalTablePartitions(db *DBConnection, d *schema.ResourceData, partitionColumns []string) ([]interface{}, error) {
+	managed := map[string]map[string]interface{}{}
+	for _, raw := range d.Get(externalTablePartitionAttr).(*schema.Set).List() {
+		partition := raw.(map[string]interface{})
+		managed[externalPartitionKey(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{}))] = partition
+	}
+	if len(managed) == 0 {
+		return []interface{}{}, nil
+	}
+
+	partitions := []interface{}{}
+	err := forEachExternalPartition(db, d.Get(externalTableSchemaAttr).(string), d.Get(externalTableNameAttr).(string), func(key, location string) bool {
+		partition, ok := managed[key]
+		if !ok {
+			return true
+		}
+		if !externalLocationsEqual(location, partition[externalTablePartitionLocationAttr].(string)) {
+			partition[externalTablePartitionLocationAttr] = location
+		}
+		partitions = append(partitions, partition)
+		delete(managed, key)
+
+		// Stop reading the catalog once all the partitions are found.
+		return len(managed) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return partitions, nil
+}
+
+// forEachExternalPartition calls fn with the key and the location of each
+// partition of an external table, until it returns false. Tables can have
+// hundreds of thousands of partitions, so the catalog is read in pages ordered
+// by the partition values.
+func forEachExternalPartition(db *DBConnection, schemaName, tableName string, fn func(key, location string) bool) error {
+	cursor := ""
+	for {
+		rows, err := db.Query(`
+  SELECT values, location
+  FROM svv_external_partitions
+  WHERE lower(schemaname) = lower($1) AND lower(tablename) = lower($2) AND values > $3
+  ORDER BY values
+  LIMIT $4
+`, schemaName, tableName, cursor, externalPartitionsPageSize)
+		if err != nil {
+			return fmt.Errorf("Error reading External Table partitions: %w", err)
+		}
+
+		count := 0
+		for rows.Next() {
+			var values, location string
+			if err := rows.Scan(&values, &location); err != nil {
+				rows.Close()
+				return err
+			}
+			count++
+			cursor = values
+
+			// The values are reported as a JSON array, in the order of the partition columns.
+			var ordered []string
+			if err := json.Unmarshal([]byte(values), &ordered); err != nil {
+				rows.Close()
+				return fmt.Errorf("Error parsing External Table partition values %q: %w", values, err)
+			}
+			key, _ := json.Marshal(ordered)
+
+			if !fn(string(key), location) {
+				rows.Close()
+				return nil
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		if count < externalPartitionsPageSize {
+			return nil
+		}
+	}
+}
+
+// readExternalTablePartitionColumns returns the names of the partition columns
+// of an external table, in order.
+func readExternalTablePartitionColumns(db *DBConnection, schemaName, tableName string) ([]string, error) {
+	rows, err := db.Query(`
+  SELECT columnname
+  FROM svv_external_columns
+  WHERE lower(schemaname) = lower($1) AND lower(tablename) = lower($2) AND part_key > 0
+  ORDER BY part_key
+`, schemaName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading External Table partition columns: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []string{}
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+// CREATE EXTERNAL TABLE and the ALTER TABLE statements of external tables
+// can't run inside a transaction block, so the statements below aren't run in
+// one.
+
+func resourceRedshiftExternalTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	if _, err := db.Exec(createExternalTableQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift external table: %w", createObjectError(err, "redshift_external_table"))
+	}
+
+	d.SetId(generateExternalTableID(d))
+
+	partitionColumns := externalTablePartitionColumns(d.Get(externalTablePartitionByAttr).([]interface{}))
+	for _, raw := range d.Get(externalTablePartitionAttr).(*schema.Set).List() {
+		if _, err := db.Exec(addExternalPartitionQuery(externalTableIdent(d), partitionColumns, raw.(map[string]interface{}))); err != nil {
+			return fmt.Errorf("Error adding External Table partition: %w", err)
+		}
+	}
+
+	return resourceRedshiftExternalTableReadImpl(db, d)
+}
+
+func resourceRedshiftExternalTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	_, err := db.Exec(fmt.Sprintf("DROP TABLE %s", externalTableIdent(d)))
+	return err
+}
+
+func resourceRedshiftExternalTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tableIdent := externalTableIdent(d)
+
+	if d.HasChange(externalTableLocationAttr) {
+		query := fmt.Sprintf("ALTER TABLE %s SET LOCATION '%s'", tableIdent, pqQuoteLiteral(d.Get(externalTableLocationAttr).(string)))
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("Error updating External Table LOCATION: %w", err)
+		}
+	}
+
+	if d.HasChange(externalTableFileFormatAttr) {
+		query := fmt.Sprintf("ALTER TABLE %s SET FILE FORMAT %s", tableIdent, strings.ToUpper(d.Get(externalTableFileFormatAttr).(string)))
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("Error updating External Table FILE FORMAT: %w", err)
+		}
+	}
+
+	if d.HasChange(externalTableTablePropertiesAttr) {
+		if properties := d.Get(externalTableTablePropertiesAttr).(map[string]interface{}); len(properties) > 0 {
+			query := fmt.Sprintf("ALTER TABLE %s SET TABLE PROPERTIES (%s)", tableIdent, externalTablePropertiesSQL(properties))
+			if _, err := db.Exec(query); err != nil {
+				return fmt.Errorf("Error updating External Table TABLE PROPERTIES: %w", err)
+			}
+		}
+	}
+
+	if err := setExternalTablePartitions(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftExternalTableReadImpl(db, d)
+}
+
+// setExternalTablePartitions drops the removed partitions, moves the ones
+// whose location changed and adds the new ones. Partitions are matched by
+// their values, so that large sets are diffed in a single pass.
+func setExternalTablePartitions(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(externalTablePartitionAttr) {
+		return nil
+	}
+
+	tableIdent := externalTableIdent(d)
+	partitionColumns := externalTablePartitionColumns(d.Get(externalTablePartitionByAttr).([]interface{}))
+	oldRaw, newRaw := d.GetChange(externalTablePartitionAttr)
+
+	oldPartitions := map[string]map[string]interface{}{}
+	for _, raw := range oldRaw.(*schema.Set).List() {
+		partition := raw.(map[string]interface{})
+		oldPartitions[externalPartitionKey(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{}))] = partition
+	}
+	newPartitions := map[string]map[string]interface{}{}
+	for _, raw := range newRaw.(*schema.Set).List() {
+		partition := raw.(map[string]interface{})
+		newPartitions[externalPartitionKey(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{}))] = partition
+	}
+
+	for key, partition := range oldPartitions {
+		if _, ok := newPartitions[key]; ok {
+			continue
+		}
+		if _, err := db.Exec(dropExternalPartitionQuery(tableIdent, partitionColumns, partition)); err != nil {
+			return fmt.Errorf("Error dropping External Table partition: %w", err)
+		}
+	}
+
+	for key, partition := range newPartitions {
+		oldPartition, ok := oldPartitions[key]
+		switch {
+		case !ok:
+			if _, err := db.Exec(addExternalPartitionQuery(tableIdent, partitionColumns, partition)); err != nil {
+				return fmt.Errorf("Error adding External Table partition: %w", err)
+			}
+		case !externalLocationsEqual(oldPartition[externalTablePartitionLocationAttr].(string), partition[externalTablePartitionLocationAttr].(string)):
+			if _, err := db.Exec(setExternalPartitionLocationQuery(tableIdent, partitionColumns, partition)); err != nil {
+				return fmt.Errorf("Error updating External Table partition LOCATION: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Acceptance test for external tables in an AWS Glue Data Catalog external schema
+// The following environment variables must be set, otherwise the test will be skipped:
+//
+//	REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_DATABASE - source database name
+//	REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_IAM_ROLE_ARNS - comma-separated list of ARNs to use
+//	REDSHIFT_EXTERNAL_TABLE_LOCATION - S3 prefix the IAM roles can read, e.g. s3://bucket/prefix/
+func TestAccRedshiftExternalTable_Basic(t *testing.T) {
+	dbName := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_DATABASE", t)
+	iamRoleArnsRaw := getEnvOrSkip("REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_IAM_ROLE_ARNS", t)
+	location := strings.TrimSuffix(getEnvOrSkip("REDSHIFT_EXTERNAL_TABLE_LOCATION", t), "/")
+	iamRoleArns, err := splitCsvAndTrim(iamRoleArnsRaw)
+	if err != nil {
+		t.Errorf("REDSHIFT_EXTERNAL_SCHEMA_DATA_CATALOG_IAM_ROLE_ARNS could not be parsed: %v", err)
+	}
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_external_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_external_table"), "-", "_")
+	config := func(partitions string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "spectrum" {
+  name = %[1]q
+  external_schema {
+    database_name = %[2]q
+    data_catalog_source {
+      iam_role_arns = %[3]s
+    }
+  }
+}
+
+resource "redshift_external_table" "table" {
+  name     = %[4]q
+  schema   = redshift_schema.spectrum.name
+  location = "%[5]s/%[4]s/"
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+
+  column {
+    name = "name"
+    type = "varchar(64)"
+  }
+
+  partition_by {
+    name = "day"
+    type = "date"
+  }
+
+  table_properties = {
+    "numRows" = "100"
+  }
+
+%[6]s
+}
+`, schemaName, dbName, tfArray(iamRoleArns), tableName, location, partitions)
+	}
+	partition := func(day string) string {
+		return fmt.Sprintf(`
+  partition {
+    values   = { day = %[1]q }
+    location = "%[2]s/%[3]s/day=%[1]s/"
+  }
+`, day, location, tableName)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(partition("2024-01-01")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_external_table.table", "id", fmt.Sprintf("%s.%s", schemaName, tableName)),
+					resource.TestCheckResourceAttr("redshift_external_table.table", "file_format", "PARQUET"),
+					resource.TestCheckResourceAttr("redshift_external_table.table", "column.#", "2"),
+					resource.TestCheckResourceAttr("redshift_external_table.table", "partition_by.#", "1"),
+					resource.TestCheckResourceAttr("redshift_external_table.table", "table_properties.numRows", "100"),
+					resource.TestCheckResourceAttr("redshift_external_table.table", "partition.#", "1"),
+				),
+			},
+			{
+				Config: config(partition("2024-01-02") + partition("2024-01-03")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_external_table.table", "partition.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "redshift_external_table.table",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{externalTablePartitionAttr, externalTableTablePropertiesAttr},
+			},
+		},
+	})
+}
+
+func TestCreateExternalTableQuery(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftExternalTable().Schema, map[string]interface{}{
+		externalTableNameAttr:   "sales",
+		externalTableSchemaAttr: "spectrum",
+		externalTableColumnAttr: []interface{}{
+			map[string]interface{}{externalTableColumnNameAttr: "id", externalTableColumnTypeAttr: "integer"},
+			map[string]interface{}{externalTableColumnNameAttr: "price", externalTableColumnTypeAttr: "decimal(8,2)"},
+		},
+		externalTablePartitionByAttr: []interface{}{
+			map[string]interface{}{externalTableColumnNameAttr: "day", externalTableColumnTypeAttr: "date"},
+		},
+		externalTableRowFormatAttr:       "DELIMITED FIELDS TERMINATED BY ','",
+		externalTableFileFormatAttr:      "textfile",
+		externalTableLocationAttr:        "s3://bucket/sales/",
+		externalTableTablePropertiesAttr: map[string]interface{}{"skip.header.line.count": "1", "numRows": "10"},
+	})
+
+	expected := `CREATE EXTERNAL TABLE "spectrum"."sales" ("id" integer, "price" decimal(8,2)) PARTITIONED BY ("day" date) ROW FORMAT DELIMITED FIELDS TERMINATED BY ',' STORED AS TEXTFILE LOCATION 's3://bucket/sales/' TABLE PROPERTIES ('numRows'='10', 'skip.header.line.count'='1')`
+	if query := createExternalTableQuery(d); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+}
+
+func TestExternalPartitionQueries(t *testing.T) {
+	partitionColumns := []string{"year", "month"}
+	partition := map[string]interface{}{
+		externalTablePartitionValuesAttr:   map[string]interface{}{"month": "01", "year": "2024"},
+		externalTablePartitionLocationAttr: "s3://bucket/sales/year=2024/month=01/",
+	}
+
+	expectedAdd := `ALTER TABLE "spectrum"."sales" ADD IF NOT EXISTS PARTITION ("year"='2024', "month"='01') LOCATION 's3://bucket/sales/year=2024/month=01/'`
+	if query := addExternalPartitionQuery(`"spectrum"."sales"`, partitionColumns, partition); query != expectedAdd {
+		t.Errorf("Expected query %q, got %q", expectedAdd, query)
+	}
+
+	expectedDrop := `ALTER TABLE "spectrum"."sales" DROP PARTITION ("year"='2024', "month"='01')`
+	if query := dropExternalPartitionQuery(`"spectrum"."sales"`, partitionColumns, partition); query != expectedDrop {
+		t.Errorf("Expected query %q, got %q", expectedDrop, query)
+	}
+
+	expectedKey := `["2024","01"]`
+	if key := externalPartitionKey(partitionColumns, partition[externalTablePartitionValuesAttr].(map[string]interface{})); key != expectedKey {
+		t.Errorf("Expected key %q, got %q", expectedKey, key)
+	}
+}
+
+func TestExternalTableImportID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftExternalTable().Schema, map[string]interface{}{})
+	d.SetId("Spectrum.Sales")
+
+	if _, err := resourceRedshiftExternalTableImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if schemaName := d.Get(externalTableSchemaAttr).(string); schemaName != "Spectrum" {
+		t.Errorf("Expected schema Spectrum, got %q", schemaName)
+	}
+	if tableName := d.Get(externalTableNameAttr).(string); tableName != "Sales" {
+		t.Errorf("Expected name Sales, got %q", tableName)
+	}
+	if id := generateExternalTableID(d); id != "Spectrum.Sales" {
+		t.Errorf("Expected ID Spectrum.Sales, got %q", id)
+	}
+}
+
+func TestNormalizeExternalColumnType(t *testing.T) {
+	tests := map[string]string{
+		"integer":               "int",
+		"INT":                   "int",
+		"double precision":      "double",
+		"character varying(64)": "varchar(64)",
+		"numeric(8, 2)":         "decimal(8,2)",
+		"timestamp":             "timestamp",
+		"array<struct<a:int>>":  "array<struct<a:int>>",
+	}
+
+	for columnType, expected := range tests {
+		if normalized := normalizeExternalColumnType(columnType); normalized != expected {
+			t.Errorf("Expected %q to be normalized to %q, got %q", columnType, expected, normalized)
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	functionNameAttr           = "name"
+	functionSchemaAttr         = "schema"
+	functionArgumentAttr       = "argument"
+	functionArgumentNameAttr   = "name"
+	functionArgumentTypeAttr   = "type"
+	functionReturnsAttr        = "returns"
+	functionLanguageAttr       = "language"
+	functionVolatilityAttr     = "volatility"
+	functionBodyAttr           = "body"
+	functionLambdaFunctionAttr = "lambda_function"
+	functionIamRoleAttr        = "iam_role"
+
+	functionLanguageLambda = "lambda"
+
+	pqErrorCodeUndefinedFunction = "42883"
+)
+
+// functionIDRegexp matches IDs like <schema>.<name>(<type>,...).
+var functionIDRegexp = regexp.MustCompile(`^([^.(]+)\.([^(]+)\((.*)\)$`)
+
+var functionVolatilities = map[string]string{
+	"v": "VOLATILE",
+	"s": "STABLE",
+	"i": "IMMUTABLE",
+}
+
+func redshiftFunction() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a scalar user-defined function (UDF). The function can be written in SQL or Python, or be a Lambda UDF calling an AWS Lambda function. Redshift identifies a function by its name and the types of its arguments, so the ID of the resource is the function signature, e.g. ` + "`my_schema.f_add(integer,integer)`" + `, and changing the arguments or the return type recreates the function.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftFunctionCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftFunctionRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftFunctionUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftFunctionDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftFunctionExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftFunctionImport,
+		},
+		CustomizeDiff: validateFunctionLanguage,
+		Schema: map[string]*schema.Schema{
+			functionNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the function. Redshift reserves the `f_` prefix for UDFs, UDF names not starting with it might conflict with future built-in functions.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			functionSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the schema the function belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			functionArgumentAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arguments of the function, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						functionArgumentNameAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Name of the argument. Only Python UDFs use argument names, SQL UDFs refer to their arguments as `$1`, `$2`, etc.",
+						},
+						functionArgumentTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Data type of the argument, e.g. `integer` or `varchar`.",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+					},
+				},
+			},
+			functionReturnsAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Data type returned by the function.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeColumnType(old) == normalizeColumnType(new)
+				},
+			},
+			functionLanguageAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "sql",
+				Description:  "Language of the function. One of `sql`, `plpythonu` or `lambda` for Lambda UDFs.",
+				ValidateFunc: validation.StringInSlice([]string{"sql", "plpythonu", functionLanguageLambda}, false),
+			},
+			functionVolatilityAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "VOLATILE",
+				Description:  "Volatility of the function. One of `VOLATILE`, `STABLE` or `IMMUTABLE`.",
+				ValidateFunc: validation.StringInSlice([]string{"VOLATILE", "STABLE", "IMMUTABLE"}, false),
+			},
+			functionBodyAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The body of the function, without the surrounding dollar quotes. A `SELECT` clause for SQL UDFs or the Python program for Python UDFs. Differences in whitespace are ignored. Required unless `language` is `lambda`.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeCallableBody(old) == normalizeCallableBody(new)
+				},
+			},
+			functionLambdaFunctionAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name or ARN of the AWS Lambda function called by a Lambda UDF. Required when `language` is `lambda`.",
+			},
+			functionIamRoleAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ARN of the IAM role the cluster uses to invoke the Lambda function, or `default` to use the default IAM role of the cluster. Required when `language` is `lambda`.",
+			},
+		},
+	}
+}
+
+func validateFunctionLanguage(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get(functionLanguageAttr).(string) == functionLanguageLambda {
+		if _, ok := d.GetOk(functionBodyAttr); ok {
+			return fmt.Errorf("cannot specify `%s` when `%s` is `%s`", functionBodyAttr, functionLanguageAttr, functionLanguageLambda)
+		}
+		for _, attr := range []string{functionLambdaFunctionAttr, functionIamRoleAttr} {
+			if _, ok := d.GetOk(attr); !ok && d.NewValueKnown(attr) {
+				return fmt.Errorf("parameter `%s` is required when `%s` is `%s`", attr, functionLanguageAttr, functionLanguageLambda)
+			}
+		}
+		return nil
+	}
+
+	if _, ok := d.GetOk(functionBodyAttr); !ok && d.NewValueKnown(functionBodyAttr) {
+		return fmt.Errorf("parameter `%s` is required when `%s` is `%s`", functionBodyAttr, functionLanguageAttr, d.Get(functionLanguageAttr))
+	}
+	for _, attr := range []string{functionLambdaFunctionAttr, functionIamRoleAttr} {
+		if _, ok := d.GetOk(attr); ok {
+			return fmt.Errorf("parameter `%s` can only be used when `%s` is `%s`", attr, functionLanguageAttr, functionLanguageLambda)
+		}
+	}
+
+	return nil
+}
+
+// generateFunctionID returns the signature of the function, which with the
+// argument types makes overloaded functions distinct.
+func generateFunctionID(d *schema.ResourceData) string {
+	argumentTypes := []string{}
+	for _, raw := range d.Get(functionArgumentAttr).([]interface{}) {
+		argument := raw.(map[string]interface{})
+		argumentTypes = append(argumentTypes, strings.ToLower(argument[functionArgumentTypeAttr].(string)))
+	}
+
+	return fmt.Sprintf(
+		"%s.%s(%s)",
+		d.Get(functionSchemaAttr).(string),
+		d.Get(functionNameAttr).(string),
+		strings.Join(argumentTypes, ","),
+	)
+}
+
+func functionSignature(d *schema.ResourceData, name string) string {
+	argumentTypes := []string{}
+	for _, raw := range d.Get(functionArgumentAttr).([]interface{}) {
+		argument := raw.(map[string]interface{})
+		argumentTypes = append(argumentTypes, argument[functionArgumentTypeAttr].(string))
+	}
+
+	return fmt.Sprintf(
+		"%s.%s(%s)",
+		pq.QuoteIdentifier(d.Get(functionSchemaAttr).(string)),
+		pq.QuoteIdentifier(name),
+		strings.Join(argumentTypes, ", "),
+	)
+}
+
+func resourceRedshiftFunctionImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	matches := functionIDRegexp.FindStringSubmatch(d.Id())
+	if matches == nil {
+		return nil, fmt.Errorf("invalid function import ID %q, expected <schema>.<name>(<argument type>,...)", d.Id())
+	}
+
+	d.Set(functionSchemaAttr, matches[1])
+	d.Set(functionNameAttr, matches[2])
+	arguments := []interface{}{}
+	if matches[3] != "" {
+		for _, argumentType := range strings.Split(matches[3], ",") {
+			arguments = append(arguments, map[string]interface{}{
+				functionArgumentTypeAttr: strings.ToLower(strings.TrimSpace(argumentType)),
+			})
+		}
+	}
+	d.Set(functionArgumentAttr, arguments)
+	d.SetId(generateFunctionID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// isFunctionNotFoundError checks if the signature of the function couldn't be
+// resolved because the function or its schema doesn't exist.
+func isFunctionNotFoundError(err error) bool {
+	return isPqErrorWithCode(err, pqErrorCodeUndefinedFunction) || isPqErrorWithCode(err, pqErrorCodeInvalidSchemaName)
+}
+
+func resourceRedshiftFunctionExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT proname FROM pg_proc_info WHERE prooid = $1::regprocedure::oid", functionSignature(d, d.Get(functionNameAttr).(string))).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows, err != nil && isFunctionNotFoundError(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftFunctionRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftFunctionReadImpl(db, d)
+}
+
+func resourceRedshiftFunctionReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var functionName, schemaName, returns, volatility, language, body string
+
+	err := db.QueryRow(`
+  SELECT pr.proname, nsp.nspname, format_type(pr.prorettype, NULL), pr.provolatile, lg.lanname, pr.prosrc
+  FROM pg_proc_info pr
+    JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+    JOIN pg_language lg ON lg.oid = pr.prolang
+  WHERE pr.prooid = $1::regprocedure::oid
+`, functionSignature(d, d.Get(functionNameAttr).(string))).Scan(&functionName, &schemaName, &returns, &volatility, &language, &body)
+	switch {
+	case err == sql.ErrNoRows, err != nil && isFunctionNotFoundError(err):
+		log.Printf("[WARN] Redshift Function (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Function: %w", err)
+	}
+
+	d.Set(functionNameAttr, functionName)
+	d.Set(functionSchemaAttr, schemaName)
+	if normalizeColumnType(returns) != normalizeColumnType(d.Get(functionReturnsAttr).(string)) {
+		d.Set(functionReturnsAttr, returns)
+	}
+	if v, ok := functionVolatilities[volatility]; ok {
+		d.Set(functionVolatilityAttr, v)
+	}
+
+	// Lambda UDFs are stored with the exfunc language, their body holds the
+	// Lambda function settings which are kept from the configuration.
+	if language == "exfunc" {
+		d.Set(functionLanguageAttr, functionLanguageLambda)
+		return nil
+	}
+
+	d.Set(functionLanguageAttr, language)
+	if normalizeCallableBody(body) != normalizeCallableBody(d.Get(functionBodyAttr).(string)) {
+		d.Set(functionBodyAttr, strings.TrimSpace(body))
+	}
+
+	return nil
+}
+
+func createFunctionQuery(d *schema.ResourceData) string {
+	arguments := []string{}
+	for _, raw := range d.Get(functionArgumentAttr).([]interface{}) {
+		argument := raw.(map[string]interface{})
+		parts := []string{}
+		if name := argument[functionArgumentNameAttr].(string); name != "" {
+			parts = append(parts, pq.QuoteIdentifier(name))
+		}
+		parts = append(parts, argument[functionArgumentTypeAttr].(string))
+		arguments = append(arguments, strings.Join(parts, " "))
+	}
+
+	ident := fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(functionSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(functionNameAttr).(string)))
+	returns := d.Get(functionReturnsAttr).(string)
+	volatility := d.Get(functionVolatilityAttr).(string)
+
+	if language := d.Get(functionLanguageAttr).(string); language == functionLanguageLambda {
+		return fmt.Sprintf(
+			"CREATE OR REPLACE EXTERNAL FUNCTION %s(%s) RETURNS %s %s LAMBDA %s IAM_ROLE %s",
+			ident,
+			strings.Join(arguments, ", "),
+			returns,
+			volatility,
+			pq.QuoteLiteral(d.Get(functionLambdaFunctionAttr).(string)),
+			pq.QuoteLiteral(d.Get(functionIamRoleAttr).(string)),
+		)
+	}
+
+	body := d.Get(functionBodyAttr).(string)
+	quote := callableBodyQuote(body)
+
+	return fmt.Sprintf(
+		"CREATE OR REPLACE FUNCTION %s(%s) RETURNS %s %s AS %s\n%s\n%s LANGUAGE %s",
+		ident,
+		strings.Join(arguments, ", "),
+		returns,
+		volatility,
+		quote,
+		strings.TrimSpace(body),
+		quote,
+		d.Get(functionLanguageAttr).(string),
+	)
+}
+
+func resourceRedshiftFunctionCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(createFunctionQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift function: %w", createObjectError(err, "redshift_function"))
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateFunctionID(d))
+
+	return resourceRedshiftFunctionReadImpl(db, d)
+}
+
+func resourceRedshiftFunctionDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(fmt.Sprintf("DROP FUNCTION %s", functionSignature(d, d.Get(functionNameAttr).(string)))); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftFunctionUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setFunctionName(tx, d); err != nil {
+		return err
+	}
+
+	if d.HasChanges(functionBodyAttr, functionVolatilityAttr, functionLambdaFunctionAttr, functionIamRoleAttr) {
+		if _, err := tx.Exec(createFunctionQuery(d)); err != nil {
+			return fmt.Errorf("Error replacing Function: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateFunctionID(d))
+
+	return resourceRedshiftFunctionReadImpl(db, d)
+}
+
+func setFunctionName(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(functionNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(functionNameAttr)
+	sql := fmt.Sprintf(
+		"ALTER FUNCTION %s RENAME TO %s",
+		functionSignature(d, oldRaw.(string)),
+		pq.QuoteIdentifier(newRaw.(string)),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Function NAME: %w", err)
+	}
+
+	return nil
+}
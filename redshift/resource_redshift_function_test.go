@@ -0,0 +1,350 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftFunction_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_function_schema"), "-", "_")
+	functionName := strings.ReplaceAll(acctest.RandomWithPrefix("f_tf_acc"), "-", "_")
+	functionNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("f_tf_acc_updated"), "-", "_")
+	config := func(name, volatility, body string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_function" "sql" {
+  name       = %[2]q
+  schema     = redshift_schema.schema.name
+  returns    = "int"
+  volatility = %[3]q
+
+  argument {
+    type = "integer"
+  }
+
+  argument {
+    type = "integer"
+  }
+
+  body = %[4]q
+}
+
+# Overload with the same name and different argument types
+resource "redshift_function" "sql_overload" {
+  name    = redshift_function.sql.name
+  schema  = redshift_schema.schema.name
+  returns = "varchar"
+
+  argument {
+    type = "varchar"
+  }
+
+  body = "SELECT $1"
+}
+
+resource "redshift_function" "python" {
+  name     = "%[2]s_py"
+  schema   = redshift_schema.schema.name
+  returns  = "integer"
+  language = "plpythonu"
+
+  argument {
+    name = "a"
+    type = "integer"
+  }
+
+  body = <<-EOT
+    return a * 2
+  EOT
+}
+`, schemaName, name, volatility, body)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(functionName, "IMMUTABLE", "SELECT $1 + $2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftFunctionExists(schemaName, functionName, 2),
+					resource.TestCheckResourceAttr("redshift_function.sql", "id", fmt.Sprintf("%s.%s(integer,integer)", schemaName, functionName)),
+					resource.TestCheckResourceAttr("redshift_function.sql", "volatility", "IMMUTABLE"),
+					resource.TestCheckResourceAttr("redshift_function.sql", "language", "sql"),
+					resource.TestCheckResourceAttr("redshift_function.sql_overload", "id", fmt.Sprintf("%s.%s(varchar)", schemaName, functionName)),
+					resource.TestCheckResourceAttr("redshift_function.python", "language", "plpythonu"),
+				),
+			},
+			{
+				ResourceName:      "redshift_function.sql",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(functionName, "STABLE", "SELECT $1 * $2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_function.sql", "volatility", "STABLE"),
+					resource.TestCheckResourceAttr("redshift_function.sql", "body", "SELECT $1 * $2"),
+				),
+			},
+			{
+				Config: config(functionNameUpdated, "STABLE", "SELECT $1 * $2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftFunctionExists(schemaName, functionNameUpdated, 2),
+					resource.TestCheckResourceAttr("redshift_function.sql", "id", fmt.Sprintf("%s.%s(integer,integer)", schemaName, functionNameUpdated)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftFunction_LanguageValidation(t *testing.T) {
+	tests := map[string]struct {
+		config        string
+		expectedError string
+	}{
+		"lambda without function": {
+			config: `
+resource "redshift_function" "lambda" {
+  name     = "f_lambda"
+  schema   = "public"
+  returns  = "integer"
+  language = "lambda"
+  iam_role = "default"
+}
+`,
+			expectedError: "parameter `lambda_function` is required when `language` is `lambda`",
+		},
+		"lambda with body": {
+			config: `
+resource "redshift_function" "lambda" {
+  name            = "f_lambda"
+  schema          = "public"
+  returns         = "integer"
+  language        = "lambda"
+  lambda_function = "my-function"
+  iam_role        = "default"
+  body            = "SELECT 1"
+}
+`,
+			expectedError: "cannot specify `body` when `language` is `lambda`",
+		},
+		"sql without body": {
+			config: `
+resource "redshift_function" "sql" {
+  name    = "f_sql"
+  schema  = "public"
+  returns = "integer"
+}
+`,
+			expectedError: "parameter `body` is required when `language` is `sql`",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				CheckDestroy:      func(s *terraform.State) error { return nil },
+				Steps: []resource.TestStep{
+					{
+						Config:      tc.config,
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(tc.expectedError),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestCreateFunctionQuery(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		"sql": {
+			input: map[string]interface{}{
+				functionNameAttr:    "f_add",
+				functionSchemaAttr:  "analytics",
+				functionReturnsAttr: "integer",
+				functionArgumentAttr: []interface{}{
+					map[string]interface{}{functionArgumentTypeAttr: "integer"},
+					map[string]interface{}{functionArgumentTypeAttr: "integer"},
+				},
+				functionBodyAttr: "SELECT $1 + $2\n",
+			},
+			expected: "CREATE OR REPLACE FUNCTION \"analytics\".\"f_add\"(integer, integer) RETURNS integer VOLATILE AS $$\nSELECT $1 + $2\n$$ LANGUAGE sql",
+		},
+		"python": {
+			input: map[string]interface{}{
+				functionNameAttr:       "f_double",
+				functionSchemaAttr:     "analytics",
+				functionReturnsAttr:    "integer",
+				functionLanguageAttr:   "plpythonu",
+				functionVolatilityAttr: "IMMUTABLE",
+				functionArgumentAttr: []interface{}{
+					map[string]interface{}{functionArgumentNameAttr: "a", functionArgumentTypeAttr: "integer"},
+				},
+				functionBodyAttr: "return a * 2",
+			},
+			expected: "CREATE OR REPLACE FUNCTION \"analytics\".\"f_double\"(\"a\" integer) RETURNS integer IMMUTABLE AS $$\nreturn a * 2\n$$ LANGUAGE plpythonu",
+		},
+		"lambda": {
+			input: map[string]interface{}{
+				functionNameAttr:           "f_upper",
+				functionSchemaAttr:         "analytics",
+				functionReturnsAttr:        "varchar",
+				functionLanguageAttr:       "lambda",
+				functionLambdaFunctionAttr: "redshift-upper",
+				functionIamRoleAttr:        "arn:aws:iam::123456789012:role/RedshiftLambdaRole",
+				functionArgumentAttr: []interface{}{
+					map[string]interface{}{functionArgumentTypeAttr: "varchar"},
+				},
+			},
+			expected: "CREATE OR REPLACE EXTERNAL FUNCTION \"analytics\".\"f_upper\"(varchar) RETURNS varchar VOLATILE LAMBDA 'redshift-upper' IAM_ROLE 'arn:aws:iam::123456789012:role/RedshiftLambdaRole'",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftFunction().Schema, tc.input)
+			if query := createFunctionQuery(d); query != tc.expected {
+				t.Errorf("Expected query %q, got %q", tc.expected, query)
+			}
+		})
+	}
+}
+
+func TestResourceRedshiftFunctionImport(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		expectedID    string
+		argumentTypes []string
+		expectError   bool
+	}{
+		"without arguments": {
+			id:         "analytics.f_now()",
+			expectedID: "analytics.f_now()",
+		},
+		"with arguments": {
+			id:            "Analytics.F_Add(INTEGER, varchar)",
+			expectedID:    "Analytics.F_Add(integer,varchar)",
+			argumentTypes: []string{"integer", "varchar"},
+		},
+		"without schema": {
+			id:          "f_add(integer)",
+			expectError: true,
+		},
+		"without signature": {
+			id:          "analytics.f_add",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := redshiftFunction().TestResourceData()
+			d.SetId(tc.id)
+
+			_, err := resourceRedshiftFunctionImport(context.Background(), d, nil)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error for import ID %q", tc.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if d.Id() != tc.expectedID {
+				t.Errorf("Expected ID %q, got %q", tc.expectedID, d.Id())
+			}
+			arguments := d.Get(functionArgumentAttr).([]interface{})
+			if len(arguments) != len(tc.argumentTypes) {
+				t.Fatalf("Expected %d arguments, got %d", len(tc.argumentTypes), len(arguments))
+			}
+			for i, argumentType := range tc.argumentTypes {
+				if got := arguments[i].(map[string]interface{})[functionArgumentTypeAttr]; got != argumentType {
+					t.Errorf("Expected argument %d of type %q, got %q", i, argumentType, got)
+				}
+			}
+		})
+	}
+}
+
+func testAccCheckRedshiftFunctionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_function" {
+			continue
+		}
+
+		count, err := countFunctions(client, rs.Primary.Attributes[functionSchemaAttr], rs.Primary.Attributes[functionNameAttr])
+
+		if err != nil {
+			return fmt.Errorf("Error checking function %s", err)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("Function still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftFunctionExists(schemaName, functionName string, expectedOverloads int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		count, err := countFunctions(client, schemaName, functionName)
+		if err != nil {
+			return fmt.Errorf("Error checking function %s", err)
+		}
+
+		if count != expectedOverloads {
+			return fmt.Errorf("Expected %d functions named %s, found %d", expectedOverloads, functionName, count)
+		}
+
+		return nil
+	}
+}
+
+func countFunctions(client *Client, schemaName, functionName string) (int, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	err = db.QueryRow(`
+  SELECT count(*)
+  FROM pg_proc_info pr
+    JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+  WHERE nsp.nspname = $1 AND pr.proname = $2 AND pr.prokind = 'f'
+`, strings.ToLower(schemaName), strings.ToLower(functionName)).Scan(&count)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("Error reading info about function: %s", err)
+	}
+
+	return count, nil
+}
@@ -1,12 +1,17 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
@@ -15,12 +20,26 @@ import (
 const (
 	grantUserAttr       = "user"
 	grantGroupAttr      = "group"
+	grantRoleAttr       = "role"
 	grantSchemaAttr     = "schema"
 	grantObjectTypeAttr = "object_type"
 	grantObjectsAttr    = "objects"
 	grantPrivilegesAttr = "privileges"
+	grantColumnsAttr    = "columns"
+	grantDatabaseAttr   = "database"
+
+	grantArgumentTypesAttr = "argument_types"
+
+	grantAssumeRoleARNAttr = "assume_role_arn"
+	grantAssumeRoleForAttr = "for"
+
+	grantWithGrantOptionAttr = "with_grant_option"
+
+	grantRevokeExistingAttr = "revoke_existing"
 
 	grantToPublicName = "public"
+
+	grantImportIDFormat = "<grantee_type>:<grantee>:<object_type>:<schema>:<object>[:<database>]"
 )
 
 var grantAllowedObjectTypes = []string{
@@ -32,45 +51,93 @@ var grantAllowedObjectTypes = []string{
 	"language",
 }
 
+// grantAssumeRoleCommands are the commands for which an IAM role can be assumed.
+var grantAssumeRoleCommands = []string{
+	"COPY",
+	"UNLOAD",
+	"EXTERNAL FUNCTION",
+	"CREATE MODEL",
+}
+
+// grantColumnPrivileges are the privileges which can be granted on columns.
+var grantColumnPrivileges = []string{"select", "update"}
+
 var grantObjectTypesCodes = map[string][]string{
 	"table":     {"r", "m", "v"},
 	"procedure": {"p"},
 	"function":  {"f"},
 }
 
+// grantPrivilegesACLCodes maps privileges to the characters used for them in aclitem.
+var grantPrivilegesACLCodes = map[string]map[string]rune{
+	"database": {"create": 'C', "temporary": 'T', "usage": 'U'},
+	"schema":   {"create": 'C', "usage": 'U'},
+	"table": {
+		"select":     'r',
+		"update":     'w',
+		"insert":     'a',
+		"delete":     'd',
+		"drop":       'D',
+		"references": 'x',
+		"rule":       'R',
+		"trigger":    't',
+	},
+	"function":  {"execute": 'X'},
+	"procedure": {"execute": 'X'},
+	"language":  {"usage": 'U'},
+}
+
 func redshiftGrant() *schema.Resource {
 	return &schema.Resource{
 		Description: `
-Defines access privileges for users and  groups. Privileges include access options such as being able to read data in tables and views, write data, create tables, and drop tables. Use this command to give specific privileges for a table, database, schema, function, procedure, language, or column.
+Defines access privileges for users, groups and roles. Privileges include access options such as being able to read data in tables and views, write data, create tables, and drop tables. Use this command to give specific privileges for a table, database, schema, function, procedure, language, or column.
+
+Views, materialized views and late-binding views are granted on with the ` + "`table`" + ` object type. Querying a late-binding view only requires ` + "`SELECT`" + ` on the view, but its owner must be able to read the objects it references, which is only checked when it's queried. Destroying a grant leaves out the tables and views which don't exist anymore, e.g. a view dropped to be recreated, instead of failing to revoke the privileges on them.
 `,
-		ReadContext: RedshiftResourceFunc(resourceRedshiftGrantRead),
-		CreateContext: RedshiftResourceFunc(
+		ReadContext: warnOnHiddenPrivileges(RedshiftResourceFunc(resourceRedshiftGrantRead)),
+		CreateContext: warnOnHiddenPrivileges(RedshiftResourceFunc(
 			RedshiftResourceRetryOnPQErrors(resourceRedshiftGrantCreate),
-		),
+		)),
 		DeleteContext: RedshiftResourceFunc(
 			RedshiftResourceRetryOnPQErrors(resourceRedshiftGrantDelete),
 		),
 
 		// Since we revoke all when creating, we can use create as update
-		UpdateContext: RedshiftResourceFunc(
+		UpdateContext: warnOnHiddenPrivileges(RedshiftResourceFunc(
 			RedshiftResourceRetryOnPQErrors(resourceRedshiftGrantCreate),
+		)),
+		CustomizeDiff: customdiff.All(
+			validateGrantColumns,
+			validateGrantArgumentTypes,
+			// The schemas of other databases can't be looked up from the provider's database.
+			customdiff.If(func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.Get(grantDatabaseAttr).(string) == ""
+			}, validateSchemaReference(grantSchemaAttr)),
+			customizeGeneratedSQL(generateGrantSQL),
 		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftGrantImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
+			generatedSQLAttr: generatedSQLSchema(),
 			grantUserAttr: {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr},
-				Description:  "The name of the user to grant privileges on. Either `user` or `group` parameter must be set.",
-				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile("^(?i)public$"), "User name cannot be 'public'. To use GRANT ... TO PUBLIC set the group name to 'public' instead."),
+				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:  "The name of the user to grant privileges on. Exactly one of `user`, `group` or `role` parameters must be set.",
+				ValidateFunc: validation.StringDoesNotMatch(publicNameRegexp, "User name cannot be 'public'. To use GRANT ... TO PUBLIC set the group name to 'public' instead."),
 			},
 			grantGroupAttr: {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr},
-				Description:  "The name of the group to grant privileges on. Either `group` or `user` parameter must be set. Settings the group name to `public` or `PUBLIC` (it is case insensitive in this case) will result in a `GRANT ... TO PUBLIC` statement.",
+				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:  "The name of the group to grant privileges on. Exactly one of `user`, `group` or `role` parameters must be set. Settings the group name to `public` or `PUBLIC` (it is case insensitive in this case) will result in a `GRANT ... TO PUBLIC` statement.",
 				StateFunc: func(val interface{}) string {
 					name := val.(string)
 					if strings.ToLower(name) == grantToPublicName {
@@ -79,6 +146,16 @@ Defines access privileges for users and  groups. Privileges include access optio
 					return name
 				},
 			},
+			grantRoleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{grantUserAttr, grantGroupAttr, grantRoleAttr},
+				Description:  "The name of the role to grant privileges on. Exactly one of `user`, `group` or `role` parameters must be set.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
 			grantSchemaAttr: {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -87,40 +164,192 @@ Defines access privileges for users and  groups. Privileges include access optio
 			},
 			grantObjectTypeAttr: {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				ForceNew:     true,
+				ExactlyOneOf: []string{grantObjectTypeAttr, grantAssumeRoleARNAttr},
 				ValidateFunc: validation.StringInSlice(grantAllowedObjectTypes, false),
-				Description:  "The Redshift object type to grant privileges on (one of: " + strings.Join(grantAllowedObjectTypes, ", ") + ").",
+				Description:  "The Redshift object type to grant privileges on (one of: " + strings.Join(grantAllowedObjectTypes, ", ") + "). Exactly one of `object_type` or `assume_role_arn` must be set.",
 			},
 			grantObjectsAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         hashIdentifier,
+				Description: "The objects upon which to grant the privileges. An empty list (the default) means to grant permissions on all objects of the specified type (`GRANT ... ON ALL TABLES IN SCHEMA`, or `GRANT EXECUTE ON ALL PROCEDURES IN SCHEMA` for procedures). This only covers the objects existing when the grant is applied: objects created later are reported as a difference and granted on the next apply. Use `redshift_default_privileges` to grant privileges on future objects. Ignored when `object_type` is one of (`database`, `schema`).",
+			},
+			grantPrivilegesAttr: {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 					StateFunc: func(val interface{}) string {
-						return strings.ToLower(val.(string))
+						return normalizeGrantPrivilege(val.(string))
 					},
 				},
-				Set:         schema.HashString,
-				Description: "The objects upon which to grant the privileges. An empty list (the default) means to grant permissions on all objects of the specified type. Ignored when `object_type` is one of (`database`, `schema`).",
+				Set:          schema.HashString,
+				Description:  "The list of privileges to apply as default privileges. See [GRANT command documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html) to see what privileges are available to which object type. Databases accept `create`, `temporary` (or `temp`) and `usage`, the latter only for databases created from a datashare. An empty list could be provided to revoke all privileges for this user or group. Required when `object_type` is set to `language`.",
+				RequiredWith: []string{grantObjectTypeAttr},
 			},
-			grantPrivilegesAttr: {
+			grantColumnsAttr: {
 				Type:     schema.TypeSet,
-				Required: true,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         hashIdentifier,
+				Description: "The columns upon which to grant the privileges. Column-level privileges can only be granted when `object_type` is `table`, exactly one table is set in `objects` and `privileges` contains only `select` and `update`.",
+			},
+			grantDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The database containing the objects to grant privileges on. Defaults to the database the provider connects to. Granting privileges in other databases requires a cluster with RA3 node types or Redshift Serverless.",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			grantArgumentTypesAttr: {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
+				},
+				Description: "The argument types of the functions or procedures set in `objects`, e.g. `[\"integer\", \"varchar\"]`. Redshift identifies functions and procedures by their signature, so the argument types are appended to each object that doesn't already define them (like `my_function(float)`). Can only be used when `object_type` is `function` or `procedure`.",
+			},
+			grantAssumeRoleARNAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{grantObjectTypeAttr, grantAssumeRoleARNAttr},
+				RequiredWith: []string{grantAssumeRoleForAttr},
+				ConflictsWith: []string{
+					grantSchemaAttr,
+					grantObjectsAttr,
+					grantPrivilegesAttr,
+					grantColumnsAttr,
+					grantDatabaseAttr,
+					grantArgumentTypesAttr,
+					grantWithGrantOptionAttr,
+					grantRevokeExistingAttr,
+				},
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^arn:aws[a-z-]*:iam::`), "must be the ARN of an IAM role"),
+				Description:  "The ARN of the IAM role the grantee is allowed to assume (`GRANT ASSUMEROLE ON '<arn>' ...`). Several roles chained with commas can be set as one ARN. Can't be combined with object-level privileges, so `object_type`, `schema`, `objects`, `privileges`, `columns`, `database`, `argument_types`, `with_grant_option` and `revoke_existing` can't be set together with it.",
+			},
+			grantAssumeRoleForAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(append([]string{"ALL"}, grantAssumeRoleCommands...), true),
 					StateFunc: func(val interface{}) string {
-						return strings.ToLower(val.(string))
+						return strings.ToUpper(val.(string))
 					},
 				},
-				Set:         schema.HashString,
-				Description: "The list of privileges to apply as default privileges. See [GRANT command documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html) to see what privileges are available to which object type. An empty list could be provided to revoke all privileges for this user or group. Required when `object_type` is set to `language`.",
+				Set:          schema.HashString,
+				RequiredWith: []string{grantAssumeRoleARNAttr},
+				Description:  "The commands for which the grantee can assume the IAM role set in `assume_role_arn`, `ALL` or any of: " + strings.Join(grantAssumeRoleCommands, ", ") + ".",
+			},
+			grantWithGrantOptionAttr: {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{grantGroupAttr, grantRoleAttr},
+				Description:   "Whether the user can grant the privileges to others (`WITH GRANT OPTION`). Can only be used together with `user`, as the grant option can't be granted to groups, roles or `PUBLIC`.",
+			},
+			grantRevokeExistingAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to revoke, when the grant is created, the privileges the grantee already holds on the objects that the grant doesn't manage, making it authoritative for the grantee and the objects. All the privileges on the objects are always revoked before granting; this also revokes the grant option of users and, for tables, the column-level privileges on every column of the tables, or of all the tables of `schema` when `objects` is empty. Privileges granted by other means, e.g. another `redshift_grant` resource with the same grantee and objects, are lost. The privileges to revoke are read when applying, so their statements aren't part of `generated_sql`.",
 			},
 		},
 	}
 }
 
+func validateGrantColumns(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	columns := d.Get(grantColumnsAttr).(*schema.Set)
+	if columns.Len() == 0 {
+		return nil
+	}
+
+	if objectType := d.Get(grantObjectTypeAttr).(string); objectType != "table" {
+		return fmt.Errorf("cannot specify `%s` when `%s` is `%s`, column-level privileges can only be granted on tables", grantColumnsAttr, grantObjectTypeAttr, objectType)
+	}
+
+	if d.NewValueKnown(grantObjectsAttr) && d.Get(grantObjectsAttr).(*schema.Set).Len() != 1 {
+		return fmt.Errorf("exactly one table must be set in `%s` when `%s` is specified", grantObjectsAttr, grantColumnsAttr)
+	}
+
+	for _, privilege := range d.Get(grantPrivilegesAttr).(*schema.Set).List() {
+		if !isColumnPrivilege(privilege.(string)) {
+			return fmt.Errorf("privilege %q can't be granted on columns, only %s are supported", privilege, strings.Join(grantColumnPrivileges, ", "))
+		}
+	}
+
+	return nil
+}
+
+func validateGrantArgumentTypes(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	argumentTypes := d.Get(grantArgumentTypesAttr).([]interface{})
+	if len(argumentTypes) == 0 {
+		return nil
+	}
+
+	if objectType := d.Get(grantObjectTypeAttr).(string); objectType != "function" && objectType != "procedure" {
+		return fmt.Errorf("cannot specify `%s` when `%s` is `%s`, argument types can only be set for functions and procedures", grantArgumentTypesAttr, grantObjectTypeAttr, objectType)
+	}
+
+	if d.NewValueKnown(grantObjectsAttr) && d.Get(grantObjectsAttr).(*schema.Set).Len() == 0 {
+		return fmt.Errorf("parameter `%s` is required when `%s` is specified", grantObjectsAttr, grantArgumentTypesAttr)
+	}
+
+	return nil
+}
+
+// grantCallableObjects returns the functions or procedures to grant privileges
+// on, with the configured argument types appended to those defined without a
+// signature.
+func grantCallableObjects(d resourceValues) *schema.Set {
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+
+	argumentTypes := []string{}
+	for _, argumentType := range d.Get(grantArgumentTypesAttr).([]interface{}) {
+		argumentTypes = append(argumentTypes, argumentType.(string))
+	}
+	if len(argumentTypes) == 0 {
+		return objects
+	}
+
+	callables := schema.NewSet(hashIdentifier, nil)
+	for _, object := range objects.List() {
+		callable := object.(string)
+		if !strings.Contains(callable, "(") {
+			callable = fmt.Sprintf("%s(%s)", callable, strings.Join(argumentTypes, ","))
+		}
+		callables.Add(callable)
+	}
+
+	return callables
+}
+
+func isColumnPrivilege(privilege string) bool {
+	for _, p := range grantColumnPrivileges {
+		if strings.ToLower(privilege) == p {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error {
+	if _, isAssumeRole := d.GetOk(grantAssumeRoleARNAttr); isAssumeRole {
+		return resourceRedshiftAssumeRoleGrantCreate(db, d)
+	}
+
 	objectType := d.Get(grantObjectTypeAttr).(string)
 	schemaName := d.Get(grantSchemaAttr).(string)
 	objects := d.Get(grantObjectsAttr).(*schema.Set).List()
@@ -147,7 +376,12 @@ func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error
 		return fmt.Errorf("Invalid privileges list %v for object of type %s", privileges, objectType)
 	}
 
-	tx, err := startTransaction(db.client, "")
+	db, err := connectToDatabase(db, d.Get(grantDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -157,6 +391,12 @@ func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error
 		return err
 	}
 
+	if d.IsNewResource() && d.Get(grantRevokeExistingAttr).(bool) {
+		if err := revokeExistingGrants(tx, db.client.databaseName, d); err != nil {
+			return err
+		}
+	}
+
 	if err := createGrants(tx, db.client.databaseName, d); err != nil {
 		return err
 	}
@@ -171,13 +411,31 @@ func resourceRedshiftGrantCreate(db *DBConnection, d *schema.ResourceData) error
 }
 
 func resourceRedshiftGrantDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	if _, isAssumeRole := d.GetOk(grantAssumeRoleARNAttr); isAssumeRole {
+		return resourceRedshiftAssumeRoleGrantDelete(db, d)
+	}
+
+	db, err := connectToDatabase(db, d.Get(grantDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
-	if err := revokeGrants(tx, db.client.databaseName, d); err != nil {
+	values, err := grantValuesOnExistingObjects(tx, d)
+	if err != nil {
+		return err
+	}
+	if values == nil {
+		log.Printf("[WARN] None of the objects of grant %s exists anymore, nothing to revoke", d.Id())
+		return nil
+	}
+
+	if err := revokeGrants(tx, db.client.databaseName, values); err != nil {
 		return err
 	}
 
@@ -188,32 +446,131 @@ func resourceRedshiftGrantDelete(db *DBConnection, d *schema.ResourceData) error
 	return nil
 }
 
+// grantObjectsValues overrides the objects of a grant.
+type grantObjectsValues struct {
+	resourceValues
+	objects *schema.Set
+}
+
+func (v grantObjectsValues) Get(key string) interface{} {
+	if key == grantObjectsAttr {
+		return v.objects
+	}
+	return v.resourceValues.Get(key)
+}
+
+func (v grantObjectsValues) GetOk(key string) (interface{}, bool) {
+	if key == grantObjectsAttr {
+		return v.objects, v.objects.Len() > 0
+	}
+	return v.resourceValues.GetOk(key)
+}
+
+// grantValuesOnExistingObjects leaves out of the objects of a grant on tables
+// the ones which don't exist anymore, e.g. a view dropped to be recreated, as
+// REVOKE fails on them. It returns nil when none of them exists.
+func grantValuesOnExistingObjects(tx *DBTransaction, d *schema.ResourceData) (resourceValues, error) {
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+	if d.Get(grantObjectTypeAttr).(string) != "table" || objects.Len() == 0 {
+		return d, nil
+	}
+
+	names := []string{}
+	for _, object := range objects.List() {
+		names = append(names, strings.ToLower(object.(string)))
+	}
+
+	rows, err := tx.Query(`
+  SELECT cl.relname
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE cl.relkind = ANY($1) AND nsp.nspname = $2 AND lower(cl.relname) = ANY($3)
+`, pq.Array(grantObjectTypesCodes["table"]), d.Get(grantSchemaAttr).(string), pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("could not check which objects of the grant exist: %w", err)
+	}
+	defer rows.Close()
+
+	existing := schema.NewSet(hashIdentifier, nil)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		existing.Add(name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if existing.Len() == 0 {
+		return nil, nil
+	}
+	return grantObjectsValues{d, existing}, nil
+}
+
 func resourceRedshiftGrantRead(db *DBConnection, d *schema.ResourceData) error {
 	return resourceRedshiftGrantReadImpl(db, d)
 }
 
 func resourceRedshiftGrantReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	if _, isAssumeRole := d.GetOk(grantAssumeRoleARNAttr); isAssumeRole {
+		return readAssumeRoleGrants(db, d)
+	}
+
 	objectType := d.Get(grantObjectTypeAttr).(string)
 
+	db, err := connectToDatabase(db, d.Get(grantDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	if columns := d.Get(grantColumnsAttr).(*schema.Set); columns.Len() > 0 {
+		return readColumnGrants(db, d)
+	}
+
+	if _, isRole := d.GetOk(grantRoleAttr); isRole {
+		return readRoleGrants(db, d)
+	}
+
 	switch objectType {
 	case "database":
-		return readDatabaseGrants(db, d)
+		err = readDatabaseGrants(db, d)
 	case "schema":
-		return readSchemaGrants(db, d)
+		err = readSchemaGrants(db, d)
 	case "table":
-		return readTableGrants(db, d)
+		err = readTableGrants(db, d)
 	case "function", "procedure":
-		return readCallableGrants(db, d)
+		err = readCallableGrants(db, d)
 	case "language":
-		return readLanguageGrants(db, d)
+		err = readLanguageGrants(db, d)
 	default:
 		return fmt.Errorf("Unsupported %s %s", grantObjectTypeAttr, objectType)
 	}
+	if err != nil {
+		return err
+	}
+
+	if _, isUser := d.GetOk(grantUserAttr); isUser {
+		return readUserGrantOption(db, d)
+	}
+
+	return nil
+}
+
+// normalizeGrantPrivilege lowercases the privilege, and replaces TEMP with
+// TEMPORARY as it's reported.
+func normalizeGrantPrivilege(privilege string) string {
+	privilege = strings.ToLower(privilege)
+	if privilege == "temp" {
+		return "temporary"
+	}
+	return privilege
 }
 
 func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
 	var entityName, query string
-	var databaseCreate, databaseTemp bool
+	var databaseCreate, databaseTemp, databaseUsage bool
 
 	_, isUser := d.GetOk(grantUserAttr)
 
@@ -222,7 +579,8 @@ func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
 		query = `
   SELECT
     decode(charindex('C',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'group '||u.usename,'__avoidGroupPrivs__'), u.usename||'=', 2) ,'/',1)), 0,0,1) AS CREATE,
-    decode(charindex('T',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'group '||u.usename,'__avoidGroupPrivs__'), u.usename||'=', 2) ,'/',1)), 0,0,1) AS TEMPORARY
+    decode(charindex('T',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'group '||u.usename,'__avoidGroupPrivs__'), u.usename||'=', 2) ,'/',1)), 0,0,1) AS TEMPORARY,
+    decode(charindex('U',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'group '||u.usename,'__avoidGroupPrivs__'), u.usename||'=', 2) ,'/',1)), 0,0,1) AS USAGE
   FROM pg_database db, pg_user u
   WHERE
     db.datname=$1 
@@ -233,7 +591,8 @@ func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
 		query = `
   SELECT
     decode(charindex('C',split_part(split_part(replace(array_to_string(db.datacl, '|'), '"', ''),'group ' || gr.groname,2 ) ,'/',1)), 0,0,1) AS CREATE,
-    decode(charindex('T',split_part(split_part(replace(array_to_string(db.datacl, '|'), '"', ''),'group ' || gr.groname,2 ) ,'/',1)), 0,0,1) AS TEMPORARY
+    decode(charindex('T',split_part(split_part(replace(array_to_string(db.datacl, '|'), '"', ''),'group ' || gr.groname,2 ) ,'/',1)), 0,0,1) AS TEMPORARY,
+    decode(charindex('U',split_part(split_part(replace(array_to_string(db.datacl, '|'), '"', ''),'group ' || gr.groname,2 ) ,'/',1)), 0,0,1) AS USAGE
   FROM pg_database db, pg_group gr
   WHERE
     db.datname=$1 
@@ -248,7 +607,8 @@ func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
 		query = `
   SELECT
     decode(charindex('C',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'[^|]+=','__avoidUserPrivs__'), '=', 2) ,'/',1)), 0,0,1) AS CREATE,
-    decode(charindex('T',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'[^|]+=','__avoidUserPrivs__'), '=', 2) ,'/',1)), 0,0,1) AS TEMPORARY
+    decode(charindex('T',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'[^|]+=','__avoidUserPrivs__'), '=', 2) ,'/',1)), 0,0,1) AS TEMPORARY,
+    decode(charindex('U',split_part(split_part(regexp_replace(replace(array_to_string(db.datacl, '|'), '"', ''),'[^|]+=','__avoidUserPrivs__'), '=', 2) ,'/',1)), 0,0,1) AS USAGE
   FROM pg_database db
   WHERE
     db.datname=$1 
@@ -256,13 +616,14 @@ func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
 		queryArgs = []interface{}{db.client.databaseName}
 	}
 
-	if err := db.QueryRow(query, queryArgs...).Scan(&databaseCreate, &databaseTemp); err != nil {
+	if err := db.QueryRow(query, queryArgs...).Scan(&databaseCreate, &databaseTemp, &databaseUsage); err != nil {
 		return err
 	}
 
 	privileges := []string{}
 	appendIfTrue(databaseCreate, "create", &privileges)
 	appendIfTrue(databaseTemp, "temporary", &privileges)
+	appendIfTrue(databaseUsage, "usage", &privileges)
 
 	log.Printf("[DEBUG] Collected database '%s' privileges for %s: %v", db.client.databaseName, entityName, privileges)
 
@@ -271,7 +632,83 @@ func readDatabaseGrants(db *DBConnection, d *schema.ResourceData) error {
 	return nil
 }
 
+// readSchemaGrants reads the privileges on the schema from
+// SVV_SCHEMA_PRIVILEGES, falling back to the ACL of the schema when the view
+// doesn't show the privileges of the grantee.
 func readSchemaGrants(db *DBConnection, d *schema.ResourceData) error {
+	if isSystemSchema(d.Get(grantSchemaAttr).(string)) {
+		return readSchemaACLGrants(db, d)
+	}
+
+	hidden, err := privilegeViewsHideGrants(db, d)
+	if err != nil {
+		return err
+	}
+	if hidden {
+		return readSchemaACLGrants(db, d)
+	}
+
+	identityType, identityName := grantIdentity(d)
+	return readPrivilegeViewGrants(db, d, identityType, identityName)
+}
+
+// privilegeViewsHideGrants reports whether the privileges of a grant on a
+// schema or on tables are read from the ACLs, as the SVV_*_PRIVILEGES views
+// only show the provider's user its own privileges.
+func privilegeViewsHideGrants(db *DBConnection, d resourceValues) (bool, error) {
+	if _, isRole := d.GetOk(grantRoleAttr); isRole {
+		return false, nil
+	}
+	if columns := d.Get(grantColumnsAttr).(*schema.Set); columns.Len() > 0 {
+		return false, nil
+	}
+	switch d.Get(grantObjectTypeAttr).(string) {
+	case "schema", "table":
+	default:
+		return false, nil
+	}
+	if isSystemSchema(d.Get(grantSchemaAttr).(string)) {
+		return false, nil
+	}
+
+	visible, err := db.seesAllPrivileges()
+	return !visible, err
+}
+
+// warnOnHiddenPrivileges warns when the privileges were read from the ACLs,
+// which don't include the privileges inherited from roles.
+func warnOnHiddenPrivileges(fn func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := fn(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		hidden := false
+		diags = append(diags, RedshiftResourceFunc(func(db *DBConnection, d *schema.ResourceData) error {
+			var err error
+			hidden, err = privilegeViewsHideGrants(db, d)
+			return err
+		})(ctx, d, meta)...)
+		if !hidden {
+			return diags
+		}
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Privileges on %s read from the ACLs", d.Get(grantSchemaAttr)),
+			Detail:   "The provider's user is neither a superuser nor granted ACCESS SYSTEM TABLE, so svv_schema_privileges and svv_relation_privileges only show its own privileges. They were read from the ACLs instead, which don't show the privileges granted through roles.",
+		})
+	}
+}
+
+// isSystemSchema reports whether the schema holds the system catalogs, whose
+// privileges the SVV_*_PRIVILEGES views don't cover.
+func isSystemSchema(schemaName string) bool {
+	return schemaName == "pg_catalog" || schemaName == "information_schema"
+}
+
+func readSchemaACLGrants(db *DBConnection, d *schema.ResourceData) error {
 	var entityName, query string
 	var schemaCreate, schemaUsage bool
 
@@ -332,8 +769,28 @@ func readSchemaGrants(db *DBConnection, d *schema.ResourceData) error {
 	return nil
 }
 
+// readTableGrants reads the privileges on the tables from
+// SVV_RELATION_PRIVILEGES, falling back to the ACLs of the tables when the
+// view doesn't show the privileges of the grantee.
 func readTableGrants(db *DBConnection, d *schema.ResourceData) error {
 	log.Printf("[DEBUG] Reading table grants")
+	if isSystemSchema(d.Get(grantSchemaAttr).(string)) {
+		return readTableACLGrants(db, d)
+	}
+
+	hidden, err := privilegeViewsHideGrants(db, d)
+	if err != nil {
+		return err
+	}
+	if hidden {
+		return readTableACLGrants(db, d)
+	}
+
+	identityType, identityName := grantIdentity(d)
+	return readPrivilegeViewGrants(db, d, identityType, identityName)
+}
+
+func readTableACLGrants(db *DBConnection, d *schema.ResourceData) error {
 	var entityName, query string
 	_, isUser := d.GetOk(grantUserAttr)
 
@@ -538,7 +995,8 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 	}
 	defer rows.Close()
 
-	privilegesSet := schema.NewSet(schema.HashString, nil)
+	// Every callable must hold the privileges, so that the callables created
+	// after granting on all of them in the schema are reported as a difference.
 	for rows.Next() {
 		var objName string
 		var callableExecute bool
@@ -550,14 +1008,22 @@ func readCallableGrants(db *DBConnection, d *schema.ResourceData) error {
 			continue
 		}
 
+		privilegesSet := schema.NewSet(schema.HashString, nil)
 		if callableExecute {
 			privilegesSet.Add("execute")
 		}
-	}
 
-	if !privilegesSet.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
-		d.Set(grantPrivilegesAttr, privilegesSet)
+		if !privilegesSet.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
+			d.Set(grantPrivilegesAttr, privilegesSet)
+			break
+		}
+
+		log.Printf("[DEBUG] Collected callable grants; callable: '%v'; privileges: %v; for: %s", objName, privilegesSet.List(), entityName)
+	}
+	if err := rows.Err(); err != nil {
+		return err
 	}
+
 	log.Printf("[DEBUG] Reading callable grants - Done")
 
 	return nil
@@ -640,44 +1106,675 @@ func readLanguageGrants(db *DBConnection, d *schema.ResourceData) error {
 	return nil
 }
 
-func revokeGrants(tx *sql.Tx, databaseName string, d *schema.ResourceData) error {
-	query := createGrantsRevokeQuery(d, databaseName)
-	_, err := tx.Exec(query)
-	return err
+// readRoleGrants reads privileges granted to a role. Role privileges are not
+// visible in the ACL columns used for users and groups, so they are read
+// from the SVV_*_PRIVILEGES system views instead.
+func readRoleGrants(db *DBConnection, d *schema.ResourceData) error {
+	log.Printf("[DEBUG] Reading role grants")
+	return readPrivilegeViewGrants(db, d, "role", d.Get(grantRoleAttr).(string))
 }
 
-func createGrants(tx *sql.Tx, databaseName string, d *schema.ResourceData) error {
-	if d.Get(grantPrivilegesAttr).(*schema.Set).Len() == 0 {
-		log.Printf("[DEBUG] no privileges to grant for %s", d.Get(grantGroupAttr).(string))
-		return nil
-	}
+// readPrivilegeViewGrants reads the privileges granted to an identity from the
+// SVV_*_PRIVILEGES system views. Unlike the ACL columns, they report the
+// privileges of users, groups, roles and PUBLIC consistently.
+func readPrivilegeViewGrants(db *DBConnection, d *schema.ResourceData, identityType, identityName string) error {
+	var query string
+	var queryArgs []interface{}
 
-	query := createGrantsQuery(d, databaseName)
-	_, err := tx.Exec(query)
-	return err
-}
+	schemaName := d.Get(grantSchemaAttr).(string)
+	objectType := d.Get(grantObjectTypeAttr).(string)
 
-func createGrantsRevokeQuery(d *schema.ResourceData, databaseName string) string {
-	var query, toWhomIndicator, entityName string
+	switch objectType {
+	case "database":
+		query = `
+  SELECT database_name, privilege_type
+  FROM svv_database_privileges
+  WHERE identity_type = $1 AND (identity_type = 'public' OR identity_name = $2) AND database_name = $3
+`
+		queryArgs = []interface{}{identityType, identityName, db.client.databaseName}
+	case "schema":
+		query = `
+  SELECT namespace_name, privilege_type
+  FROM svv_schema_privileges
+  WHERE identity_type = $1 AND (identity_type = 'public' OR identity_name = $2) AND namespace_name = $3
+`
+		queryArgs = []interface{}{identityType, identityName, schemaName}
+	case "table":
+		return readTablePrivilegeViewGrants(db, d, identityType, identityName)
+	case "function", "procedure":
+		query = `
+  SELECT function_name, privilege_type
+  FROM svv_function_privileges
+  WHERE identity_type = $1 AND (identity_type = 'public' OR identity_name = $2) AND namespace_name = $3
+`
+		queryArgs = []interface{}{identityType, identityName, schemaName}
+	case "language":
+		query = `
+  SELECT language_name, privilege_type
+  FROM svv_language_privileges
+  WHERE identity_type = $1 AND (identity_type = 'public' OR identity_name = $2)
+`
+		queryArgs = []interface{}{identityType, identityName}
+	default:
+		return fmt.Errorf("Unsupported %s %s", grantObjectTypeAttr, objectType)
+	}
 
-	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
-		toWhomIndicator = "GROUP"
-		entityName = groupName.(string)
-	} else if userName, isUser := d.GetOk(grantUserAttr); isUser {
-		entityName = userName.(string)
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
 
-	fromEntityName := pq.QuoteIdentifier(entityName)
-	if isGrantToPublic(d) {
-		toWhomIndicator = ""
-		fromEntityName = "PUBLIC"
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+	if objectType == "function" || objectType == "procedure" {
+		objects = schema.NewSet(hashIdentifier, nil)
+		for _, callable := range stripArgumentsFromCallablesDefinitions(d.Get(grantObjectsAttr).(*schema.Set)) {
+			objects.Add(callable)
+		}
 	}
 
-	switch strings.ToUpper(d.Get(grantObjectTypeAttr).(string)) {
-	case "DATABASE":
-		query = fmt.Sprintf(
-			"REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s %s",
-			pq.QuoteIdentifier(databaseName),
+	privilegesByObject := map[string]*schema.Set{}
+	for rows.Next() {
+		var objName, privilege string
+		if err := rows.Scan(&objName, &privilege); err != nil {
+			return err
+		}
+
+		if objects.Len() > 0 && !objects.Contains(objName) {
+			continue
+		}
+
+		// The objects are keyed regardless of case, like in the objects set.
+		objName = strings.ToLower(objName)
+		if _, ok := privilegesByObject[objName]; !ok {
+			privilegesByObject[objName] = schema.NewSet(schema.HashString, nil)
+		}
+		// Privileges the resource can't grant, e.g. ALTER on tables, are ignored.
+		privilege = normalizeRolePrivilege(privilege)
+		if _, ok := grantPrivilegesACLCodes[objectType][privilege]; ok {
+			privilegesByObject[objName].Add(privilege)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Objects without any privileges granted don't show up in the views at all.
+	for _, object := range objects.List() {
+		if _, ok := privilegesByObject[strings.ToLower(object.(string))]; !ok {
+			privilegesByObject[strings.ToLower(object.(string))] = schema.NewSet(schema.HashString, nil)
+		}
+	}
+
+	privilegesSet := schema.NewSet(schema.HashString, nil)
+	for objName, objPrivileges := range privilegesByObject {
+		privilegesSet = objPrivileges
+		if !objPrivileges.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
+			break
+		}
+		log.Printf("[DEBUG] Collected grants; object: '%v'; privileges: %v; for: %s %s", objName, objPrivileges.List(), identityType, identityName)
+	}
+
+	if !privilegesSet.Equal(d.Get(grantPrivilegesAttr).(*schema.Set)) {
+		d.Set(grantPrivilegesAttr, privilegesSet)
+	}
+
+	return nil
+}
+
+// readTablePrivilegeViewGrants reads the privileges on the tables from
+// SVV_RELATION_PRIVILEGES with a single query listing every table of the
+// schema, ordered by name. Schemas may hold thousands of tables, so the rows are
+// compared with the managed privileges as they are streamed rather than
+// collected first.
+func readTablePrivilegeViewGrants(db *DBConnection, d *schema.ResourceData, identityType, identityName string) error {
+	// Tables without any privileges granted don't show up in the view, so
+	// they're listed from the catalog.
+	rows, err := db.Query(`
+  SELECT cl.relname, priv.privilege_type
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+    LEFT JOIN svv_relation_privileges priv
+      ON priv.namespace_name = nsp.nspname AND priv.relation_name = cl.relname
+      AND priv.identity_type = $1 AND (priv.identity_type = 'public' OR priv.identity_name = $2)
+  WHERE cl.relkind = ANY($3) AND nsp.nspname = $4
+  ORDER BY cl.relname
+`, identityType, identityName, pq.Array(grantObjectTypesCodes["table"]), d.Get(grantSchemaAttr).(string))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	privileges, differs, err := diffTablePrivileges(rows, d.Get(grantObjectsAttr).(*schema.Set), d.Get(grantPrivilegesAttr).(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	if differs {
+		log.Printf("[DEBUG] Collected table grants; privileges: %v; for: %s %s", privileges.List(), identityType, identityName)
+		d.Set(grantPrivilegesAttr, privileges)
+	}
+
+	return nil
+}
+
+// rowScanner is the part of *sql.Rows diffTablePrivileges reads.
+type rowScanner interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// diffTablePrivileges reads rows of table names and privileges ordered by table
+// name, a NULL privilege standing for a table without any, and returns the
+// privileges of the first table of objects, or of any table when objects is
+// empty, that don't match expected. Only the privileges of the current table
+// are held in memory. A table of objects which doesn't exist has no privileges.
+func diffTablePrivileges(rows rowScanner, objects, expected *schema.Set) (*schema.Set, bool, error) {
+	expectedNames := map[string]bool{}
+	for _, privilege := range expected.List() {
+		expectedNames[privilege.(string)] = true
+	}
+
+	// The privileges of the current table are kept in a map reused from one
+	// table to the next, as schema.Set hashes every element.
+	var current string
+	privileges := map[string]bool{}
+	matches := func() bool {
+		if len(privileges) != len(expectedNames) {
+			return false
+		}
+		for privilege := range privileges {
+			if !expectedNames[privilege] {
+				return false
+			}
+		}
+		return true
+	}
+	toSet := func() *schema.Set {
+		set := schema.NewSet(schema.HashString, nil)
+		for privilege := range privileges {
+			set.Add(privilege)
+		}
+		return set
+	}
+
+	seen := 0
+	for rows.Next() {
+		var tableName string
+		var privilege sql.NullString
+		if err := rows.Scan(&tableName, &privilege); err != nil {
+			return nil, false, err
+		}
+
+		if objects.Len() > 0 && !objects.Contains(tableName) {
+			continue
+		}
+
+		if seen == 0 || tableName != current {
+			if seen > 0 && !matches() {
+				return toSet(), true, nil
+			}
+			current = tableName
+			clear(privileges)
+			seen++
+		}
+
+		// Privileges the resource can't grant, e.g. ALTER on tables, are ignored.
+		if !privilege.Valid {
+			continue
+		}
+		if name := normalizeRolePrivilege(privilege.String); grantPrivilegesACLCodes["table"][name] != 0 {
+			privileges[name] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if seen > 0 && !matches() {
+		return toSet(), true, nil
+	}
+	if seen < objects.Len() {
+		return schema.NewSet(schema.HashString, nil), expected.Len() > 0, nil
+	}
+
+	// A schema without tables has nothing to reconcile.
+	return expected, false, nil
+}
+
+// normalizeRolePrivilege maps the privilege names reported by the
+// SVV_*_PRIVILEGES views to the names accepted by the privileges attribute.
+func normalizeRolePrivilege(privilege string) string {
+	privilege = strings.ToLower(privilege)
+	if privilege == "temp" {
+		return "temporary"
+	}
+	return privilege
+}
+
+// readColumnGrants reads column-level privileges, which are not part of the
+// table ACL, from SVV_COLUMN_PRIVILEGES.
+func readColumnGrants(db *DBConnection, d *schema.ResourceData) error {
+	log.Printf("[DEBUG] Reading column grants")
+
+	identityType, identityName := privilegeIdentity(d)
+	if isGrantToPublic(d) {
+		identityType = "public"
+	}
+	schemaName := d.Get(grantSchemaAttr).(string)
+	tableName := d.Get(grantObjectsAttr).(*schema.Set).List()[0].(string)
+
+	query := `
+  SELECT column_name, privilege_type, admin_option
+  FROM svv_column_privileges
+  WHERE identity_type = $1 AND (identity_type = 'public' OR identity_name = $2) AND namespace_name = $3 AND lower(relation_name) = lower($4)
+`
+	rows, err := db.Query(query, identityType, identityName, schemaName, tableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnsSet := schema.NewSet(hashIdentifier, nil)
+	privilegesSet := schema.NewSet(schema.HashString, nil)
+	withGrantOption := true
+	for rows.Next() {
+		var columnName, privilege string
+		var adminOption bool
+		if err := rows.Scan(&columnName, &privilege, &adminOption); err != nil {
+			return err
+		}
+
+		privilege = strings.ToLower(privilege)
+		if !isColumnPrivilege(privilege) {
+			continue
+		}
+
+		columnsSet.Add(columnName)
+		privilegesSet.Add(privilege)
+		withGrantOption = withGrantOption && adminOption
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Collected column grants; table: '%s'; columns: %v; privileges: %v; for: %s", tableName, columnsSet.List(), privilegesSet.List(), identityName)
+
+	d.Set(grantColumnsAttr, columnsSet)
+	d.Set(grantPrivilegesAttr, privilegesSet)
+	if _, isUser := d.GetOk(grantUserAttr); isUser {
+		d.Set(grantWithGrantOptionAttr, privilegesSet.Len() > 0 && withGrantOption)
+	}
+
+	return nil
+}
+
+// readUserGrantOption sets with_grant_option only when every privilege held by
+// the user on the managed objects is grantable.
+func readUserGrantOption(db *DBConnection, d *schema.ResourceData) error {
+	var query string
+	var queryArgs []interface{}
+
+	userName := d.Get(grantUserAttr).(string)
+	schemaName := d.Get(grantSchemaAttr).(string)
+	objectType := d.Get(grantObjectTypeAttr).(string)
+
+	switch objectType {
+	case "database":
+		query = "SELECT datname, array_to_string(datacl, '|') FROM pg_database WHERE datname=$1"
+		queryArgs = []interface{}{db.client.databaseName}
+	case "schema":
+		query = "SELECT nspname, array_to_string(nspacl, '|') FROM pg_namespace WHERE nspname=$1"
+		queryArgs = []interface{}{schemaName}
+	case "table":
+		query = `
+  SELECT relname, array_to_string(relacl, '|')
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE
+    cl.relkind = ANY($1)
+    AND nsp.nspname=$2
+`
+		queryArgs = []interface{}{pq.Array(grantObjectTypesCodes[objectType]), schemaName}
+	case "function", "procedure":
+		query = `
+  SELECT proname, array_to_string(proacl, '|')
+  FROM pg_proc_info pr
+    JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+  WHERE
+    pr.prokind = ANY($1)
+    AND nsp.nspname=$2
+`
+		queryArgs = []interface{}{pq.Array(grantObjectTypesCodes[objectType]), schemaName}
+	case "language":
+		query = "SELECT lanname, array_to_string(lanacl, '|') FROM pg_language"
+	}
+
+	objects := schema.NewSet(hashIdentifier, nil)
+	for _, object := range d.Get(grantObjectsAttr).(*schema.Set).List() {
+		objects.Add(strings.Split(object.(string), "(")[0])
+	}
+
+	privileges := d.Get(grantPrivilegesAttr).(*schema.Set)
+
+	rows, err := db.Query(query, queryArgs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	matched := false
+	withGrantOption := privileges.Len() > 0
+	for rows.Next() {
+		var objName string
+		var acl sql.NullString
+
+		if err := rows.Scan(&objName, &acl); err != nil {
+			return err
+		}
+
+		if objects.Len() > 0 && !objects.Contains(objName) {
+			continue
+		}
+		matched = true
+
+		grantable := aclGrantablePrivileges(acl.String, userName)
+		for _, privilege := range privileges.List() {
+			if !grantable[grantPrivilegesACLCodes[objectType][privilege.(string)]] {
+				withGrantOption = false
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set(grantWithGrantOptionAttr, matched && withGrantOption)
+
+	return nil
+}
+
+// aclGrantablePrivileges parses the aclitems of an ACL joined with '|' and
+// returns the privilege codes held by the grantee, mapped to whether they are
+// grantable (followed by the '*' marker). When the same privilege is held by
+// several aclitems, e.g. granted by different grantors, it's grantable if any
+// of them holds it grantable.
+func aclGrantablePrivileges(acl string, grantee string) map[rune]bool {
+	grantable := map[rune]bool{}
+
+	for _, item := range strings.Split(strings.ReplaceAll(acl, `"`, ""), "|") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] != grantee {
+			continue
+		}
+
+		codes := []rune(strings.SplitN(parts[1], "/", 2)[0])
+		for i, code := range codes {
+			if code == '*' {
+				continue
+			}
+			isGrantable := i+1 < len(codes) && codes[i+1] == '*'
+			grantable[code] = grantable[code] || isGrantable
+		}
+	}
+
+	return grantable
+}
+
+func revokeGrants(tx sqlExecutor, databaseName string, d resourceValues) error {
+	for _, query := range createGrantsRevokeQueries(d, databaseName) {
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not revoke privileges with %q: %w", query, err)
+		}
+	}
+
+	return nil
+}
+
+// revokeExistingGrants revokes the privileges held by the grantee on the
+// objects which revokeGrants leaves untouched, as they aren't in the state: the
+// grant option of users and the column-level privileges on tables.
+func revokeExistingGrants(tx *DBTransaction, databaseName string, d *schema.ResourceData) error {
+	queries := []string{}
+
+	identityType, _ := grantIdentity(d)
+	if identityType == "user" && !d.Get(grantWithGrantOptionAttr).(bool) {
+		queries = append(queries, createGrantOptionRevokeQuery(d, databaseName))
+	}
+
+	if d.Get(grantObjectTypeAttr).(string) == "table" {
+		columns, err := readExistingGrantColumns(tx, d)
+		if err != nil {
+			return err
+		}
+		queries = append(queries, createExistingColumnGrantsRevokeQueries(d, columns)...)
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not revoke existing privileges with %q: %w", query, err)
+		}
+	}
+
+	return nil
+}
+
+// readExistingGrantColumns returns the columns the grantee holds column-level
+// privileges on, by table.
+func readExistingGrantColumns(tx *DBTransaction, d *schema.ResourceData) (map[string]*schema.Set, error) {
+	identityType, identityName := grantIdentity(d)
+	schemaName := d.Get(grantSchemaAttr).(string)
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+
+	rows, err := tx.Query(`
+  SELECT DISTINCT relation_name, column_name
+  FROM svv_column_privileges
+  WHERE identity_type = $1 AND (identity_type = 'public' OR identity_name = $2) AND namespace_name = $3
+`, identityType, identityName, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read existing column privileges: %w", err)
+	}
+	defer rows.Close()
+
+	columns := map[string]*schema.Set{}
+	for rows.Next() {
+		var tableName, columnName string
+		if err := rows.Scan(&tableName, &columnName); err != nil {
+			return nil, err
+		}
+		if objects.Len() > 0 && !objects.Contains(tableName) {
+			continue
+		}
+		if _, ok := columns[tableName]; !ok {
+			columns[tableName] = schema.NewSet(schema.HashString, nil)
+		}
+		columns[tableName].Add(columnName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// createExistingColumnGrantsRevokeQueries returns a statement per table
+// revoking the column-level privileges on the given columns. The columns
+// managed by the grant are granted back afterwards.
+func createExistingColumnGrantsRevokeQueries(d resourceValues, columns map[string]*schema.Set) []string {
+	tableNames := []string{}
+	for tableName := range columns {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+
+	queries := []string{}
+	for _, tableName := range tableNames {
+		queries = append(queries, fmt.Sprintf(
+			"REVOKE %s ON TABLE %s.%s FROM %s",
+			columnPrivilegesList(grantColumnPrivileges, columns[tableName]),
+			pq.QuoteIdentifier(d.Get(grantSchemaAttr).(string)),
+			pq.QuoteIdentifier(tableName),
+			grantGrantee(d),
+		))
+	}
+
+	return queries
+}
+
+// createGrantsRevokeQueries returns the statements revoking all the privileges
+// managed by the grant. All the privileges are revoked at once, only the grant
+// option and the column-level privileges need their own statements.
+func createGrantsRevokeQueries(d resourceValues, databaseName string) []string {
+	if isSchemaPrivilegesUpdate(d) {
+		revokeQuery, _ := createSchemaPrivilegesUpdateQueries(d)
+		if revokeQuery == "" {
+			return []string{}
+		}
+		return []string{revokeQuery}
+	}
+
+	queries := []string{}
+
+	if hadGrantOption, _ := d.GetChange(grantWithGrantOptionAttr); hadGrantOption.(bool) {
+		queries = append(queries, createGrantOptionRevokeQuery(d, databaseName))
+	}
+
+	queries = append(queries, createGrantsRevokeQuery(d, databaseName))
+
+	// Revoke column-level privileges from both the previous and the current columns.
+	oldColumns, newColumns := d.GetChange(grantColumnsAttr)
+	if columns := oldColumns.(*schema.Set).Union(newColumns.(*schema.Set)); columns.Len() > 0 {
+		queries = append(queries, createColumnGrantsRevokeQuery(d, databaseName, columns))
+	}
+
+	return queries
+}
+
+// isSchemaPrivilegesUpdate reports whether only the privileges of an existing
+// grant on a schema change. USAGE and CREATE are often toggled independently,
+// so only the removed privileges are revoked and the added ones granted, e.g.
+// revoking CREATE leaves USAGE untouched. Deleting the grant changes nothing,
+// so it still revokes all the privileges.
+func isSchemaPrivilegesUpdate(d resourceValues) bool {
+	return d.Id() != "" &&
+		d.Get(grantObjectTypeAttr).(string) == "schema" &&
+		d.HasChange(grantPrivilegesAttr) &&
+		!d.HasChange(grantWithGrantOptionAttr)
+}
+
+// createSchemaPrivilegesUpdateQueries returns the statements revoking the
+// privileges removed from the grant and granting the added ones, which are
+// empty when there are none.
+func createSchemaPrivilegesUpdateQueries(d resourceValues) (string, string) {
+	oldPrivileges, newPrivileges := d.GetChange(grantPrivilegesAttr)
+	removed := oldPrivileges.(*schema.Set).Difference(newPrivileges.(*schema.Set))
+	added := newPrivileges.(*schema.Set).Difference(oldPrivileges.(*schema.Set))
+
+	schemaName := pq.QuoteIdentifier(d.Get(grantSchemaAttr).(string))
+	grantee := grantGrantee(d)
+	privilegesList := func(privileges *schema.Set) string {
+		list := []string{}
+		for _, p := range privileges.List() {
+			list = append(list, p.(string))
+		}
+		sort.Strings(list)
+		return strings.Join(list, ",")
+	}
+
+	var revokeQuery, grantQuery string
+	if removed.Len() > 0 {
+		revokeQuery = fmt.Sprintf("REVOKE %s ON SCHEMA %s FROM %s", privilegesList(removed), schemaName, grantee)
+	}
+	if added.Len() > 0 {
+		grantQuery = fmt.Sprintf("GRANT %s ON SCHEMA %s TO %s", privilegesList(added), schemaName, grantee)
+		if d.Get(grantWithGrantOptionAttr).(bool) {
+			grantQuery += " WITH GRANT OPTION"
+		}
+	}
+
+	return revokeQuery, grantQuery
+}
+
+// generateGrantSQL records the statements revoking and granting the privileges,
+// which are the same whether the grant is created or updated.
+func generateGrantSQL(tx sqlExecutor, d *schema.ResourceDiff, meta interface{}) error {
+	if _, isAssumeRole := d.GetOk(grantAssumeRoleARNAttr); isAssumeRole {
+		for _, query := range []string{createAssumeRoleRevokeQuery(d), createAssumeRoleGrantQuery(d)} {
+			if _, err := tx.Exec(query); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	databaseName := d.Get(grantDatabaseAttr).(string)
+	if client, ok := meta.(*Client); ok && databaseName == "" {
+		databaseName = client.databaseName
+	}
+
+	if err := revokeGrants(tx, databaseName, d); err != nil {
+		return err
+	}
+
+	return createGrants(tx, databaseName, d)
+}
+
+func createGrants(tx sqlExecutor, databaseName string, d resourceValues) error {
+	if d.Get(grantPrivilegesAttr).(*schema.Set).Len() == 0 {
+		log.Printf("[DEBUG] no privileges to grant for %s", d.Get(grantGroupAttr).(string))
+		return nil
+	}
+
+	// All the privileges are granted with a single statement, so Redshift
+	// either grants all of them or none.
+	query := createGrantsQuery(d, databaseName)
+	if isSchemaPrivilegesUpdate(d) {
+		if _, query = createSchemaPrivilegesUpdateQueries(d); query == "" {
+			return nil
+		}
+	}
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not grant privileges %v with %q: %w", grantPrivilegesList(d), query, err)
+	}
+
+	return nil
+}
+
+func grantPrivilegesList(d resourceValues) []string {
+	privileges := []string{}
+	for _, p := range d.Get(grantPrivilegesAttr).(*schema.Set).List() {
+		privileges = append(privileges, p.(string))
+	}
+	sort.Strings(privileges)
+
+	return privileges
+}
+
+func createGrantsRevokeQuery(d resourceValues, databaseName string) string {
+	var query, toWhomIndicator, entityName string
+
+	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
+		toWhomIndicator = "GROUP"
+		entityName = groupName.(string)
+	} else if roleName, isRole := d.GetOk(grantRoleAttr); isRole {
+		toWhomIndicator = "ROLE"
+		entityName = roleName.(string)
+	} else if userName, isUser := d.GetOk(grantUserAttr); isUser {
+		entityName = userName.(string)
+	}
+
+	fromEntityName := pq.QuoteIdentifier(entityName)
+	if isGrantToPublic(d) {
+		toWhomIndicator = ""
+		fromEntityName = "PUBLIC"
+	}
+
+	switch strings.ToUpper(d.Get(grantObjectTypeAttr).(string)) {
+	case "DATABASE":
+		query = fmt.Sprintf(
+			"REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s %s",
+			pq.QuoteIdentifier(databaseName),
 			toWhomIndicator,
 			fromEntityName,
 		)
@@ -708,7 +1805,7 @@ func createGrantsRevokeQuery(d *schema.ResourceData, databaseName string) string
 			)
 		}
 	case "FUNCTION", "PROCEDURE":
-		objects := d.Get(grantObjectsAttr).(*schema.Set)
+		objects := grantCallableObjects(d)
 		if objects.Len() > 0 {
 			query = fmt.Sprintf(
 				"REVOKE ALL PRIVILEGES ON %s %s FROM %s %s",
@@ -739,16 +1836,41 @@ func createGrantsRevokeQuery(d *schema.ResourceData, databaseName string) string
 	return query
 }
 
-func createGrantsQuery(d *schema.ResourceData, databaseName string) string {
+func createColumnGrantsRevokeQuery(d resourceValues, databaseName string, columns *schema.Set) string {
+	return strings.Replace(createGrantsRevokeQuery(d, databaseName), "ALL PRIVILEGES", columnPrivilegesList(grantColumnPrivileges, columns), 1)
+}
+
+// columnPrivilegesList scopes each privilege to the given columns, e.g. `select ("a","b"),update ("a","b")`.
+func columnPrivilegesList(privileges []string, columns *schema.Set) string {
+	scoped := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		scoped[i] = fmt.Sprintf("%s (%s)", privilege, setToPgIdentList(columns, ""))
+	}
+
+	return strings.Join(scoped, ",")
+}
+
+func createGrantOptionRevokeQuery(d resourceValues, databaseName string) string {
+	return strings.Replace(createGrantsRevokeQuery(d, databaseName), "REVOKE ", "REVOKE GRANT OPTION FOR ", 1)
+}
+
+func createGrantsQuery(d resourceValues, databaseName string) string {
 	var query, toWhomIndicator, entityName string
 	privileges := []string{}
 	for _, p := range d.Get(grantPrivilegesAttr).(*schema.Set).List() {
 		privileges = append(privileges, p.(string))
 	}
 
+	if columns := d.Get(grantColumnsAttr).(*schema.Set); columns.Len() > 0 {
+		privileges = []string{columnPrivilegesList(privileges, columns)}
+	}
+
 	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
 		toWhomIndicator = "GROUP"
 		entityName = groupName.(string)
+	} else if roleName, isRole := d.GetOk(grantRoleAttr); isRole {
+		toWhomIndicator = "ROLE"
+		entityName = roleName.(string)
 	} else if userName, isUser := d.GetOk(grantUserAttr); isUser {
 		entityName = userName.(string)
 	}
@@ -798,7 +1920,7 @@ func createGrantsQuery(d *schema.ResourceData, databaseName string) string {
 			)
 		}
 	case "FUNCTION", "PROCEDURE":
-		objects := d.Get(grantObjectsAttr).(*schema.Set)
+		objects := grantCallableObjects(d)
 		if objects.Len() > 0 {
 			query = fmt.Sprintf(
 				"GRANT %s ON %s %s TO %s %s",
@@ -820,11 +1942,15 @@ func createGrantsQuery(d *schema.ResourceData, databaseName string) string {
 		}
 	}
 
+	if d.Get(grantWithGrantOptionAttr).(bool) {
+		query = query + " WITH GRANT OPTION"
+	}
+
 	log.Printf("[DEBUG] Created GRANT query: %s", query)
 	return query
 }
 
-func isGrantToPublic(d *schema.ResourceData) bool {
+func isGrantToPublic(d resourceValues) bool {
 	if _, isGroup := d.GetOk(grantGroupAttr); isGroup {
 		entityName := d.Get(grantGroupAttr).(string)
 
@@ -837,6 +1963,10 @@ func isGrantToPublic(d *schema.ResourceData) bool {
 func generateGrantID(d *schema.ResourceData) string {
 	parts := []string{}
 
+	if database, ok := d.GetOk(grantDatabaseAttr); ok {
+		parts = append(parts, fmt.Sprintf("dn:%s", strings.ToLower(database.(string))))
+	}
+
 	if _, isGroup := d.GetOk(grantGroupAttr); isGroup {
 		name := d.Get(grantGroupAttr).(string)
 		if isGrantToPublic(d) {
@@ -850,6 +1980,15 @@ func generateGrantID(d *schema.ResourceData) string {
 		parts = append(parts, fmt.Sprintf("un:%s", d.Get(grantUserAttr).(string)))
 	}
 
+	if _, isRole := d.GetOk(grantRoleAttr); isRole {
+		parts = append(parts, fmt.Sprintf("rn:%s", d.Get(grantRoleAttr).(string)))
+	}
+
+	if arn, isAssumeRole := d.GetOk(grantAssumeRoleARNAttr); isAssumeRole {
+		parts = append(parts, fmt.Sprintf("ar:%s", arn.(string)))
+		return strings.Join(parts, "_")
+	}
+
 	objectType := fmt.Sprintf("ot:%s", d.Get(grantObjectTypeAttr).(string))
 	parts = append(parts, objectType)
 
@@ -857,9 +1996,280 @@ func generateGrantID(d *schema.ResourceData) string {
 		parts = append(parts, d.Get(grantSchemaAttr).(string))
 	}
 
-	for _, object := range d.Get(grantObjectsAttr).(*schema.Set).List() {
+	objects := d.Get(grantObjectsAttr).(*schema.Set)
+	if objectType == "ot:function" || objectType == "ot:procedure" {
+		objects = grantCallableObjects(d)
+	}
+	for _, object := range objects.List() {
 		parts = append(parts, object.(string))
 	}
 
 	return strings.Join(parts, "_")
 }
+
+// resourceRedshiftGrantImport sets the grantee and the objects from an ID like
+// <grantee_type>:<grantee>:<object_type>:<schema>:<object>[:<database>], the
+// privileges are then populated by the read that follows the import. Functions
+// and procedures are imported with their signature, like f(integer,varchar),
+// and the columns of a table as mytable(id,name).
+func resourceRedshiftGrantImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) == 6 {
+		if parts[5] != "" {
+			d.Set(grantDatabaseAttr, strings.ToLower(parts[5]))
+		}
+		parts = parts[:5]
+	}
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid grant import ID %q, expected %s, e.g. user:alice:table:myschema:mytable, group:public:schema:myschema: or role:analyst:database::", d.Id(), grantImportIDFormat)
+	}
+	granteeType, grantee, objectType, schemaName, object := parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	if grantee == "" {
+		return nil, fmt.Errorf("invalid grant import ID %q, the grantee can't be empty, expected %s", d.Id(), grantImportIDFormat)
+	}
+	// Apply the normalization of the StateFuncs, so that the configuration
+	// of the imported grant doesn't differ in letter case only.
+	if granteeType == grantRoleAttr || strings.ToLower(grantee) == grantToPublicName {
+		grantee = strings.ToLower(grantee)
+	}
+	switch granteeType {
+	case grantUserAttr, grantGroupAttr, grantRoleAttr:
+		d.Set(granteeType, grantee)
+	default:
+		return nil, fmt.Errorf("invalid grant import ID %q, the grantee type must be one of %s, %s or %s, expected %s", d.Id(), grantUserAttr, grantGroupAttr, grantRoleAttr, grantImportIDFormat)
+	}
+
+	validObjectType := false
+	for _, allowed := range grantAllowedObjectTypes {
+		validObjectType = validObjectType || objectType == allowed
+	}
+	if !validObjectType {
+		return nil, fmt.Errorf("invalid grant import ID %q, the object type must be one of %s, expected %s", d.Id(), strings.Join(grantAllowedObjectTypes, ", "), grantImportIDFormat)
+	}
+
+	if objectType != "database" && objectType != "language" {
+		if schemaName == "" {
+			return nil, fmt.Errorf("invalid grant import ID %q, the schema is required for objects of type %s, expected %s", d.Id(), objectType, grantImportIDFormat)
+		}
+		d.Set(grantSchemaAttr, schemaName)
+	}
+
+	objects := []string{}
+	if object != "" {
+		objects = splitGrantImportObjects(object)
+	}
+	if strings.Contains(object, "(") {
+		switch objectType {
+		case "function", "procedure":
+		case "table":
+			if len(objects) != 1 {
+				return nil, fmt.Errorf("invalid grant import ID %q, columns can only be imported for a single table, e.g. user:alice:table:myschema:mytable(id,name)", d.Id())
+			}
+			table, columns, ok := parseGrantImportColumns(objects[0])
+			if !ok {
+				return nil, fmt.Errorf("invalid grant import ID %q, the columns must be set as mytable(id,name)", d.Id())
+			}
+			objects = []string{table}
+			d.Set(grantColumnsAttr, columns)
+		default:
+			return nil, fmt.Errorf("invalid grant import ID %q, only functions and procedures can be imported with a signature and tables with columns", d.Id())
+		}
+	}
+	if (objectType == "database" || objectType == "schema") && len(objects) > 0 {
+		return nil, fmt.Errorf("invalid grant import ID %q, the object must be empty when the object type is %s, expected %s", d.Id(), objectType, grantImportIDFormat)
+	}
+	if objectType == "language" && len(objects) == 0 {
+		return nil, fmt.Errorf("invalid grant import ID %q, the language is required, expected %s", d.Id(), grantImportIDFormat)
+	}
+
+	d.Set(grantObjectTypeAttr, objectType)
+	d.Set(grantObjectsAttr, objects)
+	d.Set(grantWithGrantOptionAttr, false)
+	d.Set(grantRevokeExistingAttr, false)
+	d.SetId(generateGrantID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// splitGrantImportObjects splits the objects of an import ID on the commas
+// which aren't part of a signature or of a list of columns.
+func splitGrantImportObjects(objects string) []string {
+	parts := []string{}
+	depth, start := 0, 0
+	for i, c := range objects {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, objects[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, objects[start:])
+}
+
+// parseGrantImportColumns parses a table and its columns set as mytable(id,name).
+func parseGrantImportColumns(object string) (string, []string, bool) {
+	table, columns, found := strings.Cut(object, "(")
+	if !found || table == "" || !strings.HasSuffix(columns, ")") {
+		return "", nil, false
+	}
+
+	names := strings.Split(strings.TrimSuffix(columns, ")"), ",")
+	for _, name := range names {
+		if name == "" {
+			return "", nil, false
+		}
+	}
+
+	return table, names, true
+}
+
+func resourceRedshiftAssumeRoleGrantCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	for _, query := range []string{createAssumeRoleRevokeQuery(d), createAssumeRoleGrantQuery(d)} {
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not grant ASSUMEROLE with %q: %w", query, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(generateGrantID(d))
+
+	return readAssumeRoleGrants(db, d)
+}
+
+func resourceRedshiftAssumeRoleGrantDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := createAssumeRoleRevokeQuery(d)
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("could not revoke ASSUMEROLE with %q: %w", query, err)
+	}
+
+	return tx.Commit()
+}
+
+func readAssumeRoleGrants(db *DBConnection, d *schema.ResourceData) error {
+	identityType, identityName := grantIdentity(d)
+
+	rows, err := db.Query(`
+  SELECT command_type
+  FROM svv_iam_privileges
+  WHERE iam_arn = $1 AND identity_type = $2 AND ($2 = 'public' OR identity_name = $3)
+`, d.Get(grantAssumeRoleARNAttr).(string), identityType, identityName)
+	if err != nil {
+		return fmt.Errorf("Error reading ASSUMEROLE privileges: %w", err)
+	}
+	defer rows.Close()
+
+	commands := schema.NewSet(schema.HashString, nil)
+	for rows.Next() {
+		var command string
+		if err := rows.Scan(&command); err != nil {
+			return err
+		}
+		commands.Add(strings.ToUpper(command))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	configured := d.Get(grantAssumeRoleForAttr).(*schema.Set)
+	if !expandAssumeRoleCommands(commands).Equal(expandAssumeRoleCommands(configured)) {
+		d.Set(grantAssumeRoleForAttr, commands)
+	}
+
+	return nil
+}
+
+// expandAssumeRoleCommands replaces ALL with the commands it stands for, so
+// that it matches the commands reported by SVV_IAM_PRIVILEGES.
+func expandAssumeRoleCommands(commands *schema.Set) *schema.Set {
+	expanded := schema.NewSet(schema.HashString, nil)
+	for _, command := range commands.List() {
+		if strings.ToUpper(command.(string)) != "ALL" {
+			expanded.Add(strings.ToUpper(command.(string)))
+			continue
+		}
+		for _, c := range grantAssumeRoleCommands {
+			expanded.Add(c)
+		}
+	}
+
+	return expanded
+}
+
+// grantIdentity returns the identity type and name of the grantee, as reported
+// by the SVV_*_PRIVILEGES views.
+func grantIdentity(d resourceValues) (string, string) {
+	if isGrantToPublic(d) {
+		return "public", ""
+	}
+	if groupName, isGroup := d.GetOk(grantGroupAttr); isGroup {
+		return "group", groupName.(string)
+	}
+	if roleName, isRole := d.GetOk(grantRoleAttr); isRole {
+		return "role", roleName.(string)
+	}
+	return "user", d.Get(grantUserAttr).(string)
+}
+
+// grantGrantee renders the grantee of GRANT and REVOKE statements.
+func grantGrantee(d resourceValues) string {
+	identityType, identityName := grantIdentity(d)
+	return granteeSQL(identityType, identityName)
+}
+
+// granteeSQL renders a grantee of the given identity type for GRANT and REVOKE statements.
+func granteeSQL(identityType, identityName string) string {
+	switch identityType {
+	case "public":
+		return "PUBLIC"
+	case "group", "role":
+		return fmt.Sprintf("%s %s", strings.ToUpper(identityType), pq.QuoteIdentifier(identityName))
+	default:
+		return pq.QuoteIdentifier(identityName)
+	}
+}
+
+func createAssumeRoleGrantQuery(d resourceValues) string {
+	commands := []string{}
+	for _, command := range d.Get(grantAssumeRoleForAttr).(*schema.Set).List() {
+		commands = append(commands, strings.ToUpper(command.(string)))
+	}
+	sort.Strings(commands)
+
+	return fmt.Sprintf(
+		"GRANT ASSUMEROLE ON '%s' TO %s FOR %s",
+		pqQuoteLiteral(d.Get(grantAssumeRoleARNAttr).(string)),
+		grantGrantee(d),
+		strings.Join(commands, ", "),
+	)
+}
+
+func createAssumeRoleRevokeQuery(d resourceValues) string {
+	return fmt.Sprintf(
+		"REVOKE ASSUMEROLE ON '%s' FROM %s FOR ALL",
+		pqQuoteLiteral(d.Get(grantAssumeRoleARNAttr).(string)),
+		grantGrantee(d),
+	)
+}
@@ -0,0 +1,173 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	grantRoleRoleAttr        = "role"
+	grantRoleToRoleAttr      = "to_role"
+	grantRoleToUserAttr      = "to_user"
+	grantRoleAdminOptionAttr = "admin_option"
+
+	grantRoleImportIDFormat = "<role>:user:<user> or <role>:role:<role>"
+)
+
+func redshiftGrantRole() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Grants a role to a user or to another role. The grantee inherits the privileges of the granted role. Unlike the ` + "`roles`" + ` attribute of ` + "`redshift_role`" + `, this resource manages a single membership and leaves the other roles granted to the grantee alone. Don't combine it with the ` + "`roles`" + ` attribute of the grantee role, which would revoke the role again.
+`,
+		CreateContext: RedshiftResourceFunc(
+			RedshiftResourceRequireFeature(clusterFeatureRoles, "redshift_grant_role", resourceRedshiftGrantRoleCreate),
+		),
+		ReadContext: RedshiftResourceFunc(resourceRedshiftGrantRoleRead),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftGrantRoleDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftGrantRoleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			grantRoleRoleAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The name of the role to grant.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			grantRoleToRoleAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{grantRoleToRoleAttr, grantRoleToUserAttr},
+				Description:      "The name of the role the role is granted to. Exactly one of `to_role` or `to_user` must be set.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			grantRoleToUserAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{grantRoleToRoleAttr, grantRoleToUserAttr},
+				Description:      "The name of the user the role is granted to. Exactly one of `to_role` or `to_user` must be set.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			grantRoleAdminOptionAttr: {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				Default:       false,
+				ConflictsWith: []string{grantRoleToRoleAttr},
+				Description:   "Whether the user can grant the role to other users and roles. Redshift only supports the admin option for users.",
+			},
+		},
+	}
+}
+
+func generateGrantRoleID(d *schema.ResourceData) string {
+	role := d.Get(grantRoleRoleAttr).(string)
+	if user, ok := d.GetOk(grantRoleToUserAttr); ok {
+		return fmt.Sprintf("%s:user:%s", role, user.(string))
+	}
+
+	return fmt.Sprintf("%s:role:%s", role, d.Get(grantRoleToRoleAttr).(string))
+}
+
+func resourceRedshiftGrantRoleImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid role grant import ID %q, expected %s", d.Id(), grantRoleImportIDFormat)
+	}
+
+	d.Set(grantRoleRoleAttr, parts[0])
+	switch parts[1] {
+	case "user":
+		d.Set(grantRoleToUserAttr, parts[2])
+	case "role":
+		d.Set(grantRoleToRoleAttr, parts[2])
+	default:
+		return nil, fmt.Errorf("invalid role grant import ID %q, the grantee type must be user or role, expected %s", d.Id(), grantRoleImportIDFormat)
+	}
+	d.SetId(generateGrantRoleID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func createGrantRoleQuery(d *schema.ResourceData) string {
+	role := pq.QuoteIdentifier(d.Get(grantRoleRoleAttr).(string))
+	if user, ok := d.GetOk(grantRoleToUserAttr); ok {
+		query := fmt.Sprintf("GRANT ROLE %s TO %s", role, pq.QuoteIdentifier(user.(string)))
+		if d.Get(grantRoleAdminOptionAttr).(bool) {
+			query += " WITH ADMIN OPTION"
+		}
+		return query
+	}
+
+	return fmt.Sprintf("GRANT ROLE %s TO ROLE %s", role, pq.QuoteIdentifier(d.Get(grantRoleToRoleAttr).(string)))
+}
+
+func createRevokeRoleQuery(d *schema.ResourceData) string {
+	role := pq.QuoteIdentifier(d.Get(grantRoleRoleAttr).(string))
+	if user, ok := d.GetOk(grantRoleToUserAttr); ok {
+		return fmt.Sprintf("REVOKE ROLE %s FROM %s", role, pq.QuoteIdentifier(user.(string)))
+	}
+
+	return fmt.Sprintf("REVOKE ROLE %s FROM ROLE %s", role, pq.QuoteIdentifier(d.Get(grantRoleToRoleAttr).(string)))
+}
+
+func resourceRedshiftGrantRoleCreate(db *DBConnection, d *schema.ResourceData) error {
+	query := createGrantRoleQuery(d)
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Error granting role %s: %w", d.Get(grantRoleRoleAttr).(string), err)
+	}
+
+	d.SetId(generateGrantRoleID(d))
+
+	return resourceRedshiftGrantRoleRead(db, d)
+}
+
+func resourceRedshiftGrantRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	role := d.Get(grantRoleRoleAttr).(string)
+
+	var err error
+	if user, ok := d.GetOk(grantRoleToUserAttr); ok {
+		var adminOption bool
+		err = db.QueryRow("SELECT admin_option FROM svv_user_grants WHERE lower(role_name) = lower($1) AND lower(user_name) = lower($2)", role, user.(string)).Scan(&adminOption)
+		if err == nil {
+			d.Set(grantRoleAdminOptionAttr, adminOption)
+		}
+	} else {
+		var grantedRole string
+		err = db.QueryRow("SELECT granted_role_name FROM svv_role_grants WHERE lower(granted_role_name) = lower($1) AND lower(role_name) = lower($2)", role, d.Get(grantRoleToRoleAttr).(string)).Scan(&grantedRole)
+	}
+
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift role grant (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading role grant: %w", err)
+	}
+
+	return nil
+}
+
+func resourceRedshiftGrantRoleDelete(db *DBConnection, d *schema.ResourceData) error {
+	query := createRevokeRoleQuery(d)
+	log.Printf("[DEBUG] %s\n", query)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Error revoking role %s: %w", d.Get(grantRoleRoleAttr).(string), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftGrantRole_Basic(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_grant_role"), "-", "_")
+	parentRoleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_grant_role_parent"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_grant_role_user"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name = %[1]q
+}
+
+resource "redshift_role" "parent" {
+  name = %[2]q
+
+  lifecycle {
+    ignore_changes = [roles]
+  }
+}
+
+resource "redshift_user" "user" {
+  name = %[3]q
+}
+
+resource "redshift_grant_role" "to_role" {
+  role    = redshift_role.role.name
+  to_role = redshift_role.parent.name
+}
+
+resource "redshift_grant_role" "to_user" {
+  role         = redshift_role.role.name
+  to_user      = redshift_user.user.name
+  admin_option = true
+}
+`, roleName, parentRoleName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGrantRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant_role.to_role", "id", fmt.Sprintf("%s:role:%s", roleName, parentRoleName)),
+					resource.TestCheckResourceAttr("redshift_grant_role.to_role", grantRoleAdminOptionAttr, "false"),
+					resource.TestCheckResourceAttr("redshift_grant_role.to_user", "id", fmt.Sprintf("%s:user:%s", roleName, userName)),
+					resource.TestCheckResourceAttr("redshift_grant_role.to_user", grantRoleAdminOptionAttr, "true"),
+				),
+			},
+			{
+				ResourceName:      "redshift_grant_role.to_role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "redshift_grant_role.to_user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrantRole_Validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "redshift_grant_role" "grant" {
+  role = "analyst"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("one of `to_role,to_user` must be specified"),
+			},
+			{
+				Config: `
+resource "redshift_grant_role" "grant" {
+  role         = "analyst"
+  to_role      = "reporting"
+  admin_option = true
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("conflicts with to_role"),
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftGrantRoleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	db, err := client.Connect()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_grant_role" {
+			continue
+		}
+
+		role := rs.Primary.Attributes[grantRoleRoleAttr]
+		var query, grantee string
+		if user := rs.Primary.Attributes[grantRoleToUserAttr]; user != "" {
+			query, grantee = "SELECT 1 FROM svv_user_grants WHERE role_name = $1 AND user_name = $2", user
+		} else {
+			query, grantee = "SELECT 1 FROM svv_role_grants WHERE granted_role_name = $1 AND role_name = $2", rs.Primary.Attributes[grantRoleToRoleAttr]
+		}
+
+		var exists int
+		err := db.QueryRow(query, role, grantee).Scan(&exists)
+		switch {
+		case err == sql.ErrNoRows:
+			continue
+		case err != nil:
+			return fmt.Errorf("Error checking role grant %s: %w", rs.Primary.ID, err)
+		}
+
+		return fmt.Errorf("Role grant %s still exists after destroy", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func TestGrantRoleQueries(t *testing.T) {
+	tests := map[string]struct {
+		input          map[string]interface{}
+		expectedGrant  string
+		expectedRevoke string
+		expectedID     string
+	}{
+		"to role": {
+			input: map[string]interface{}{
+				grantRoleRoleAttr:   "analyst",
+				grantRoleToRoleAttr: "reporting",
+			},
+			expectedGrant:  `GRANT ROLE "analyst" TO ROLE "reporting"`,
+			expectedRevoke: `REVOKE ROLE "analyst" FROM ROLE "reporting"`,
+			expectedID:     "analyst:role:reporting",
+		},
+		"to user": {
+			input: map[string]interface{}{
+				grantRoleRoleAttr:   "analyst",
+				grantRoleToUserAttr: "john",
+			},
+			expectedGrant:  `GRANT ROLE "analyst" TO "john"`,
+			expectedRevoke: `REVOKE ROLE "analyst" FROM "john"`,
+			expectedID:     "analyst:user:john",
+		},
+		"to user with admin option": {
+			input: map[string]interface{}{
+				grantRoleRoleAttr:        "analyst",
+				grantRoleToUserAttr:      "john",
+				grantRoleAdminOptionAttr: true,
+			},
+			expectedGrant:  `GRANT ROLE "analyst" TO "john" WITH ADMIN OPTION`,
+			expectedRevoke: `REVOKE ROLE "analyst" FROM "john"`,
+			expectedID:     "analyst:user:john",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftGrantRole().Schema, tt.input)
+
+			if grant := createGrantRoleQuery(d); grant != tt.expectedGrant {
+				t.Errorf("Expected grant %q, got %q", tt.expectedGrant, grant)
+			}
+			if revoke := createRevokeRoleQuery(d); revoke != tt.expectedRevoke {
+				t.Errorf("Expected revoke %q, got %q", tt.expectedRevoke, revoke)
+			}
+			if id := generateGrantRoleID(d); id != tt.expectedID {
+				t.Errorf("Expected ID %q, got %q", tt.expectedID, id)
+			}
+		})
+	}
+}
+
+func TestResourceRedshiftGrantRoleImport(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		expectedRole  string
+		expectedUser  string
+		expectedTo    string
+		expectedError bool
+	}{
+		"user":                  {id: "Analyst:user:John", expectedRole: "Analyst", expectedUser: "John"},
+		"role":                  {id: "analyst:role:reporting", expectedRole: "analyst", expectedTo: "reporting"},
+		"unknown grantee type":  {id: "analyst:group:reporting", expectedError: true},
+		"missing grantee":       {id: "analyst:user:", expectedError: true},
+		"wrong number of parts": {id: "analyst:reporting", expectedError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := redshiftGrantRole().TestResourceData()
+			d.SetId(tt.id)
+
+			_, err := resourceRedshiftGrantRoleImport(context.Background(), d, nil)
+			if tt.expectedError {
+				if err == nil {
+					t.Fatalf("Expected an error for import ID %q", tt.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if role := d.Get(grantRoleRoleAttr).(string); role != tt.expectedRole {
+				t.Errorf("Expected role %q, got %q", tt.expectedRole, role)
+			}
+			if user := d.Get(grantRoleToUserAttr).(string); user != tt.expectedUser {
+				t.Errorf("Expected user %q, got %q", tt.expectedUser, user)
+			}
+			if to := d.Get(grantRoleToRoleAttr).(string); to != tt.expectedTo {
+				t.Errorf("Expected role grantee %q, got %q", tt.expectedTo, to)
+			}
+		})
+	}
+}
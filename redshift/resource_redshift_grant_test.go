@@ -1,12 +1,18 @@
 package redshift
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/lib/pq"
 )
@@ -56,6 +62,11 @@ resource "redshift_grant" "user" {
 					resource.TestCheckTypeSetElemAttr("redshift_grant.public", "privileges.*", "usage"),
 				),
 			},
+			// Refreshing the state must read the PUBLIC grant back without producing a diff.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -83,6 +94,10 @@ resource "redshift_grant" "public" {
 					resource.TestCheckTypeSetElemAttr("redshift_grant.public", "privileges.*", "temporary"),
 				),
 			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -111,6 +126,10 @@ resource "redshift_grant" "public" {
 					resource.TestCheckTypeSetElemAttr("redshift_grant.public", "privileges.*", "usage"),
 				),
 			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -151,10 +170,362 @@ resource "redshift_grant" "public" {
 					resource.TestCheckTypeSetElemAttr("redshift_grant.public", "privileges.*", "trigger"),
 				),
 			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_AllTablesInSchema(t *testing.T) {
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_all_tables"), "-", "_")
+
+	configGrant := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name              = %[2]q
+  cascade_on_delete = true
+}
+
+resource "redshift_grant" "grant" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  privileges  = ["select"]
+}
+`, groupName, schemaName)
+
+	configWithTable := configGrant + `
+resource "redshift_table" "table" {
+  name   = "created_after_grant"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+`
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			// A schema without tables has nothing to reconcile
+			{
+				Config: configGrant,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "objects.#", "0"),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "select"),
+				),
+			},
+			// Tables created after the grant aren't covered by it until the next apply
+			{
+				Config:             configWithTable,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: configWithTable,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "select"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_NoDiffAfterApply(t *testing.T) {
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_no_diff"), "-", "_")
+
+	config := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "schema" {
+  name              = %[3]q
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name   = "events"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_grant" "schema_user" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage", "create"]
+}
+
+resource "redshift_grant" "schema_group" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage"]
+}
+
+resource "redshift_grant" "schema_public" {
+  group       = "public"
+  schema      = redshift_schema.schema.name
+  object_type = "schema"
+  privileges  = ["usage"]
+}
+
+resource "redshift_grant" "table_user" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_table.table.name]
+  privileges  = ["select", "insert", "update", "delete"]
+}
+
+resource "redshift_grant" "table_group" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  privileges  = ["select"]
+
+  depends_on = [redshift_table.table]
+}
+
+resource "redshift_grant" "table_public" {
+  group       = "public"
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_table.table.name]
+  privileges  = ["select"]
+}
+`, groupName, userName, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.schema_user", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("redshift_grant.table_user", "privileges.#", "4"),
+					resource.TestCheckResourceAttr("redshift_grant.table_group", "privileges.#", "1"),
+				),
+			},
+			// The privileges read back from the system views match the configuration.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_AllProceduresInSchema(t *testing.T) {
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_all_procedures"), "-", "_")
+
+	procedure := func(name string) string {
+		return fmt.Sprintf(`
+resource "redshift_stored_procedure" %[1]q {
+  name   = %[1]q
+  schema = redshift_schema.schema.name
+  body   = "BEGIN RAISE INFO 'called'; END;"
+}
+`, name)
+	}
+
+	configGrant := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name              = %[2]q
+  cascade_on_delete = true
+}
+
+resource "redshift_grant" "grant" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "procedure"
+  privileges  = ["execute"]
+
+  depends_on = [redshift_stored_procedure.granted]
+}
+`, groupName, schemaName) + procedure("granted")
+
+	configWithProcedure := configGrant + procedure("created_after_grant")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: configGrant,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "objects.#", "0"),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "execute"),
+				),
+			},
+			// Procedures created after the grant aren't covered by it until the next apply
+			{
+				Config:             configWithProcedure,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: configWithProcedure,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "execute"),
+				),
+			},
 		},
 	})
 }
 
+func TestGrantToPublicQueries(t *testing.T) {
+	tests := map[string]struct {
+		entity         map[string]interface{}
+		expectedPublic bool
+		expectedGrant  string
+		expectedRevoke string
+	}{
+		"lowercase public group": {
+			entity:         map[string]interface{}{grantGroupAttr: "public"},
+			expectedPublic: true,
+			expectedGrant:  "GRANT usage ON SCHEMA \"test_schema\" TO PUBLIC",
+			expectedRevoke: "REVOKE ALL PRIVILEGES ON SCHEMA \"test_schema\" FROM PUBLIC",
+		},
+		"uppercase public group": {
+			entity:         map[string]interface{}{grantGroupAttr: "PUBLIC"},
+			expectedPublic: true,
+			expectedGrant:  "GRANT usage ON SCHEMA \"test_schema\" TO PUBLIC",
+			expectedRevoke: "REVOKE ALL PRIVILEGES ON SCHEMA \"test_schema\" FROM PUBLIC",
+		},
+		"regular group": {
+			entity:         map[string]interface{}{grantGroupAttr: "public_readers"},
+			expectedPublic: false,
+			expectedGrant:  "GRANT usage ON SCHEMA \"test_schema\" TO GROUP \"public_readers\"",
+			expectedRevoke: "REVOKE ALL PRIVILEGES ON SCHEMA \"test_schema\" FROM GROUP \"public_readers\"",
+		},
+		"role named like public": {
+			entity:         map[string]interface{}{grantRoleAttr: "public_role"},
+			expectedPublic: false,
+			expectedGrant:  "GRANT usage ON SCHEMA \"test_schema\" TO ROLE \"public_role\"",
+			expectedRevoke: "REVOKE ALL PRIVILEGES ON SCHEMA \"test_schema\" FROM ROLE \"public_role\"",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				grantSchemaAttr:     "test_schema",
+				grantObjectTypeAttr: "schema",
+				grantPrivilegesAttr: []interface{}{"usage"},
+			}
+			for k, v := range tc.entity {
+				raw[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, raw)
+
+			if isPublic := isGrantToPublic(d); isPublic != tc.expectedPublic {
+				t.Errorf("isGrantToPublic() = %t, expected %t", isPublic, tc.expectedPublic)
+			}
+
+			// The grantee keyword is omitted for PUBLIC, so compare the queries with normalized whitespace.
+			if query := strings.Join(strings.Fields(createGrantsQuery(d, "test_db")), " "); query != tc.expectedGrant {
+				t.Errorf("createGrantsQuery() = %q, expected %q", query, tc.expectedGrant)
+			}
+			if query := strings.Join(strings.Fields(createGrantsRevokeQuery(d, "test_db")), " "); query != tc.expectedRevoke {
+				t.Errorf("createGrantsRevokeQuery() = %q, expected %q", query, tc.expectedRevoke)
+			}
+		})
+	}
+}
+
+// The public schema and the PUBLIC grantee are unrelated: the schema is quoted
+// as any other identifier, only the grantee is rendered as the keyword.
+func TestGrantOnPublicSchemaQueries(t *testing.T) {
+	tests := map[string]struct {
+		raw            map[string]interface{}
+		expectedGrant  string
+		expectedRevoke string
+	}{
+		"schema to PUBLIC": {
+			raw: map[string]interface{}{
+				grantGroupAttr:      "PUBLIC",
+				grantObjectTypeAttr: "schema",
+				grantPrivilegesAttr: []interface{}{"usage"},
+			},
+			expectedGrant:  `GRANT usage ON SCHEMA "public" TO PUBLIC`,
+			expectedRevoke: `REVOKE ALL PRIVILEGES ON SCHEMA "public" FROM PUBLIC`,
+		},
+		"schema to a group": {
+			raw: map[string]interface{}{
+				grantGroupAttr:      "analysts",
+				grantObjectTypeAttr: "schema",
+				grantPrivilegesAttr: []interface{}{"create"},
+			},
+			expectedGrant:  `GRANT create ON SCHEMA "public" TO GROUP "analysts"`,
+			expectedRevoke: `REVOKE ALL PRIVILEGES ON SCHEMA "public" FROM GROUP "analysts"`,
+		},
+		"all tables to PUBLIC": {
+			raw: map[string]interface{}{
+				grantGroupAttr:      "public",
+				grantObjectTypeAttr: "table",
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			expectedGrant:  `GRANT select ON ALL TABLES IN SCHEMA "public" TO PUBLIC`,
+			expectedRevoke: `REVOKE ALL PRIVILEGES ON ALL TABLES IN SCHEMA "public" FROM PUBLIC`,
+		},
+		"table to a user": {
+			raw: map[string]interface{}{
+				grantUserAttr:       "alice",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"public"},
+				grantPrivilegesAttr: []interface{}{"select"},
+			},
+			expectedGrant:  `GRANT select ON TABLE "public"."public" TO "alice"`,
+			expectedRevoke: `REVOKE ALL PRIVILEGES ON TABLE "public"."public" FROM "alice"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.raw[grantSchemaAttr] = "public"
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, tc.raw)
+
+			if query := strings.Join(strings.Fields(createGrantsQuery(d, "test_db")), " "); query != tc.expectedGrant {
+				t.Errorf("createGrantsQuery() = %q, expected %q", query, tc.expectedGrant)
+			}
+			if query := strings.Join(strings.Fields(createGrantsRevokeQuery(d, "test_db")), " "); query != tc.expectedRevoke {
+				t.Errorf("createGrantsRevokeQuery() = %q, expected %q", query, tc.expectedRevoke)
+			}
+		})
+	}
+}
+
 func TestAccRedshiftGrant_BasicDatabase(t *testing.T) {
 	groupNames := []string{
 		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
@@ -216,6 +587,42 @@ func TestAccRedshiftGrant_BasicDatabase(t *testing.T) {
 	}
 }
 
+func TestAccRedshiftGrant_DatabaseTempAlias(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_temp"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_grant" "grant" {
+  user        = redshift_user.user.name
+  object_type = "database"
+  privileges  = ["create", "TEMP"]
+}
+`, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "create"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "temporary"),
+				),
+			},
+			// TEMP is read back as TEMPORARY.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccRedshiftGrant_BasicSchema(t *testing.T) {
 	groupNames := []string{
 		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
@@ -288,6 +695,172 @@ func TestAccRedshiftGrant_BasicSchema(t *testing.T) {
 	}
 }
 
+func TestAccRedshiftGrant_SchemaUsageWithoutCreate(t *testing.T) {
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_usage"), "-", "_")
+	config := func(privileges string) string {
+		configBase := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name = %[1]q
+}
+
+resource "redshift_schema" "schema" {
+  name = %[2]q
+}
+`, groupName, schemaName)
+		if privileges == "" {
+			return configBase
+		}
+		return configBase + fmt.Sprintf(`
+resource "redshift_grant" "grant" {
+  group  = redshift_group.group.name
+  schema = redshift_schema.schema.name
+
+  object_type = "schema"
+  privileges  = %[1]s
+}
+`, privileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSchemaPrivilegesRevoked(groupName, schemaName),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["usage"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "usage"),
+				),
+			},
+			{
+				Config: config(`["create", "usage"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "create"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "usage"),
+				),
+			},
+			{
+				// Revoking CREATE keeps USAGE.
+				Config: config(`["usage"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "usage"),
+				),
+			},
+			{
+				Config:   config(`["usage"]`),
+				PlanOnly: true,
+			},
+			{
+				// Destroying the grant revokes the privileges it kept.
+				Config: config(""),
+				Check:  testAccCheckSchemaPrivilegesRevoked(groupName, schemaName),
+			},
+		},
+	})
+}
+
+// testAccCheckSchemaPrivilegesRevoked checks that the group holds no privilege
+// on the schema, which passes too once the schema or the group are dropped.
+func testAccCheckSchemaPrivilegesRevoked(groupName, schemaName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		var acl string
+		err = db.QueryRow("SELECT coalesce(array_to_string(nspacl, '|'), '') FROM pg_namespace WHERE nspname = $1", schemaName).Scan(&acl)
+		switch {
+		case err == sql.ErrNoRows:
+			return nil
+		case err != nil:
+			return fmt.Errorf("could not read the ACL of schema %s: %w", schemaName, err)
+		}
+		if strings.Contains(strings.ReplaceAll(acl, `"`, ""), "group "+groupName+"=") {
+			return fmt.Errorf("expected the privileges of group %s on schema %s to be revoked, got ACL %s", groupName, schemaName, acl)
+		}
+
+		return nil
+	}
+}
+
+func TestSchemaPrivilegesDeleteQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "sales",
+		grantObjectTypeAttr: "schema",
+		grantPrivilegesAttr: []interface{}{"create", "usage"},
+	})
+	d.SetId(generateGrantID(d))
+
+	// The data of a grant being deleted is read from its state, without changes.
+	d, err := schema.InternalMap(redshiftGrant().Schema).Data(d.State(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []string{`REVOKE ALL PRIVILEGES ON SCHEMA "sales" FROM GROUP "analysts"`}
+	if queries := createGrantsRevokeQueries(d, "redshift"); strings.Join(queries, ";") != strings.Join(expected, ";") {
+		t.Errorf("Expected %v, got %v", expected, queries)
+	}
+}
+
+func TestSchemaPrivilegesUpdateQueries(t *testing.T) {
+	tests := map[string]struct {
+		oldPrivileges []interface{}
+		newPrivileges []interface{}
+		expected      string
+	}{
+		"revoke create": {
+			oldPrivileges: []interface{}{"create", "usage"},
+			newPrivileges: []interface{}{"usage"},
+			expected:      `REVOKE create ON SCHEMA "sales" FROM GROUP "analysts";`,
+		},
+		"grant create": {
+			oldPrivileges: []interface{}{"usage"},
+			newPrivileges: []interface{}{"create", "usage"},
+			expected:      `GRANT create ON SCHEMA "sales" TO GROUP "analysts";`,
+		},
+		"swap": {
+			oldPrivileges: []interface{}{"create"},
+			newPrivileges: []interface{}{"usage"},
+			expected: `REVOKE create ON SCHEMA "sales" FROM GROUP "analysts";
+GRANT usage ON SCHEMA "sales" TO GROUP "analysts";`,
+		},
+		"revoke all": {
+			oldPrivileges: []interface{}{"create", "usage"},
+			newPrivileges: []interface{}{},
+			expected:      `REVOKE create,usage ON SCHEMA "sales" FROM GROUP "analysts";`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				grantGroupAttr:      "analysts",
+				grantSchemaAttr:     "sales",
+				grantObjectTypeAttr: "schema",
+				grantPrivilegesAttr: tc.oldPrivileges,
+			}
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, raw)
+			d.SetId(generateGrantID(d))
+
+			raw[grantPrivilegesAttr] = tc.newPrivileges
+			diff, err := redshiftGrant().Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := diff.Attributes[generatedSQLAttr].New; actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
 func TestAccRedshiftGrant_BasicTable(t *testing.T) {
 	groupNames := []string{
 		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_"),
@@ -369,6 +942,20 @@ func TestAccRedshiftGrant_BasicTable(t *testing.T) {
 						resource.TestCheckTypeSetElemAttr("redshift_grant.grant_user", "privileges.*", "trigger"),
 					),
 				},
+				{
+					ResourceName:            "redshift_grant.grant",
+					ImportState:             true,
+					ImportStateId:           fmt.Sprintf("group:%s:table:pg_catalog:pg_user_info", groupName),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{generatedSQLAttr},
+				},
+				{
+					ResourceName:            "redshift_grant.grant_user",
+					ImportState:             true,
+					ImportStateId:           fmt.Sprintf("user:%s:table:pg_catalog:pg_user_info", userName),
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{generatedSQLAttr},
+				},
 			},
 		})
 	}
@@ -526,24 +1113,1047 @@ func TestAccRedshiftGrant_BasicLanguage(t *testing.T) {
 	}
 }
 
-func TestAccRedshiftGrant_Regression_GH_Issue_24(t *testing.T) {
-	userNames := []string{
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
-		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
-	}
-	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grant"), "-", "_")
-	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_db_grant"), "-", "_")
+func TestAccRedshiftGrant_BasicRole(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_role"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_role"), "-", "_")
 
-	for _, userName := range userNames {
-		config := fmt.Sprintf(`
-		resource "redshift_user" "user" {
-		name = %[1]q
-		}
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
 
-		# Create a group named the same as user
-		resource "redshift_group" "group" {
-		name = %[1]q
-		}
+resource "redshift_role" "role" {
+  name = %[2]q
+}
+
+resource "redshift_schema" "schema" {
+  name  = %[3]q
+  owner = redshift_user.user.name
+}
+
+resource "redshift_grant" "schema" {
+  role   = redshift_role.role.name
+  schema = redshift_schema.schema.name
+
+  object_type = "schema"
+  privileges  = ["create", "usage"]
+}
+
+resource "redshift_grant" "table" {
+  role   = redshift_role.role.name
+  schema = redshift_schema.schema.name
+
+  object_type = "table"
+  privileges  = ["select", "insert"]
+}
+
+resource "redshift_grant" "database" {
+  role = redshift_role.role.name
+
+  object_type = "database"
+  privileges  = ["create", "temporary"]
+}
+`, userName, roleName, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.schema", "id", fmt.Sprintf("rn:%s_ot:schema_%s", roleName, schemaName)),
+					resource.TestCheckResourceAttr("redshift_grant.schema", "role", roleName),
+					resource.TestCheckResourceAttr("redshift_grant.schema", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.schema", "privileges.*", "create"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.schema", "privileges.*", "usage"),
+
+					resource.TestCheckResourceAttr("redshift_grant.table", "id", fmt.Sprintf("rn:%s_ot:table_%s", roleName, schemaName)),
+					resource.TestCheckResourceAttr("redshift_grant.table", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.table", "privileges.*", "select"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.table", "privileges.*", "insert"),
+
+					resource.TestCheckResourceAttr("redshift_grant.database", "id", fmt.Sprintf("rn:%s_ot:database", roleName)),
+					resource.TestCheckResourceAttr("redshift_grant.database", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "create"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.database", "privileges.*", "temporary"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_RoleConflictsWithUserAndGroup(t *testing.T) {
+	config := `
+resource "redshift_grant" "grant" {
+  role  = "tf_acc_role"
+  group = "tf_acc_group"
+
+  object_type = "database"
+  privileges  = ["temporary"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("only one of `group,role,user` can be specified"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_WithGrantOption(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grant_option"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_grant_option"), "-", "_")
+	config := func(withGrantOption bool) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_grant" "grant" {
+  user              = redshift_user.user.name
+  schema            = redshift_schema.schema.name
+  object_type       = "schema"
+  privileges        = ["usage", "create"]
+  with_grant_option = %[3]t
+}
+`, schemaName, userName, withGrantOption)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "true"),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "2"),
+				),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "false"),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "2"),
+				),
+			},
+			// Only one of the privileges is grantable, so the grant option must be detected as missing.
+			{
+				PreConfig: func() {
+					dbClient := testAccProvider.Meta().(*Client)
+					conn, err := dbClient.Connect()
+					defer dbClient.Close()
+					if err != nil {
+						t.Fatalf("couldn't start redshift connection: %s", err)
+					}
+					query := fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s WITH GRANT OPTION", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(userName))
+					if _, err := conn.Exec(query); err != nil {
+						t.Fatalf("couldn't grant usage with grant option: %s", err)
+					}
+				},
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_WithGrantOptionConflictsWithGroup(t *testing.T) {
+	config := `
+resource "redshift_grant" "grant" {
+  group             = "tf_acc_group"
+  with_grant_option = true
+
+  object_type = "database"
+  privileges  = ["temporary"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("conflicts with group"),
+			},
+		},
+	})
+}
+
+func TestAclGrantablePrivileges(t *testing.T) {
+	tests := map[string]struct {
+		acl      string
+		grantee  string
+		expected map[rune]bool
+	}{
+		"no privileges": {
+			acl:      "",
+			grantee:  "alice",
+			expected: map[rune]bool{},
+		},
+		"plain privileges": {
+			acl:      "owner=arwdRxt/owner|alice=rw/owner",
+			grantee:  "alice",
+			expected: map[rune]bool{'r': false, 'w': false},
+		},
+		"grantable privileges": {
+			acl:      "owner=arwdRxt/owner|alice=r*w/owner",
+			grantee:  "alice",
+			expected: map[rune]bool{'r': true, 'w': false},
+		},
+		"same privilege held grantable and non-grantable": {
+			acl:      "alice=r/owner|alice=r*/bob",
+			grantee:  "alice",
+			expected: map[rune]bool{'r': true},
+		},
+		"group and public entries are ignored": {
+			acl:      "\"group alice=r*w*/owner\"|=a*/owner|alice=U/owner",
+			grantee:  "alice",
+			expected: map[rune]bool{'U': false},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := aclGrantablePrivileges(tc.acl, tc.grantee)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("aclGrantablePrivileges() = %v, expected %v", result, tc.expected)
+			}
+			for code, grantable := range tc.expected {
+				if held, ok := result[code]; !ok || held != grantable {
+					t.Errorf("aclGrantablePrivileges()[%q] = %t (held: %t), expected %t", code, held, ok, grantable)
+				}
+			}
+		})
+	}
+}
+
+func TestGrantWithGrantOptionQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantUserAttr:            "alice",
+		grantSchemaAttr:          "test_schema",
+		grantObjectTypeAttr:      "table",
+		grantObjectsAttr:         []interface{}{"test_table"},
+		grantPrivilegesAttr:      []interface{}{"select"},
+		grantWithGrantOptionAttr: true,
+	})
+
+	expectedGrant := "GRANT select ON TABLE \"test_schema\".\"test_table\" TO \"alice\" WITH GRANT OPTION"
+	if query := strings.Join(strings.Fields(createGrantsQuery(d, "test_db")), " "); query != expectedGrant {
+		t.Errorf("createGrantsQuery() = %q, expected %q", query, expectedGrant)
+	}
+
+	expectedRevoke := "REVOKE GRANT OPTION FOR ALL PRIVILEGES ON TABLE \"test_schema\".\"test_table\" FROM \"alice\""
+	if query := strings.Join(strings.Fields(createGrantOptionRevokeQuery(d, "test_db")), " "); query != expectedRevoke {
+		t.Errorf("createGrantOptionRevokeQuery() = %q, expected %q", query, expectedRevoke)
+	}
+}
+
+func TestAccRedshiftGrant_Columns(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_columns"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_columns"), "-", "_")
+	configBase := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+`, schemaName, userName)
+	configGrant := func(columns string, privileges string) string {
+		return configBase + fmt.Sprintf(`
+resource "redshift_grant" "columns" {
+  user        = redshift_user.user.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = ["test_table"]
+  columns     = %[1]s
+  privileges  = %[2]s
+}
+`, columns, privileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: configBase,
+			},
+			{
+				PreConfig: func() {
+					dbClient := testAccProvider.Meta().(*Client)
+					conn, err := dbClient.Connect()
+					defer dbClient.Close()
+					if err != nil {
+						t.Fatalf("couldn't start redshift connection: %s", err)
+					}
+					query := fmt.Sprintf("CREATE TABLE %s.test_table (id int, name varchar(32), secret varchar(32))", pq.QuoteIdentifier(schemaName))
+					if _, err := conn.Exec(query); err != nil {
+						t.Fatalf("couldn't create table: %s", err)
+					}
+				},
+				Config: configGrant(`["id", "name"]`, `["select"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.columns", "columns.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "columns.*", "id"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "columns.*", "name"),
+					resource.TestCheckResourceAttr("redshift_grant.columns", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "privileges.*", "select"),
+				),
+			},
+			{
+				Config: configGrant(`["name"]`, `["select", "update"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.columns", "columns.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "columns.*", "name"),
+					resource.TestCheckResourceAttr("redshift_grant.columns", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "privileges.*", "select"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "privileges.*", "update"),
+				),
+			},
+			{
+				Config: configBase + `
+resource "redshift_grant" "columns" {
+  group       = "public"
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = ["test_table"]
+  columns     = ["id"]
+  privileges  = ["select"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.columns", "columns.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "columns.*", "id"),
+					resource.TestCheckResourceAttr("redshift_grant.columns", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.columns", "privileges.*", "select"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_RevokeExisting(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_revoke_existing"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_revoke_existing"), "-", "_")
+	configBase := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+`, schemaName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: configBase,
+			},
+			{
+				PreConfig: func() {
+					dbClient := testAccProvider.Meta().(*Client)
+					conn, err := dbClient.Connect()
+					defer dbClient.Close()
+					if err != nil {
+						t.Fatalf("couldn't start redshift connection: %s", err)
+					}
+					for _, query := range []string{
+						fmt.Sprintf("CREATE TABLE %s.test_table (id int, secret varchar(32))", pq.QuoteIdentifier(schemaName)),
+						fmt.Sprintf("GRANT SELECT (secret) ON TABLE %s.test_table TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(userName)),
+						fmt.Sprintf("GRANT INSERT ON TABLE %s.test_table TO %s WITH GRANT OPTION", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(userName)),
+					} {
+						if _, err := conn.Exec(query); err != nil {
+							t.Fatalf("couldn't run %q: %s", query, err)
+						}
+					}
+				},
+				Config: configBase + `
+resource "redshift_grant" "grant" {
+  user            = redshift_user.user.name
+  schema          = redshift_schema.schema.name
+  object_type     = "table"
+  objects         = ["test_table"]
+  privileges      = ["select"]
+  revoke_existing = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.grant", "privileges.*", "select"),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "with_grant_option", "false"),
+					func(s *terraform.State) error {
+						client := testAccProvider.Meta().(*Client)
+						db, err := client.Connect()
+						if err != nil {
+							return err
+						}
+
+						var columnPrivileges int
+						if err := db.QueryRow("SELECT count(*) FROM svv_column_privileges WHERE identity_type = 'user' AND identity_name = $1 AND namespace_name = $2", userName, schemaName).Scan(&columnPrivileges); err != nil {
+							return err
+						}
+						if columnPrivileges != 0 {
+							return fmt.Errorf("expected the column-level privileges of %s to be revoked, %d are left", userName, columnPrivileges)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestCreateExistingColumnGrantsRevokeQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:          "analysts",
+		grantSchemaAttr:         "test_schema",
+		grantObjectTypeAttr:     "table",
+		grantPrivilegesAttr:     []interface{}{"select"},
+		grantRevokeExistingAttr: true,
+	})
+
+	columns := map[string]*schema.Set{
+		"sales":  schema.NewSet(schema.HashString, []interface{}{"id"}),
+		"events": schema.NewSet(schema.HashString, []interface{}{"payload"}),
+	}
+	expected := []string{
+		`REVOKE select ("payload"),update ("payload") ON TABLE "test_schema"."events" FROM GROUP "analysts"`,
+		`REVOKE select ("id"),update ("id") ON TABLE "test_schema"."sales" FROM GROUP "analysts"`,
+	}
+	if queries := createExistingColumnGrantsRevokeQueries(d, columns); !reflect.DeepEqual(queries, expected) {
+		t.Errorf("createExistingColumnGrantsRevokeQueries() = %q, expected %q", queries, expected)
+	}
+}
+
+func TestAccRedshiftGrant_LateBindingView(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_grant_view"), "-", "_")
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	configBase := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_group" "group" {
+  name = %[2]q
+}
+
+resource "redshift_table" "table" {
+  name   = "events"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_view" "view" {
+  name                   = "events_view"
+  schema                 = redshift_schema.schema.name
+  query                  = "SELECT id FROM ${redshift_schema.schema.name}.${redshift_table.table.name}"
+  with_no_schema_binding = true
+}
+`, schemaName, groupName)
+	configGrant := configBase + `
+resource "redshift_grant" "view" {
+  group       = redshift_group.group.name
+  schema      = redshift_schema.schema.name
+  object_type = "table"
+  objects     = [redshift_view.view.name]
+  privileges  = ["select"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: configGrant,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.view", "objects.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.view", "objects.*", "events_view"),
+					resource.TestCheckResourceAttr("redshift_grant.view", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.view", "privileges.*", "select"),
+				),
+			},
+			{
+				ResourceName:            "redshift_grant.view",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("group:%s:table:%s:events_view", groupName, schemaName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
+			},
+			{
+				// Removing the grant of a view dropped in the meantime doesn't fail.
+				PreConfig: func() {
+					dbClient := testAccProvider.Meta().(*Client)
+					conn, err := dbClient.Connect()
+					defer dbClient.Close()
+					if err != nil {
+						t.Fatalf("couldn't start redshift connection: %s", err)
+					}
+					query := fmt.Sprintf("DROP VIEW %s.events_view", pq.QuoteIdentifier(schemaName))
+					if _, err := conn.Exec(query); err != nil {
+						t.Fatalf("couldn't drop view: %s", err)
+					}
+				},
+				Config: configBase,
+			},
+		},
+	})
+}
+
+func TestGrantObjectsValues(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "test_schema",
+		grantObjectTypeAttr: "table",
+		grantObjectsAttr:    []interface{}{"events", "events_view"},
+		grantPrivilegesAttr: []interface{}{"select"},
+	})
+
+	values := grantObjectsValues{d, schema.NewSet(schema.HashString, []interface{}{"events"})}
+	expected := `REVOKE ALL PRIVILEGES ON TABLE "test_schema"."events" FROM GROUP "analysts"`
+	if query := createGrantsRevokeQuery(values, "test_db"); query != expected {
+		t.Errorf("createGrantsRevokeQuery() = %q, expected %q", query, expected)
+	}
+}
+
+func TestPrivilegeViewsHideGrants(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]interface{}
+		visible  bool
+		expected bool
+	}{
+		"schema of a user without visibility": {
+			config:   map[string]interface{}{grantUserAttr: "analyst", grantSchemaAttr: "analytics", grantObjectTypeAttr: "schema"},
+			expected: true,
+		},
+		"tables of a group without visibility": {
+			config:   map[string]interface{}{grantGroupAttr: "analysts", grantSchemaAttr: "analytics", grantObjectTypeAttr: "table"},
+			expected: true,
+		},
+		"tables with visibility": {
+			config:  map[string]interface{}{grantGroupAttr: "analysts", grantSchemaAttr: "analytics", grantObjectTypeAttr: "table"},
+			visible: true,
+		},
+		"tables of a system schema": {
+			config: map[string]interface{}{grantGroupAttr: "analysts", grantSchemaAttr: "pg_catalog", grantObjectTypeAttr: "table"},
+		},
+		"table columns": {
+			config: map[string]interface{}{grantUserAttr: "analyst", grantSchemaAttr: "analytics", grantObjectTypeAttr: "table", grantObjectsAttr: []interface{}{"events"}, grantColumnsAttr: []interface{}{"id"}},
+		},
+		"tables of a role": {
+			config: map[string]interface{}{grantRoleAttr: "analyst", grantSchemaAttr: "analytics", grantObjectTypeAttr: "table"},
+		},
+		"functions": {
+			config: map[string]interface{}{grantUserAttr: "analyst", grantSchemaAttr: "analytics", grantObjectTypeAttr: "function"},
+		},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			visible := c.visible
+			db := &DBConnection{
+				capabilities: &clusterCapabilities{supported: map[string]bool{}, privilegesVisible: &visible},
+			}
+			c.config[grantPrivilegesAttr] = []interface{}{"select"}
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, c.config)
+
+			hidden, err := privilegeViewsHideGrants(db, d)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if hidden != c.expected {
+				t.Errorf("Expected privilegeViewsHideGrants to be %t, got %t", c.expected, hidden)
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrant_ColumnsValidation(t *testing.T) {
+	tests := map[string]struct {
+		config        string
+		expectedError string
+	}{
+		"not a table": {
+			config: `
+resource "redshift_grant" "columns" {
+  user        = "tf_acc_user"
+  schema      = "public"
+  object_type = "schema"
+  columns     = ["id"]
+  privileges  = ["usage"]
+}
+`,
+			expectedError: "column-level privileges can only be granted on tables",
+		},
+		"multiple tables": {
+			config: `
+resource "redshift_grant" "columns" {
+  user        = "tf_acc_user"
+  schema      = "public"
+  object_type = "table"
+  objects     = ["table_a", "table_b"]
+  columns     = ["id"]
+  privileges  = ["select"]
+}
+`,
+			expectedError: "exactly one table must be set in `objects`",
+		},
+		"non column privilege": {
+			config: `
+resource "redshift_grant" "columns" {
+  user        = "tf_acc_user"
+  schema      = "public"
+  object_type = "table"
+  objects     = ["table_a"]
+  columns     = ["id"]
+  privileges  = ["select", "delete"]
+}
+`,
+			expectedError: "privilege \"delete\" can't be granted on columns",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				CheckDestroy:      func(s *terraform.State) error { return nil },
+				Steps: []resource.TestStep{
+					{
+						Config:      tc.config,
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(tc.expectedError),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestGrantColumnsQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:      "analysts",
+		grantSchemaAttr:     "test_schema",
+		grantObjectTypeAttr: "table",
+		grantObjectsAttr:    []interface{}{"test_table"},
+		grantColumnsAttr:    []interface{}{"id"},
+		grantPrivilegesAttr: []interface{}{"select"},
+	})
+
+	expectedGrant := "GRANT select (\"id\") ON TABLE \"test_schema\".\"test_table\" TO GROUP \"analysts\""
+	if query := strings.Join(strings.Fields(createGrantsQuery(d, "test_db")), " "); query != expectedGrant {
+		t.Errorf("createGrantsQuery() = %q, expected %q", query, expectedGrant)
+	}
+
+	expectedRevoke := "REVOKE select (\"id\"),update (\"id\") ON TABLE \"test_schema\".\"test_table\" FROM GROUP \"analysts\""
+	if query := strings.Join(strings.Fields(createColumnGrantsRevokeQuery(d, "test_db", d.Get(grantColumnsAttr).(*schema.Set))), " "); query != expectedRevoke {
+		t.Errorf("createColumnGrantsRevokeQuery() = %q, expected %q", query, expectedRevoke)
+	}
+}
+
+func TestAccRedshiftGrant_ArgumentTypesValidation(t *testing.T) {
+	tests := map[string]struct {
+		config        string
+		expectedError string
+	}{
+		"not a callable": {
+			config: `
+resource "redshift_grant" "argument_types" {
+  user           = "tf_acc_user"
+  schema         = "public"
+  object_type    = "table"
+  objects        = ["table_a"]
+  argument_types = ["integer"]
+  privileges     = ["select"]
+}
+`,
+			expectedError: "argument types can only be set for functions and procedures",
+		},
+		"all functions": {
+			config: `
+resource "redshift_grant" "argument_types" {
+  user           = "tf_acc_user"
+  schema         = "public"
+  object_type    = "function"
+  argument_types = ["integer"]
+  privileges     = ["execute"]
+}
+`,
+			expectedError: "parameter `objects` is required when `argument_types` is specified",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				CheckDestroy:      func(s *terraform.State) error { return nil },
+				Steps: []resource.TestStep{
+					{
+						Config:      tc.config,
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(tc.expectedError),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestGrantArgumentTypesQueries(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+		grantGroupAttr:         "analysts",
+		grantSchemaAttr:        "test_schema",
+		grantObjectTypeAttr:    "procedure",
+		grantObjectsAttr:       []interface{}{"test_call"},
+		grantArgumentTypesAttr: []interface{}{"integer", "varchar"},
+		grantPrivilegesAttr:    []interface{}{"execute"},
+	})
+
+	expectedGrant := "GRANT execute ON PROCEDURE test_schema.test_call(integer,varchar) TO GROUP \"analysts\""
+	if query := strings.Join(strings.Fields(createGrantsQuery(d, "test_db")), " "); query != expectedGrant {
+		t.Errorf("createGrantsQuery() = %q, expected %q", query, expectedGrant)
+	}
+
+	expectedRevoke := "REVOKE ALL PRIVILEGES ON PROCEDURE test_schema.test_call(integer,varchar) FROM GROUP \"analysts\""
+	if query := strings.Join(strings.Fields(createGrantsRevokeQuery(d, "test_db")), " "); query != expectedRevoke {
+		t.Errorf("createGrantsRevokeQuery() = %q, expected %q", query, expectedRevoke)
+	}
+
+	expectedID := "gn:analysts_ot:procedure_test_schema_test_call(integer,varchar)"
+	if id := generateGrantID(d); id != expectedID {
+		t.Errorf("generateGrantID() = %q, expected %q", id, expectedID)
+	}
+}
+
+func TestAccRedshiftGrant_AssumeRole(t *testing.T) {
+	arn := getEnvOrSkip("REDSHIFT_GRANT_ASSUME_ROLE_ARN", t)
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_")
+	config := func(commands string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+}
+
+resource "redshift_grant" "assume_role" {
+  user            = redshift_user.user.name
+  assume_role_arn = %[2]q
+  for             = %[3]s
+}
+`, userName, arn, commands)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["COPY", "UNLOAD"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.assume_role", "id", fmt.Sprintf("un:%s_ar:%s", userName, arn)),
+					resource.TestCheckResourceAttr("redshift_grant.assume_role", "for.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.assume_role", "for.*", "COPY"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.assume_role", "for.*", "UNLOAD"),
+				),
+			},
+			{
+				Config: config(`["all"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.assume_role", "for.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_grant.assume_role", "for.*", "ALL"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftGrant_AssumeRoleValidation(t *testing.T) {
+	tests := map[string]struct {
+		config        string
+		expectedError string
+	}{
+		"combined with object privileges": {
+			config: `
+resource "redshift_grant" "assume_role" {
+  user            = "tf_acc_user"
+  assume_role_arn = "arn:aws:iam::123456789012:role/Copy"
+  for             = ["COPY"]
+  object_type     = "schema"
+  schema          = "public"
+  privileges      = ["usage"]
+}
+`,
+			expectedError: "conflicts with",
+		},
+		"without commands": {
+			config: `
+resource "redshift_grant" "assume_role" {
+  user            = "tf_acc_user"
+  assume_role_arn = "arn:aws:iam::123456789012:role/Copy"
+}
+`,
+			expectedError: "all of `assume_role_arn,for` must be specified",
+		},
+		"not a role": {
+			config: `
+resource "redshift_grant" "assume_role" {
+  user            = "tf_acc_user"
+  assume_role_arn = "Copy"
+  for             = ["COPY"]
+}
+`,
+			expectedError: "must be the ARN of an IAM role",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:          func() { testAccPreCheck(t) },
+				ProviderFactories: testAccProviders,
+				CheckDestroy:      func(s *terraform.State) error { return nil },
+				Steps: []resource.TestStep{
+					{
+						Config:      tc.config,
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expectedError)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAssumeRoleGrantQueries(t *testing.T) {
+	tests := map[string]struct {
+		grantee        map[string]interface{}
+		expectedGrant  string
+		expectedRevoke string
+		expectedID     string
+	}{
+		"user": {
+			grantee:        map[string]interface{}{grantUserAttr: "john"},
+			expectedGrant:  `GRANT ASSUMEROLE ON 'arn:aws:iam::123456789012:role/Copy' TO "john" FOR COPY, UNLOAD`,
+			expectedRevoke: `REVOKE ASSUMEROLE ON 'arn:aws:iam::123456789012:role/Copy' FROM "john" FOR ALL`,
+			expectedID:     "un:john_ar:arn:aws:iam::123456789012:role/Copy",
+		},
+		"group": {
+			grantee:        map[string]interface{}{grantGroupAttr: "loaders"},
+			expectedGrant:  `GRANT ASSUMEROLE ON 'arn:aws:iam::123456789012:role/Copy' TO GROUP "loaders" FOR COPY, UNLOAD`,
+			expectedRevoke: `REVOKE ASSUMEROLE ON 'arn:aws:iam::123456789012:role/Copy' FROM GROUP "loaders" FOR ALL`,
+			expectedID:     "gn:loaders_ar:arn:aws:iam::123456789012:role/Copy",
+		},
+		"public": {
+			grantee:        map[string]interface{}{grantGroupAttr: "PUBLIC"},
+			expectedGrant:  `GRANT ASSUMEROLE ON 'arn:aws:iam::123456789012:role/Copy' TO PUBLIC FOR COPY, UNLOAD`,
+			expectedRevoke: `REVOKE ASSUMEROLE ON 'arn:aws:iam::123456789012:role/Copy' FROM PUBLIC FOR ALL`,
+			expectedID:     "gn:public_ar:arn:aws:iam::123456789012:role/Copy",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				grantAssumeRoleARNAttr: "arn:aws:iam::123456789012:role/Copy",
+				grantAssumeRoleForAttr: []interface{}{"unload", "COPY"},
+			}
+			for k, v := range tt.grantee {
+				raw[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, raw)
+
+			if query := createAssumeRoleGrantQuery(d); query != tt.expectedGrant {
+				t.Errorf("createAssumeRoleGrantQuery() = %q, expected %q", query, tt.expectedGrant)
+			}
+			if query := createAssumeRoleRevokeQuery(d); query != tt.expectedRevoke {
+				t.Errorf("createAssumeRoleRevokeQuery() = %q, expected %q", query, tt.expectedRevoke)
+			}
+			if id := generateGrantID(d); id != tt.expectedID {
+				t.Errorf("generateGrantID() = %q, expected %q", id, tt.expectedID)
+			}
+		})
+	}
+}
+
+func TestExpandAssumeRoleCommands(t *testing.T) {
+	all := expandAssumeRoleCommands(schema.NewSet(schema.HashString, []interface{}{"ALL"}))
+	listed := expandAssumeRoleCommands(schema.NewSet(schema.HashString, []interface{}{"COPY", "unload", "EXTERNAL FUNCTION", "CREATE MODEL"}))
+	if !all.Equal(listed) {
+		t.Errorf("Expected ALL to stand for every command, got %v and %v", all.List(), listed.List())
+	}
+
+	copyOnly := expandAssumeRoleCommands(schema.NewSet(schema.HashString, []interface{}{"COPY"}))
+	if copyOnly.Equal(all) {
+		t.Errorf("Expected COPY alone to differ from ALL")
+	}
+}
+
+func TestGrantCallableObjects(t *testing.T) {
+	tests := map[string]struct {
+		objects       []interface{}
+		argumentTypes []interface{}
+		expected      []string
+	}{
+		"without argument types": {
+			objects:  []interface{}{"test_call(float)"},
+			expected: []string{"test_call(float)"},
+		},
+		"with argument types": {
+			objects:       []interface{}{"test_call", "other_call"},
+			argumentTypes: []interface{}{"int", "float"},
+			expected:      []string{"other_call(int,float)", "test_call(int,float)"},
+		},
+		"object defining its signature": {
+			objects:       []interface{}{"test_call()", "other_call"},
+			argumentTypes: []interface{}{"int"},
+			expected:      []string{"other_call(int)", "test_call()"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{
+				grantGroupAttr:         "analysts",
+				grantSchemaAttr:        "test_schema",
+				grantObjectTypeAttr:    "function",
+				grantObjectsAttr:       tc.objects,
+				grantArgumentTypesAttr: tc.argumentTypes,
+				grantPrivilegesAttr:    []interface{}{"execute"},
+			})
+
+			callables := []string{}
+			for _, callable := range grantCallableObjects(d).List() {
+				callables = append(callables, callable.(string))
+			}
+			sort.Strings(callables)
+
+			if !reflect.DeepEqual(callables, tc.expected) {
+				t.Errorf("Expected callables %v, got %v", tc.expected, callables)
+			}
+		})
+	}
+}
+
+func TestGrantStatementsAreBatched(t *testing.T) {
+	tests := map[string]struct {
+		input                   map[string]interface{}
+		expectedRevokeStatement int
+	}{
+		"all table privileges": {
+			input: map[string]interface{}{
+				grantGroupAttr:      "analysts",
+				grantSchemaAttr:     "test_schema",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"table_a", "table_b", "table_c"},
+				grantPrivilegesAttr: []interface{}{"select", "update", "insert", "delete", "drop", "references", "rule", "trigger"},
+			},
+			expectedRevokeStatement: 1,
+		},
+		"with grant option": {
+			input: map[string]interface{}{
+				grantUserAttr:            "analyst",
+				grantSchemaAttr:          "test_schema",
+				grantObjectTypeAttr:      "schema",
+				grantPrivilegesAttr:      []interface{}{"create", "usage"},
+				grantWithGrantOptionAttr: true,
+			},
+			// The grant option is only revoked when it was granted before
+			expectedRevokeStatement: 1,
+		},
+		"columns": {
+			input: map[string]interface{}{
+				grantGroupAttr:      "analysts",
+				grantSchemaAttr:     "test_schema",
+				grantObjectTypeAttr: "table",
+				grantObjectsAttr:    []interface{}{"table_a"},
+				grantColumnsAttr:    []interface{}{"id", "name"},
+				grantPrivilegesAttr: []interface{}{"select", "update"},
+			},
+			expectedRevokeStatement: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, tc.input)
+			d.SetId("grant")
+
+			if queries := createGrantsRevokeQueries(d, "test_db"); len(queries) != tc.expectedRevokeStatement {
+				t.Errorf("Expected %d REVOKE statements, got %d: %v", tc.expectedRevokeStatement, len(queries), queries)
+			}
+
+			query := createGrantsQuery(d, "test_db")
+			if !strings.HasPrefix(query, "GRANT ") || strings.Contains(query, ";") {
+				t.Errorf("Expected a single GRANT statement, got %q", query)
+			}
+			for _, privilege := range grantPrivilegesList(d) {
+				if !strings.Contains(query, privilege) {
+					t.Errorf("Expected privilege %q to be granted by %q", privilege, query)
+				}
+			}
+		})
+	}
+}
+
+func TestAccRedshiftGrant_Regression_GH_Issue_24(t *testing.T) {
+	userNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user@tf_acc_domain.tld"), "-", "_"),
+	}
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grant"), "-", "_")
+	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_db_grant"), "-", "_")
+
+	for _, userName := range userNames {
+		config := fmt.Sprintf(`
+		resource "redshift_user" "user" {
+		name = %[1]q
+		}
+
+		# Create a group named the same as user
+		resource "redshift_group" "group" {
+		name = %[1]q
+		}
 
 		# Create a schema and set user as owner
 		resource "redshift_schema" "schema" {
@@ -779,3 +2389,270 @@ func testAccRedshiftGrant_basicCallables_dropResources(t *testing.T, db *DBConne
 	}
 	return nil
 }
+
+func TestAccRedshiftGrant_OtherDatabase(t *testing.T) {
+	dbName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_grant_db"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_grant_user"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_database" "db" {
+  name = %[1]q
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_grant" "grant" {
+  database    = redshift_database.db.name
+  user        = redshift_user.user.name
+  schema      = "public"
+  object_type = "schema"
+  privileges  = ["create", "usage"]
+}
+`, dbName, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_grant.grant", "id", fmt.Sprintf("dn:%s_un:%s_ot:schema_public", dbName, userName)),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "database", dbName),
+					resource.TestCheckResourceAttr("redshift_grant.grant", "privileges.#", "2"),
+				),
+			},
+			// The privileges must be read from the catalog of the granted database
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "redshift_grant.grant",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("user:%s:schema:public::%s", userName, dbName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
+			},
+		},
+	})
+}
+
+func TestResourceRedshiftGrantImport(t *testing.T) {
+	tests := map[string]struct {
+		id              string
+		expectedID      string
+		expectedObjects []string
+		expectedColumns []string
+		expectError     bool
+	}{
+		"user table":         {id: "user:alice:table:myschema:mytable", expectedID: "un:alice_ot:table_myschema_mytable"},
+		"mixed case table":   {id: "user:alice:table:MySchema:MyTable", expectedID: "un:alice_ot:table_MySchema_MyTable", expectedObjects: []string{"MyTable"}},
+		"tables":             {id: "user:alice:table:myschema:events,Users", expectedObjects: []string{"events", "Users"}},
+		"table columns":      {id: "user:alice:table:myschema:mytable(id,Name)", expectedID: "un:alice_ot:table_myschema_mytable", expectedObjects: []string{"mytable"}, expectedColumns: []string{"id", "Name"}},
+		"function signature": {id: "user:alice:function:myschema:f_add(integer,numeric(10,2))", expectedID: "un:alice_ot:function_myschema_f_add(integer,numeric(10,2))"},
+		"procedures":         {id: "user:alice:procedure:myschema:p_load(varchar),p_purge()", expectedObjects: []string{"p_load(varchar)", "p_purge()"}},
+		"columns of tables":  {id: "user:alice:table:myschema:a(id),b", expectError: true},
+		"empty column":       {id: "user:alice:table:myschema:mytable(id,)", expectError: true},
+		"unclosed columns":   {id: "user:alice:table:myschema:mytable(id", expectError: true},
+		"language signature": {id: "user:alice:language::plpythonu(text)", expectError: true},
+		"public schema":      {id: "group:PUBLIC:schema:myschema:", expectedID: "gn:public_ot:schema_myschema"},
+		"role database":      {id: "role:Analyst:database::", expectedID: "rn:analyst_ot:database"},
+		"group language":     {id: "group:devs:language::plpythonu", expectedID: "gn:devs_ot:language_plpythonu"},
+		"all tables":         {id: "user:alice:table:myschema:", expectedID: "un:alice_ot:table_myschema"},
+		"other database":     {id: "user:alice:table:myschema:mytable:OtherDB", expectedID: "dn:otherdb_un:alice_ot:table_myschema_mytable"},
+		"missing parts":      {id: "user:alice:table", expectError: true},
+		"unknown grantee":    {id: "owner:alice:table:myschema:mytable", expectError: true},
+		"empty grantee":      {id: "user::table:myschema:mytable", expectError: true},
+		"unknown type":       {id: "user:alice:view:myschema:myview", expectError: true},
+		"table no schema":    {id: "user:alice:table::mytable", expectError: true},
+		"schema with object": {id: "user:alice:schema:myschema:mytable", expectError: true},
+		"language no object": {id: "user:alice:language::", expectError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftGrant().Schema, map[string]interface{}{})
+			d.SetId(tc.id)
+
+			_, err := resourceRedshiftGrantImport(context.Background(), d, nil)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error for import ID %q", tc.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.expectedID != "" && d.Id() != tc.expectedID {
+				t.Errorf("Expected ID %q, got %q", tc.expectedID, d.Id())
+			}
+			sorted := func(names []string) []string {
+				names = append([]string{}, names...)
+				sort.Strings(names)
+				return names
+			}
+			setNames := func(attr string) []string {
+				names := []string{}
+				for _, name := range d.Get(attr).(*schema.Set).List() {
+					names = append(names, name.(string))
+				}
+				return sorted(names)
+			}
+			if objects := setNames(grantObjectsAttr); tc.expectedObjects != nil && !reflect.DeepEqual(objects, sorted(tc.expectedObjects)) {
+				t.Errorf("Expected objects %v, got %v", tc.expectedObjects, objects)
+			}
+			if columns := setNames(grantColumnsAttr); !reflect.DeepEqual(columns, sorted(tc.expectedColumns)) {
+				t.Errorf("Expected columns %v, got %v", tc.expectedColumns, columns)
+			}
+		})
+	}
+}
+
+// fakeTablePrivilegeRows yields the rows of a catalog of tables, each holding
+// the same privileges, without keeping them in memory.
+type fakeTablePrivilegeRows struct {
+	tables     int
+	privileges []string
+	table      int
+	privilege  int
+}
+
+func (r *fakeTablePrivilegeRows) Next() bool {
+	r.privilege++
+	if r.privilege >= len(r.privileges) {
+		r.privilege = 0
+		r.table++
+	}
+	return r.table <= r.tables
+}
+
+func (r *fakeTablePrivilegeRows) Scan(dest ...interface{}) error {
+	*dest[0].(*string) = fmt.Sprintf("table_%06d", r.table)
+	if len(r.privileges) == 0 {
+		*dest[1].(*sql.NullString) = sql.NullString{}
+	} else {
+		*dest[1].(*sql.NullString) = sql.NullString{String: r.privileges[r.privilege], Valid: true}
+	}
+	return nil
+}
+
+func (r *fakeTablePrivilegeRows) Err() error {
+	return nil
+}
+
+func newFakeTablePrivilegeRows(tables int, privileges ...string) *fakeTablePrivilegeRows {
+	return &fakeTablePrivilegeRows{tables: tables, privileges: privileges, privilege: len(privileges)}
+}
+
+type sliceTablePrivilegeRows struct {
+	rows [][2]string
+	next int
+}
+
+func (r *sliceTablePrivilegeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *sliceTablePrivilegeRows) Scan(dest ...interface{}) error {
+	row := r.rows[r.next-1]
+	*dest[0].(*string) = row[0]
+	*dest[1].(*sql.NullString) = sql.NullString{String: row[1], Valid: row[1] != ""}
+	return nil
+}
+
+func (r *sliceTablePrivilegeRows) Err() error {
+	return nil
+}
+
+func TestDiffTablePrivileges(t *testing.T) {
+	set := func(values ...interface{}) *schema.Set {
+		return schema.NewSet(schema.HashString, values)
+	}
+
+	tests := map[string]struct {
+		rows            [][2]string
+		objects         *schema.Set
+		expected        *schema.Set
+		expectedDiffers bool
+		expectedResult  *schema.Set
+	}{
+		"all tables match": {
+			rows:     [][2]string{{"a", "SELECT"}, {"a", "INSERT"}, {"b", "INSERT"}, {"b", "SELECT"}},
+			objects:  set(),
+			expected: set("select", "insert"),
+		},
+		"one table differs": {
+			rows:            [][2]string{{"a", "SELECT"}, {"b", "SELECT"}, {"b", "DELETE"}, {"c", "SELECT"}},
+			objects:         set(),
+			expected:        set("select"),
+			expectedDiffers: true,
+			expectedResult:  set("select", "delete"),
+		},
+		"table without privileges": {
+			rows:            [][2]string{{"a", "SELECT"}, {"b", ""}},
+			objects:         set(),
+			expected:        set("select"),
+			expectedDiffers: true,
+			expectedResult:  set(),
+		},
+		"ungrantable privileges ignored": {
+			rows:     [][2]string{{"a", "SELECT"}, {"a", "ALTER"}},
+			objects:  set(),
+			expected: set("select"),
+		},
+		"only objects compared": {
+			rows:     [][2]string{{"a", "SELECT"}, {"b", "DELETE"}},
+			objects:  set("a"),
+			expected: set("select"),
+		},
+		"missing object": {
+			rows:            [][2]string{{"a", "SELECT"}},
+			objects:         set("a", "missing"),
+			expected:        set("select"),
+			expectedDiffers: true,
+			expectedResult:  set(),
+		},
+		"empty schema": {
+			rows:     nil,
+			objects:  set(),
+			expected: set("select"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			privileges, differs, err := diffTablePrivileges(&sliceTablePrivilegeRows{rows: tc.rows}, tc.objects, tc.expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if differs != tc.expectedDiffers {
+				t.Fatalf("diffTablePrivileges() differs = %t, expected %t", differs, tc.expectedDiffers)
+			}
+			if differs && !privileges.Equal(tc.expectedResult) {
+				t.Errorf("diffTablePrivileges() = %v, expected %v", privileges.List(), tc.expectedResult.List())
+			}
+		})
+	}
+}
+
+func BenchmarkDiffTablePrivileges(b *testing.B) {
+	expected := schema.NewSet(schema.HashString, []interface{}{"select", "insert", "update"})
+	objects := schema.NewSet(schema.HashString, nil)
+
+	for _, tables := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("%d tables", tables), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rows := newFakeTablePrivilegeRows(tables, "SELECT", "INSERT", "UPDATE")
+				if _, differs, err := diffTablePrivileges(rows, objects, expected); err != nil || differs {
+					b.Fatalf("diffTablePrivileges() differs = %t, err = %v", differs, err)
+				}
+			}
+		})
+	}
+}
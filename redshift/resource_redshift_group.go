@@ -1,11 +1,14 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
@@ -14,12 +17,16 @@ import (
 const (
 	groupNameAttr  = "name"
 	groupUsersAttr = "users"
+
+	groupRoleEquivalentSQLAttr = "role_equivalent_sql"
 )
 
 func redshiftGroup() *schema.Resource {
 	return &schema.Resource{
 		Description: `
 Groups are collections of users who are all granted whatever privileges are associated with the group. You can use groups to assign privileges by role. For example, you can create different groups for sales, administration, and support and give the users in each group the appropriate access to the data they require for their work. You can grant or revoke privileges at the group level, and those changes will apply to all members of the group, except for superusers.
+
+AWS recommends using roles instead of groups. The ` + "`role_equivalent_sql`" + ` attribute holds the statements creating a role with the same members, to help migrating a group to a ` + "`redshift_role`" + ` with ` + "`redshift_grant_role`" + ` resources. The privileges granted to the group must be granted to the role separately, e.g. by changing the ` + "`group`" + ` of its ` + "`redshift_grant`" + ` resources to ` + "`role`" + `.
 `,
 		CreateContext: RedshiftResourceFunc(resourceRedshiftGroupCreate),
 		ReadContext:   RedshiftResourceFunc(resourceRedshiftGroupRead),
@@ -31,16 +38,20 @@ Groups are collections of users who are all granted whatever privileges are asso
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.ComputedIf(groupRoleEquivalentSQLAttr, func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+			return d.HasChanges(groupNameAttr, groupUsersAttr)
+		}),
 
 		Schema: map[string]*schema.Schema{
 			groupNameAttr: {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Name of the user group. Group names beginning with two underscores are reserved for Amazon Redshift internal use.",
-				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile("^__.*"), "Group names beginning with two underscores are reserved for Amazon Redshift internal use"),
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the user group. Group names beginning with two underscores are reserved for Amazon Redshift internal use. The group name can't be `PUBLIC`.",
+				ValidateFunc: validation.All(
+					validation.StringDoesNotMatch(regexp.MustCompile("^__.*"), "Group names beginning with two underscores are reserved for Amazon Redshift internal use"),
+					validateNotPublic("Group"),
+				),
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			groupUsersAttr: {
 				Type:     schema.TypeSet,
@@ -50,6 +61,11 @@ Groups are collections of users who are all granted whatever privileges are asso
 				},
 				Description: "List of the user names to add to the group",
 			},
+			groupRoleEquivalentSQLAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The statements creating a role with the same name and members as the group, for migrating the group to a role.",
+			},
 		},
 	}
 }
@@ -85,6 +101,7 @@ func resourceRedshiftGroupReadImpl(db *DBConnection, d *schema.ResourceData) err
 
 	d.Set(groupNameAttr, groupName)
 	d.Set(groupUsersAttr, groupUsers)
+	d.Set(groupRoleEquivalentSQLAttr, groupRoleEquivalentSQL(groupName, groupUsers))
 
 	return nil
 }
@@ -92,7 +109,7 @@ func resourceRedshiftGroupReadImpl(db *DBConnection, d *schema.ResourceData) err
 func resourceRedshiftGroupCreate(db *DBConnection, d *schema.ResourceData) error {
 	groupName := d.Get(groupNameAttr).(string)
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -114,11 +131,16 @@ func resourceRedshiftGroupCreate(db *DBConnection, d *schema.ResourceData) error
 	}
 
 	if _, err := tx.Exec(sql); err != nil {
-		return fmt.Errorf("Could not create redshift group: %s", err)
+		return fmt.Errorf("Could not create redshift group: %w", createObjectError(err, "redshift_group"))
+	}
+
+	names, err := storedIdentifiers(tx, groupName)
+	if err != nil {
+		return err
 	}
 
 	var groSysID string
-	if err := tx.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", strings.ToLower(groupName)).Scan(&groSysID); err != nil {
+	if err := tx.QueryRow("SELECT grosysid FROM pg_group WHERE groname = $1", names[0]).Scan(&groSysID); err != nil {
 		return fmt.Errorf("Could not get redshift group id for '%s': %s", groupName, err)
 	}
 
@@ -134,7 +156,7 @@ func resourceRedshiftGroupCreate(db *DBConnection, d *schema.ResourceData) error
 func resourceRedshiftGroupDelete(db *DBConnection, d *schema.ResourceData) error {
 	groupName := d.Get(groupNameAttr).(string)
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -168,7 +190,7 @@ func resourceRedshiftGroupDelete(db *DBConnection, d *schema.ResourceData) error
 }
 
 func resourceRedshiftGroupUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -189,7 +211,7 @@ func resourceRedshiftGroupUpdate(db *DBConnection, d *schema.ResourceData) error
 	return resourceRedshiftGroupReadImpl(db, d)
 }
 
-func setGroupName(tx *sql.Tx, d *schema.ResourceData) error {
+func setGroupName(tx *DBTransaction, d *schema.ResourceData) error {
 	if !d.HasChange(groupNameAttr) {
 		return nil
 	}
@@ -210,7 +232,7 @@ func setGroupName(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func checkIfUserExists(tx *sql.Tx, name string) (bool, error) {
+func checkIfUserExists(tx *DBTransaction, name string) (bool, error) {
 
 	var result int
 	err := tx.QueryRow("SELECT 1 FROM pg_user_info WHERE usename=$1", name).Scan(&result)
@@ -225,26 +247,25 @@ func checkIfUserExists(tx *sql.Tx, name string) (bool, error) {
 	return true, nil
 }
 
-func setUsersNames(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
+func setUsersNames(tx *DBTransaction, db *DBConnection, d *schema.ResourceData) error {
 	if !d.HasChange(groupUsersAttr) {
 		return nil
 	}
 
 	groupName := d.Get(groupNameAttr).(string)
 	oldUsersSet, newUsersSet := d.GetChange(groupUsersAttr)
-	removedUsers := oldUsersSet.(*schema.Set).Difference(newUsersSet.(*schema.Set))
-	addedUsers := newUsersSet.(*schema.Set).Difference(oldUsersSet.(*schema.Set))
+	addedUsers, removedUsers := groupUsersDelta(oldUsersSet.(*schema.Set), newUsersSet.(*schema.Set))
 
-	if removedUsers.Len() > 0 {
+	if len(removedUsers) > 0 {
 		removedUsersNamesSafe := []string{}
-		for _, name := range removedUsers.List() {
-			userExists, err := checkIfUserExists(tx, name.(string))
+		for _, name := range removedUsers {
+			userExists, err := checkIfUserExists(tx, name)
 			if err != nil {
 				return err
 			}
 
 			if userExists {
-				removedUsersNamesSafe = append(removedUsersNamesSafe, pq.QuoteIdentifier(name.(string)))
+				removedUsersNamesSafe = append(removedUsersNamesSafe, pq.QuoteIdentifier(name))
 			}
 		}
 
@@ -257,10 +278,10 @@ func setUsersNames(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
 		}
 	}
 
-	if addedUsers.Len() > 0 {
+	if len(addedUsers) > 0 {
 		addedUsersNamesSafe := []string{}
-		for _, name := range addedUsers.List() {
-			addedUsersNamesSafe = append(addedUsersNamesSafe, pq.QuoteIdentifier(name.(string)))
+		for _, name := range addedUsers {
+			addedUsersNamesSafe = append(addedUsersNamesSafe, pq.QuoteIdentifier(name))
 		}
 
 		sql := fmt.Sprintf("ALTER GROUP %s ADD USER %s", pq.QuoteIdentifier(groupName), strings.Join(addedUsersNamesSafe, ", "))
@@ -272,3 +293,35 @@ func setUsersNames(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
 
 	return nil
 }
+
+// groupUsersDelta returns the sorted names of the users to add to and to drop
+// from the group, so that members which didn't change aren't touched.
+func groupUsersDelta(oldUsers, newUsers *schema.Set) ([]string, []string) {
+	added := []string{}
+	for _, name := range newUsers.Difference(oldUsers).List() {
+		added = append(added, name.(string))
+	}
+	removed := []string{}
+	for _, name := range oldUsers.Difference(newUsers).List() {
+		removed = append(removed, name.(string))
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+// groupRoleEquivalentSQL returns the statements creating a role with the name
+// of the group and granting it to the members of the group.
+func groupRoleEquivalentSQL(groupName string, users []string) string {
+	users = append([]string{}, users...)
+	sort.Strings(users)
+
+	statements := []string{fmt.Sprintf("CREATE ROLE %s;", pq.QuoteIdentifier(groupName))}
+	for _, user := range users {
+		statements = append(statements, fmt.Sprintf("GRANT ROLE %s TO %s;", pq.QuoteIdentifier(groupName), pq.QuoteIdentifier(user)))
+	}
+
+	return strings.Join(statements, "\n")
+}
@@ -3,12 +3,15 @@ package redshift
 import (
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftGroup_Basic(t *testing.T) {
@@ -29,6 +32,7 @@ func TestAccRedshiftGroup_Basic(t *testing.T) {
 					testAccCheckRedshiftGroupExists("group_defaults"),
 					resource.TestCheckResourceAttr("redshift_group.group_defaults", "name", "group_defaults"),
 					resource.TestCheckResourceAttr("redshift_group.group_defaults", "users.#", "0"),
+					resource.TestCheckResourceAttr("redshift_group.group_defaults", groupRoleEquivalentSQLAttr, `CREATE ROLE "group_defaults";`),
 
 					testAccCheckRedshiftGroupExists("group_users"),
 					resource.TestCheckResourceAttr("redshift_group.group_users", "name", "group_users"),
@@ -190,6 +194,114 @@ resource "redshift_user" "user2" {
 	})
 }
 
+func TestAccRedshiftGroup_UserAddedOutOfBand(t *testing.T) {
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group"), "-", "_")
+	userName1 := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group_user"), "-", "_")
+	userName2 := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_group_user"), "-", "_")
+
+	config := fmt.Sprintf(`
+resource "redshift_group" "group" {
+  name  = %[1]q
+  users = [
+    redshift_user.user1.name,
+  ]
+}
+
+resource "redshift_user" "user1" {
+  name = %[2]q
+}
+
+resource "redshift_user" "user2" {
+  name = %[3]q
+}
+`, groupName, userName1, userName2)
+
+	addUserOutOfBand := func() {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("Unable to connect to database: %s", err)
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER GROUP %s ADD USER %s", pq.QuoteIdentifier(groupName), pq.QuoteIdentifier(userName2))); err != nil {
+			t.Fatalf("Unable to add user to group: %s", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftGroupExists(groupName),
+					resource.TestCheckResourceAttr("redshift_group.group", "users.#", "1"),
+				),
+			},
+			{
+				PreConfig:          addUserOutOfBand,
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_group.group", "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_group.group", "users.*", userName1),
+				),
+			},
+		},
+	})
+}
+
+func TestGroupUsersDelta(t *testing.T) {
+	tests := map[string]struct {
+		oldUsers        []interface{}
+		newUsers        []interface{}
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		"no change": {
+			oldUsers:        []interface{}{"alice", "bob"},
+			newUsers:        []interface{}{"bob", "alice"},
+			expectedAdded:   []string{},
+			expectedRemoved: []string{},
+		},
+		"added users": {
+			oldUsers:        []interface{}{"alice"},
+			newUsers:        []interface{}{"alice", "dave", "carol"},
+			expectedAdded:   []string{"carol", "dave"},
+			expectedRemoved: []string{},
+		},
+		"removed users": {
+			oldUsers:        []interface{}{"alice", "dave", "carol"},
+			newUsers:        []interface{}{"carol"},
+			expectedAdded:   []string{},
+			expectedRemoved: []string{"alice", "dave"},
+		},
+		"replaced user": {
+			oldUsers:        []interface{}{"alice", "bob"},
+			newUsers:        []interface{}{"alice", "carol"},
+			expectedAdded:   []string{"carol"},
+			expectedRemoved: []string{"bob"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			added, removed := groupUsersDelta(schema.NewSet(schema.HashString, tc.oldUsers), schema.NewSet(schema.HashString, tc.newUsers))
+			if !reflect.DeepEqual(added, tc.expectedAdded) {
+				t.Errorf("Expected added users %v, got %v", tc.expectedAdded, added)
+			}
+			if !reflect.DeepEqual(removed, tc.expectedRemoved) {
+				t.Errorf("Expected removed users %v, got %v", tc.expectedRemoved, removed)
+			}
+		})
+	}
+}
+
 func testAccCheckRedshiftGroupDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
 
@@ -276,3 +388,16 @@ resource "redshift_user" "group_test_user2" {
   name = "group_test_user2"
 }
 `
+
+func TestGroupRoleEquivalentSQL(t *testing.T) {
+	expected := `CREATE ROLE "analysts";
+GRANT ROLE "analysts" TO "alice";
+GRANT ROLE "analysts" TO "bob@example.com";`
+	if actual := groupRoleEquivalentSQL("analysts", []string{"bob@example.com", "alice"}); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+
+	if actual := groupRoleEquivalentSQL("empty", nil); actual != `CREATE ROLE "empty";` {
+		t.Errorf("Expected only the role to be created, got %q", actual)
+	}
+}
@@ -0,0 +1,155 @@
+package redshift
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	maintenanceTablesAttr           = "tables"
+	maintenanceVacuumAttr           = "vacuum"
+	maintenanceAnalyzeAttr          = "analyze"
+	maintenancePredicateColumnsAttr = "analyze_predicate_columns"
+	maintenanceTriggersAttr         = "triggers"
+)
+
+var maintenanceVacuumModes = []string{"FULL", "SORT ONLY", "DELETE ONLY", "REINDEX", "RECLUSTER"}
+
+func redshiftMaintenance() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Runs ` + "`VACUUM`" + ` and/or ` + "`ANALYZE`" + ` once when the resource is created, and again every time ` + "`triggers`" + ` or any other argument changes, like ` + "`terraform_data`" + `. It doesn't manage any object: destroying it does nothing. The statements can't run in a transaction block, so they're run one by one, and a failure leaves the tables processed before it as they are.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftMaintenanceCreate),
+		// Nothing is left to read or drop once the statements ran.
+		ReadContext:   schema.NoopContext,
+		DeleteContext: schema.NoopContext,
+		Schema: map[string]*schema.Schema{
+			maintenanceTablesAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The tables to process, qualified with their schema, e.g. `public.sales`. When it's empty, all the tables of the database the provider connects to are processed.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			maintenanceVacuumAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Runs `VACUUM` with this mode, one of `" + strings.Join(maintenanceVacuumModes, "`, `") + "`. `VACUUM` isn't run when it's not set.",
+				ValidateFunc: validation.StringInSlice(maintenanceVacuumModes, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+				AtLeastOneOf: []string{maintenanceVacuumAttr, maintenanceAnalyzeAttr},
+			},
+			maintenanceAnalyzeAttr: {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      false,
+				Description:  "Runs `ANALYZE` to update the statistics of the tables, after `VACUUM` when both are set.",
+				AtLeastOneOf: []string{maintenanceVacuumAttr, maintenanceAnalyzeAttr},
+			},
+			maintenancePredicateColumnsAttr: {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      false,
+				Description:  "Only analyzes the columns used as predicates in queries, and the distribution and sort keys, with `ANALYZE ... PREDICATE COLUMNS`.",
+				RequiredWith: []string{maintenanceAnalyzeAttr},
+			},
+			maintenanceTriggersAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values which run the statements again when they change, e.g. the time of the last load of the tables.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// maintenanceTableIdent quotes the parts of a table name qualified with its
+// schema.
+func maintenanceTableIdent(table string) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// maintenanceQueries returns the VACUUM statements of all the tables, followed
+// by their ANALYZE statements.
+func maintenanceQueries(d *schema.ResourceData) []string {
+	tables := []string{}
+	for _, table := range d.Get(maintenanceTablesAttr).([]interface{}) {
+		tables = append(tables, maintenanceTableIdent(table.(string)))
+	}
+	// An empty table name processes the whole database.
+	if len(tables) == 0 {
+		tables = []string{""}
+	}
+
+	queries := []string{}
+	if vacuum := d.Get(maintenanceVacuumAttr).(string); vacuum != "" {
+		for _, table := range tables {
+			queries = append(queries, strings.TrimSpace(fmt.Sprintf("VACUUM %s %s", strings.ToUpper(vacuum), table)))
+		}
+	}
+	if d.Get(maintenanceAnalyzeAttr).(bool) {
+		for _, table := range tables {
+			query := strings.TrimSpace(fmt.Sprintf("ANALYZE %s", table))
+			if d.Get(maintenancePredicateColumnsAttr).(bool) {
+				query += " PREDICATE COLUMNS"
+			}
+			queries = append(queries, query)
+		}
+	}
+
+	return queries
+}
+
+// generateMaintenanceID hashes the statements and the triggers, so that the ID
+// changes with every new run.
+func generateMaintenanceID(d *schema.ResourceData) (string, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"queries":  maintenanceQueries(d),
+		"triggers": d.Get(maintenanceTriggersAttr),
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(raw)), nil
+}
+
+func resourceRedshiftMaintenanceCreate(db *DBConnection, d *schema.ResourceData) error {
+	// VACUUM can't run in a transaction block.
+	for _, query := range maintenanceQueries(d) {
+		log.Printf("[DEBUG] running %s", query)
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("could not run %s: %w", query, err)
+		}
+	}
+
+	id, err := generateMaintenanceID(d)
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package redshift
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftMaintenance_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_maintenance"), "-", "_")
+	config := func(loadedAt string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name   = "events"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_maintenance" "events" {
+  tables                    = ["${redshift_schema.schema.name}.${redshift_table.table.name}"]
+  vacuum                    = "sort only"
+  analyze                   = true
+  analyze_predicate_columns = true
+  triggers = {
+    loaded_at = %[2]q
+  }
+}
+`, schemaName, loadedAt)
+	}
+
+	var firstID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config("2024-01-01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_maintenance.events", "vacuum", "SORT ONLY"),
+					func(s *terraform.State) error {
+						firstID = s.RootModule().Resources["redshift_maintenance.events"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("2024-01-02"),
+				Check: func(s *terraform.State) error {
+					if id := s.RootModule().Resources["redshift_maintenance.events"].Primary.ID; id == firstID {
+						return fmt.Errorf("Expected the statements to run again when the triggers change")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestMaintenanceQueries(t *testing.T) {
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		expected []string
+	}{
+		"vacuum and analyze tables": {
+			raw: map[string]interface{}{
+				maintenanceTablesAttr:           []interface{}{"public.sales", "events"},
+				maintenanceVacuumAttr:           "sort only",
+				maintenanceAnalyzeAttr:          true,
+				maintenancePredicateColumnsAttr: true,
+			},
+			expected: []string{
+				`VACUUM SORT ONLY "public"."sales"`,
+				`VACUUM SORT ONLY "events"`,
+				`ANALYZE "public"."sales" PREDICATE COLUMNS`,
+				`ANALYZE "events" PREDICATE COLUMNS`,
+			},
+		},
+		"vacuum database": {
+			raw: map[string]interface{}{
+				maintenanceVacuumAttr: "FULL",
+			},
+			expected: []string{"VACUUM FULL"},
+		},
+		"analyze database": {
+			raw: map[string]interface{}{
+				maintenanceAnalyzeAttr: true,
+			},
+			expected: []string{"ANALYZE"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftMaintenance().Schema, tt.raw)
+			if queries := maintenanceQueries(d); !reflect.DeepEqual(queries, tt.expected) {
+				t.Errorf("Expected queries %v, got %v", tt.expected, queries)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	materializedViewNameAttr           = "name"
+	materializedViewSchemaAttr         = "schema"
+	materializedViewQueryAttr          = "query"
+	materializedViewAutoRefreshAttr    = "auto_refresh"
+	materializedViewRefreshOnApplyAttr = "refresh_on_apply"
+	materializedViewIsStaleAttr        = "is_stale"
+)
+
+func redshiftMaterializedView() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a materialized view. A materialized view contains a precomputed result set, based on a SQL query over one or more base tables. Redshift can't redefine a materialized view in place, so changing its query recreates it.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftMaterializedViewCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftMaterializedViewRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftMaterializedViewUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftMaterializedViewDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftMaterializedViewExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			materializedViewNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the materialized view.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			materializedViewSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the schema the materialized view belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			materializedViewQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The `SELECT` statement defining the materialized view. Differences in whitespace, letter case and a trailing semicolon are ignored. Changing it recreates the materialized view.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return viewQueriesEqual(old, new)
+				},
+			},
+			materializedViewAutoRefreshAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the materialized view is refreshed automatically by Redshift.",
+			},
+			materializedViewRefreshOnApplyAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Runs `REFRESH MATERIALIZED VIEW` at the end of every apply creating or updating the materialized view.",
+			},
+			materializedViewIsStaleAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the materialized view is stale, i.e. doesn't reflect the latest changes of its base tables.",
+			},
+		},
+	}
+}
+
+func resourceRedshiftMaterializedViewExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT relname FROM pg_class WHERE oid = $1", d.Id()).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftMaterializedViewRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftMaterializedViewReadImpl(db, d)
+}
+
+func resourceRedshiftMaterializedViewReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var viewName, schemaName, autoRefresh, isStale string
+
+	err := db.QueryRow(`
+  SELECT cl.relname, nsp.nspname, mv.autorefresh, mv.is_stale
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+    JOIN stv_mv_info mv ON TRIM(mv.schema) = nsp.nspname AND TRIM(mv.name) = cl.relname
+  WHERE cl.oid = $1
+`, d.Id()).Scan(&viewName, &schemaName, &autoRefresh, &isStale)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift Materialized View (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Materialized View: %w", err)
+	}
+
+	d.Set(materializedViewNameAttr, viewName)
+	d.Set(materializedViewSchemaAttr, schemaName)
+	d.Set(materializedViewAutoRefreshAttr, strings.TrimSpace(autoRefresh) == "t")
+	d.Set(materializedViewIsStaleAttr, strings.TrimSpace(isStale) == "t")
+
+	return nil
+}
+
+func materializedViewIdent(d *schema.ResourceData) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(materializedViewSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(materializedViewNameAttr).(string)))
+}
+
+func autoRefreshToSQL(autoRefresh bool) string {
+	if autoRefresh {
+		return "YES"
+	}
+	return "NO"
+}
+
+func createMaterializedViewQuery(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"CREATE MATERIALIZED VIEW %s AUTO REFRESH %s AS %s",
+		materializedViewIdent(d),
+		autoRefreshToSQL(d.Get(materializedViewAutoRefreshAttr).(bool)),
+		normalizeViewQuery(d.Get(materializedViewQueryAttr).(string)),
+	)
+}
+
+func resourceRedshiftMaterializedViewCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(createMaterializedViewQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift materialized view: %w", createObjectError(err, "redshift_materialized_view"))
+	}
+
+	names, err := storedIdentifiers(tx, d.Get(materializedViewSchemaAttr).(string), d.Get(materializedViewNameAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	var viewOID string
+	query := `
+  SELECT cl.oid
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2
+`
+	if err := tx.QueryRow(query, names[0], names[1]).Scan(&viewOID); err != nil {
+		return fmt.Errorf("Could not get redshift materialized view oid: %w", err)
+	}
+
+	d.SetId(viewOID)
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	if err := refreshMaterializedViewOnApply(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftMaterializedViewReadImpl(db, d)
+}
+
+func resourceRedshiftMaterializedViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(fmt.Sprintf("DROP MATERIALIZED VIEW %s", materializedViewIdent(d))); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftMaterializedViewUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setMaterializedViewName(tx, d); err != nil {
+		return err
+	}
+
+	if err := setMaterializedViewAutoRefresh(tx, d); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	if err := refreshMaterializedViewOnApply(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftMaterializedViewReadImpl(db, d)
+}
+
+func setMaterializedViewName(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(materializedViewNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(materializedViewNameAttr)
+	sql := fmt.Sprintf(
+		"ALTER MATERIALIZED VIEW %s.%s RENAME TO %s",
+		pq.QuoteIdentifier(d.Get(materializedViewSchemaAttr).(string)),
+		pq.QuoteIdentifier(oldRaw.(string)),
+		pq.QuoteIdentifier(newRaw.(string)),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Materialized View NAME: %w", err)
+	}
+
+	return nil
+}
+
+func setMaterializedViewAutoRefresh(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(materializedViewAutoRefreshAttr) {
+		return nil
+	}
+
+	sql := fmt.Sprintf("ALTER MATERIALIZED VIEW %s AUTO REFRESH %s", materializedViewIdent(d), autoRefreshToSQL(d.Get(materializedViewAutoRefreshAttr).(bool)))
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Materialized View AUTO REFRESH: %w", err)
+	}
+
+	return nil
+}
+
+// refreshMaterializedViewOnApply is run outside of the apply transaction, as
+// refreshing some materialized views can't be run inside a transaction block.
+func refreshMaterializedViewOnApply(db *DBConnection, d *schema.ResourceData) error {
+	if !d.Get(materializedViewRefreshOnApplyAttr).(bool) {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", materializedViewIdent(d))); err != nil {
+		return fmt.Errorf("Error refreshing Materialized View: %w", err)
+	}
+
+	return nil
+}
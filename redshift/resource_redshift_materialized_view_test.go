@@ -0,0 +1,167 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftMaterializedView_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_mv_schema"), "-", "_")
+	viewName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_mv"), "-", "_")
+	viewNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_mv_updated"), "-", "_")
+	config := func(name string, autoRefresh bool) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name   = "source_table"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_materialized_view" "view" {
+  name             = %[2]q
+  schema           = redshift_schema.schema.name
+  auto_refresh     = %[3]t
+  refresh_on_apply = true
+  query            = "SELECT count(*) AS total FROM ${redshift_schema.schema.name}.${redshift_table.table.name}"
+}
+`, schemaName, name, autoRefresh)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftMaterializedViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(viewName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftMaterializedViewExists(schemaName, viewName),
+					resource.TestCheckResourceAttr("redshift_materialized_view.view", "name", viewName),
+					resource.TestCheckResourceAttr("redshift_materialized_view.view", "schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_materialized_view.view", "auto_refresh", "false"),
+					resource.TestCheckResourceAttr("redshift_materialized_view.view", "is_stale", "false"),
+				),
+			},
+			{
+				ResourceName:            "redshift_materialized_view.view",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{materializedViewQueryAttr, materializedViewRefreshOnApplyAttr},
+			},
+			{
+				Config: config(viewNameUpdated, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftMaterializedViewExists(schemaName, viewNameUpdated),
+					resource.TestCheckResourceAttr("redshift_materialized_view.view", "name", viewNameUpdated),
+					resource.TestCheckResourceAttr("redshift_materialized_view.view", "auto_refresh", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateMaterializedViewQuery(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		"auto refresh disabled": {
+			input: map[string]interface{}{
+				materializedViewNameAttr:   "mv",
+				materializedViewSchemaAttr: "analytics",
+				materializedViewQueryAttr:  "SELECT id\n  FROM analytics.t;\n",
+			},
+			expected: `CREATE MATERIALIZED VIEW "analytics"."mv" AUTO REFRESH NO AS SELECT id FROM analytics.t`,
+		},
+		"auto refresh enabled": {
+			input: map[string]interface{}{
+				materializedViewNameAttr:        "mv",
+				materializedViewSchemaAttr:      "analytics",
+				materializedViewQueryAttr:       "SELECT id FROM analytics.t",
+				materializedViewAutoRefreshAttr: true,
+			},
+			expected: `CREATE MATERIALIZED VIEW "analytics"."mv" AUTO REFRESH YES AS SELECT id FROM analytics.t`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftMaterializedView().Schema, tc.input)
+			if query := createMaterializedViewQuery(d); query != tc.expected {
+				t.Errorf("Expected query %q, got %q", tc.expected, query)
+			}
+		})
+	}
+}
+
+func testAccCheckRedshiftMaterializedViewDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_materialized_view" {
+			continue
+		}
+
+		exists, err := checkMaterializedViewExists(client, rs.Primary.Attributes[materializedViewSchemaAttr], rs.Primary.Attributes[materializedViewNameAttr])
+
+		if err != nil {
+			return fmt.Errorf("Error checking materialized view %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Materialized view still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftMaterializedViewExists(schemaName, viewName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkMaterializedViewExists(client, schemaName, viewName)
+		if err != nil {
+			return fmt.Errorf("Error checking materialized view %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Materialized view not found")
+		}
+
+		return nil
+	}
+}
+
+func checkMaterializedViewExists(client *Client, schemaName, viewName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	err = db.QueryRow("SELECT 1 FROM stv_mv_info WHERE TRIM(schema) = $1 AND TRIM(name) = $2", strings.ToLower(schemaName), strings.ToLower(viewName)).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about materialized view: %s", err)
+	}
+
+	return true, nil
+}
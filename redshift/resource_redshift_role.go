@@ -0,0 +1,465 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	roleNameAttr       = "name"
+	roleExternalIdAttr = "externalid"
+	roleRolesAttr      = "roles"
+
+	roleSystemPrivilegesAttr = "system_privileges"
+
+	systemRolePrefix = "sys:"
+)
+
+// roleSystemPrivileges are the system privileges which can be granted to roles.
+// See https://docs.aws.amazon.com/redshift/latest/dg/r_GRANT.html
+var roleSystemPrivileges = []string{
+	"ACCESS CATALOG",
+	"ACCESS SYSTEM TABLE",
+	"ALTER DATASHARE",
+	"ALTER DEFAULT PRIVILEGES",
+	"ALTER TABLE",
+	"ALTER USER",
+	"ANALYZE",
+	"CANCEL",
+	"CREATE DATASHARE",
+	"CREATE LIBRARY",
+	"CREATE MODEL",
+	"CREATE OR REPLACE EXTERNAL FUNCTION",
+	"CREATE OR REPLACE FUNCTION",
+	"CREATE OR REPLACE PROCEDURE",
+	"CREATE OR REPLACE VIEW",
+	"CREATE ROLE",
+	"CREATE SCHEMA",
+	"CREATE TABLE",
+	"CREATE USER",
+	"DROP DATASHARE",
+	"DROP FUNCTION",
+	"DROP LIBRARY",
+	"DROP MODEL",
+	"DROP PROCEDURE",
+	"DROP ROLE",
+	"DROP SCHEMA",
+	"DROP TABLE",
+	"DROP USER",
+	"DROP VIEW",
+	"EXPLAIN MASKING",
+	"EXPLAIN RLS",
+	"IGNORE RLS",
+	"TRUNCATE TABLE",
+	"VACUUM",
+}
+
+func redshiftRole() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Roles are named collections of privileges and other roles. Unlike groups, roles can be granted to other roles, which allows building a hierarchy of permissions. Role-based access control (RBAC) lets you grant a role to users and roles and manage its privileges in one place. System-defined roles (prefixed with ` + "`sys:`" + `) are managed by Amazon Redshift and can't be managed with this resource.
+`,
+		CreateContext: RedshiftResourceFunc(
+			RedshiftResourceRequireFeature(clusterFeatureRoles, "redshift_role", resourceRedshiftRoleCreate),
+		),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftRoleRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftRoleUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftRoleDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftRoleExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftRoleImport,
+		},
+		CustomizeDiff: customdiff.ForceNewIfChange(roleExternalIdAttr, func(_ context.Context, old, new, meta interface{}) bool {
+			// Redshift can change the external id of a role but can't remove it.
+			return old.(string) != "" && new.(string) == ""
+		}),
+
+		Schema: map[string]*schema.Schema{
+			roleNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role. Role names beginning with `sys:` are reserved for system-defined roles. The role name can't be `PUBLIC`.",
+				ValidateFunc: validation.All(
+					validation.StringDoesNotMatch(regexp.MustCompile("(?i)^"+systemRolePrefix), "Role names beginning with sys: are reserved for system-defined roles"),
+					validateNotPublic("Role"),
+				),
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			roleExternalIdAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The identifier of the role in an identity provider, used for roles federated from IAM Identity Center.",
+			},
+			roleRolesAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         hashIdentifier,
+				Description: "List of the role names granted to this role. Each granted role's privileges are inherited by this role.",
+			},
+			roleSystemPrivilegesAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(roleSystemPrivileges, true),
+					StateFunc: func(val interface{}) string {
+						return strings.ToUpper(val.(string))
+					},
+				},
+				Set: func(val interface{}) int {
+					return schema.HashString(strings.ToUpper(val.(string)))
+				},
+				Description: "The system privileges granted to this role, e.g. `CREATE USER` or `ACCESS SYSTEM TABLE`. One of: " + strings.Join(roleSystemPrivileges, ", ") + ".",
+			},
+		},
+	}
+}
+
+func isSystemRole(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), systemRolePrefix)
+}
+
+func resourceRedshiftRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	roleName := d.Id()
+	if isSystemRole(roleName) {
+		return nil, fmt.Errorf("Role %q is a system-defined role and can't be managed by terraform", roleName)
+	}
+
+	db, err := connectWithRetries(ctx, meta.(*Client))
+	if err != nil {
+		return nil, err
+	}
+
+	var roleId string
+	err = db.QueryRow("SELECT role_id FROM svv_roles WHERE lower(role_name) = lower($1)", roleName).Scan(&roleId)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, fmt.Errorf("Role %q does not exist", roleName)
+	case err != nil:
+		return nil, fmt.Errorf("Could not get redshift role id for '%s': %w", roleName, err)
+	}
+
+	d.SetId(roleId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftRoleExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT role_name FROM svv_roles WHERE role_id = $1", d.Id()).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftRoleReadImpl(db, d)
+}
+
+func resourceRedshiftRoleReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var (
+		roleName   string
+		externalId sql.NullString
+	)
+
+	err := db.QueryRow("SELECT role_name, external_id FROM svv_roles WHERE role_id = $1", d.Id()).Scan(&roleName, &externalId)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift Role (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Role: %w", err)
+	}
+
+	if isSystemRole(roleName) {
+		return fmt.Errorf("Role %q is a system-defined role and can't be managed by terraform", roleName)
+	}
+
+	rows, err := db.Query("SELECT granted_role_name FROM svv_role_grants WHERE role_id = $1", d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading Role grants: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var grantedRoleName string
+		if err := rows.Scan(&grantedRoleName); err != nil {
+			return err
+		}
+		roles = append(roles, grantedRoleName)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	systemPrivileges, err := readRoleSystemPrivileges(db, d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set(roleNameAttr, roleName)
+	d.Set(roleExternalIdAttr, externalId.String)
+	d.Set(roleRolesAttr, roles)
+	d.Set(roleSystemPrivilegesAttr, systemPrivileges)
+
+	return nil
+}
+
+func resourceRedshiftRoleCreate(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(roleNameAttr).(string)
+
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	sql := fmt.Sprintf("CREATE ROLE %s", pq.QuoteIdentifier(roleName))
+	if v, ok := d.GetOk(roleExternalIdAttr); ok {
+		sql = fmt.Sprintf("%s EXTERNALID %s", sql, pq.QuoteIdentifier(v.(string)))
+	}
+
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Could not create redshift role: %w", createObjectError(err, "redshift_role"))
+	}
+
+	for _, grantedRole := range d.Get(roleRolesAttr).(*schema.Set).List() {
+		if err := grantRoleToRole(tx, grantedRole.(string), roleName); err != nil {
+			return err
+		}
+	}
+
+	if err := grantRoleSystemPrivileges(tx, roleName, d.Get(roleSystemPrivilegesAttr).(*schema.Set)); err != nil {
+		return err
+	}
+
+	names, err := storedIdentifiers(tx, roleName)
+	if err != nil {
+		return err
+	}
+
+	var roleId string
+	if err := tx.QueryRow("SELECT role_id FROM svv_roles WHERE role_name = $1", names[0]).Scan(&roleId); err != nil {
+		return fmt.Errorf("Could not get redshift role id for '%s': %w", roleName, err)
+	}
+
+	d.SetId(roleId)
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftRoleReadImpl(db, d)
+}
+
+func resourceRedshiftRoleDelete(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get(roleNameAttr).(string)
+
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	// FORCE revokes the role from all users and roles it was granted to.
+	if _, err := tx.Exec(fmt.Sprintf("DROP ROLE %s FORCE", pq.QuoteIdentifier(roleName))); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftRoleUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setRoleName(tx, d); err != nil {
+		return err
+	}
+
+	if err := setRoleExternalId(tx, d); err != nil {
+		return err
+	}
+
+	if err := setRoleRoles(tx, d); err != nil {
+		return err
+	}
+
+	if err := setRoleSystemPrivileges(tx, d); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftRoleReadImpl(db, d)
+}
+
+func setRoleName(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(roleNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(roleNameAttr)
+	oldValue := oldRaw.(string)
+	newValue := newRaw.(string)
+
+	if newValue == "" {
+		return fmt.Errorf("Error setting role name to an empty string")
+	}
+
+	sql := fmt.Sprintf("ALTER ROLE %s RENAME TO %s", pq.QuoteIdentifier(oldValue), pq.QuoteIdentifier(newValue))
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Role NAME: %w", err)
+	}
+
+	return nil
+}
+
+func setRoleExternalId(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(roleExternalIdAttr) {
+		return nil
+	}
+
+	roleName := d.Get(roleNameAttr).(string)
+	externalId := d.Get(roleExternalIdAttr).(string)
+
+	sql := fmt.Sprintf("ALTER ROLE %s EXTERNALID TO %s", pq.QuoteIdentifier(roleName), pq.QuoteIdentifier(externalId))
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Role EXTERNALID: %w", err)
+	}
+
+	return nil
+}
+
+func setRoleRoles(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(roleRolesAttr) {
+		return nil
+	}
+
+	roleName := d.Get(roleNameAttr).(string)
+	oldRolesSet, newRolesSet := d.GetChange(roleRolesAttr)
+	removedRoles := oldRolesSet.(*schema.Set).Difference(newRolesSet.(*schema.Set))
+	addedRoles := newRolesSet.(*schema.Set).Difference(oldRolesSet.(*schema.Set))
+
+	for _, grantedRole := range removedRoles.List() {
+		sql := fmt.Sprintf("REVOKE ROLE %s FROM ROLE %s", pq.QuoteIdentifier(grantedRole.(string)), pq.QuoteIdentifier(roleName))
+		if _, err := tx.Exec(sql); err != nil {
+			return fmt.Errorf("Error revoking role %s from role %s: %w", grantedRole, roleName, err)
+		}
+	}
+
+	for _, grantedRole := range addedRoles.List() {
+		if err := grantRoleToRole(tx, grantedRole.(string), roleName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func grantRoleToRole(tx *DBTransaction, grantedRole string, roleName string) error {
+	sql := fmt.Sprintf("GRANT ROLE %s TO ROLE %s", pq.QuoteIdentifier(grantedRole), pq.QuoteIdentifier(roleName))
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error granting role %s to role %s: %w", grantedRole, roleName, err)
+	}
+
+	return nil
+}
+
+func readRoleSystemPrivileges(db *DBConnection, roleID string) ([]string, error) {
+	rows, err := db.Query("SELECT TRIM(system_privilege) FROM svv_system_privileges WHERE identity_type = 'role' AND identity_id = $1", roleID)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading Role system privileges: %w", err)
+	}
+	defer rows.Close()
+
+	privileges := []string{}
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, strings.ToUpper(privilege))
+	}
+
+	return privileges, rows.Err()
+}
+
+func setRoleSystemPrivileges(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(roleSystemPrivilegesAttr) {
+		return nil
+	}
+
+	roleName := d.Get(roleNameAttr).(string)
+	oldRaw, newRaw := d.GetChange(roleSystemPrivilegesAttr)
+	removed := oldRaw.(*schema.Set).Difference(newRaw.(*schema.Set))
+	added := newRaw.(*schema.Set).Difference(oldRaw.(*schema.Set))
+
+	if removed.Len() > 0 {
+		sql := fmt.Sprintf("REVOKE %s FROM ROLE %s", systemPrivilegesList(removed), pq.QuoteIdentifier(roleName))
+		if _, err := tx.Exec(sql); err != nil {
+			return fmt.Errorf("Error revoking system privileges from role %s: %w", roleName, err)
+		}
+	}
+
+	return grantRoleSystemPrivileges(tx, roleName, added)
+}
+
+func grantRoleSystemPrivileges(tx *DBTransaction, roleName string, privileges *schema.Set) error {
+	if privileges.Len() == 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf("GRANT %s TO ROLE %s", systemPrivilegesList(privileges), pq.QuoteIdentifier(roleName))
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error granting system privileges to role %s: %w", roleName, err)
+	}
+
+	return nil
+}
+
+// systemPrivilegesList returns the privileges as a comma separated list in a
+// stable order. The privileges are validated against roleSystemPrivileges, so
+// they don't need to be quoted.
+func systemPrivilegesList(privileges *schema.Set) string {
+	list := make([]string, 0, privileges.Len())
+	for _, privilege := range privileges.List() {
+		list = append(list, strings.ToUpper(privilege.(string)))
+	}
+	sort.Strings(list)
+
+	return strings.Join(list, ", ")
+}
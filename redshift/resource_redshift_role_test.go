@@ -0,0 +1,285 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftRole_Basic(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("TF_acc_role"), "-", "_")
+	childRoleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_child_role"), "-", "_")
+
+	config := fmt.Sprintf(`
+resource "redshift_role" "child" {
+  name = %[2]q
+}
+
+resource "redshift_role" "role" {
+  name  = %[1]q
+  roles = [redshift_role.child.name]
+}
+`, roleName, childRoleName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					testAccCheckRedshiftRoleExists(childRoleName),
+					resource.TestCheckResourceAttr("redshift_role.role", "name", strings.ToLower(roleName)),
+					resource.TestCheckResourceAttr("redshift_role.role", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "roles.*", childRoleName),
+					resource.TestCheckResourceAttr("redshift_role.child", "roles.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "redshift_role.role",
+				ImportState:       true,
+				ImportStateId:     roleName,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "redshift_role.child",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRole_Update(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_role"), "-", "_")
+	roleNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_role_updated"), "-", "_")
+	childRoleNames := []string{
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_child_role"), "-", "_"),
+		strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_child_role"), "-", "_"),
+	}
+
+	configCreate := fmt.Sprintf(`
+resource "redshift_role" "child1" {
+  name = %[2]q
+}
+
+resource "redshift_role" "child2" {
+  name = %[3]q
+}
+
+resource "redshift_role" "update_role" {
+  name  = %[1]q
+  roles = [redshift_role.child1.name]
+}
+`, roleName, childRoleNames[0], childRoleNames[1])
+
+	configUpdate := fmt.Sprintf(`
+resource "redshift_role" "child1" {
+  name = %[2]q
+}
+
+resource "redshift_role" "child2" {
+  name = %[3]q
+}
+
+resource "redshift_role" "update_role" {
+  name       = %[1]q
+  externalid = "tf_acc_external_id"
+  roles      = [redshift_role.child2.name]
+}
+`, roleNameUpdated, childRoleNames[0], childRoleNames[1])
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "name", roleName),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "externalid", ""),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.update_role", "roles.*", childRoleNames[0]),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleNameUpdated),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "name", roleNameUpdated),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "externalid", "tf_acc_external_id"),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.update_role", "roles.*", childRoleNames[1]),
+				),
+			},
+			// apply the first one again to check if all parameters roll back properly
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftRoleExists(roleName),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "name", roleName),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "externalid", ""),
+					resource.TestCheckResourceAttr("redshift_role.update_role", "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.update_role", "roles.*", childRoleNames[0]),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRole_SystemPrivileges(t *testing.T) {
+	roleName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_role_sysprivs"), "-", "_")
+	config := func(privileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_role" "role" {
+  name              = %[1]q
+  system_privileges = [%[2]s]
+}
+`, roleName, privileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`"create user", "ACCESS SYSTEM TABLE"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role.role", "system_privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "CREATE USER"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "ACCESS SYSTEM TABLE"),
+				),
+			},
+			{
+				Config: config(`"ACCESS SYSTEM TABLE", "DROP USER"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_role.role", "system_privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "ACCESS SYSTEM TABLE"),
+					resource.TestCheckTypeSetElemAttr("redshift_role.role", "system_privileges.*", "DROP USER"),
+				),
+			},
+			{
+				Config: config(""),
+				Check:  resource.TestCheckResourceAttr("redshift_role.role", "system_privileges.#", "0"),
+			},
+			{
+				Config:      config(`"CREATE UNICORN"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected system_privileges\.\d+ to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftRole_SystemRole(t *testing.T) {
+	config := `
+resource "redshift_role" "system" {
+  name = "sys:operator"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Role names beginning with sys: are reserved for system-defined roles"),
+			},
+		},
+	})
+}
+
+func TestIsSystemRole(t *testing.T) {
+	tests := map[string]bool{
+		"sys:operator":  true,
+		"SYS:DBA":       true,
+		"analyst":       false,
+		"my_sys:role":   false,
+		"system_admins": false,
+	}
+
+	for name, expected := range tests {
+		if result := isSystemRole(name); result != expected {
+			t.Errorf("isSystemRole(%q) = %t, expected %t", name, result, expected)
+		}
+	}
+}
+
+func testAccCheckRedshiftRoleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_role" {
+			continue
+		}
+
+		exists, err := checkRoleExists(client, rs.Primary.Attributes[roleNameAttr])
+
+		if err != nil {
+			return fmt.Errorf("Error checking role %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Role still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftRoleExists(role string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkRoleExists(client, role)
+		if err != nil {
+			return fmt.Errorf("Error checking role %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Role not found")
+		}
+
+		return nil
+	}
+}
+
+func checkRoleExists(client *Client, role string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	err = db.QueryRow("SELECT 1 FROM svv_roles WHERE role_name=$1", strings.ToLower(role)).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about role: %s", err)
+	}
+
+	return true, nil
+}
+
+func TestSystemPrivilegesList(t *testing.T) {
+	privileges := schema.NewSet(schema.HashString, []interface{}{"drop user", "CREATE USER", "Access System Table"})
+
+	expected := "ACCESS SYSTEM TABLE, CREATE USER, DROP USER"
+	if result := systemPrivilegesList(privileges); result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
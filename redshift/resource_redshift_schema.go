@@ -1,7 +1,9 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -17,15 +19,27 @@ const (
 	schemaNameAttr            = "name"
 	schemaOwnerAttr           = "owner"
 	schemaQuotaAttr           = "quota"
+	schemaQuotaUnitAttr       = "quota_unit"
 	schemaCascadeOnDeleteAttr = "cascade_on_delete"
+	schemaIfNotExistsAttr     = "if_not_exists"
 	schemaExternalSchemaAttr  = "external_schema"
 	dataCatalogAttr           = "external_schema.0.data_catalog_source.0"
 	hiveMetastoreAttr         = "external_schema.0.hive_metastore_source.0"
 	rdsPostgresAttr           = "external_schema.0.rds_postgres_source.0"
 	rdsMysqlAttr              = "external_schema.0.rds_mysql_source.0"
 	redshiftAttr              = "external_schema.0.redshift_source.0"
+
+	defaultSchemaQuotaUnit = "GB"
 )
 
+// schemaQuotaUnitsInMB are the quota units supported by Redshift, in MB,
+// which is the unit the quota is read with and stored in the state.
+var schemaQuotaUnitsInMB = map[string]int{
+	"MB": 1,
+	"GB": 1024,
+	"TB": 1024 * 1024,
+}
+
 func redshiftSchema() *schema.Resource {
 	return &schema.Resource{
 		Description: `
@@ -41,36 +55,57 @@ A database contains one or more named schemas. Each schema in a database contain
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: forceNewIfListSizeChanged(schemaExternalSchemaAttr),
+		CustomizeDiff: customdiff.All(
+			forceNewIfListSizeChanged(schemaExternalSchemaAttr),
+			customizeExternalSchemaIAMRole(supportsAlterExternalSchema),
+			customizeGeneratedSQL(generateSchemaSQL),
+		),
 		Schema: map[string]*schema.Schema{
+			generatedSQLAttr: generatedSQLSchema(),
 			schemaNameAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Name of the schema. The schema name can't be `PUBLIC`.",
-				ValidateFunc: validation.StringNotInSlice([]string{
-					"public",
-				}, true),
-				StateFunc: func(val interface{}) string {
-					return strings.ToLower(val.(string))
-				},
+				Description: "Name of the schema. The schema name can't be `PUBLIC`: the `public` schema exists in every database, so it isn't created or dropped by this resource. Its privileges can still be managed with `redshift_grant` or `redshift_schema_grants`.",
+				ValidateFunc: validation.StringDoesNotMatch(
+					publicNameRegexp,
+					"The public schema exists in every database and can't be managed with redshift_schema, manage its privileges with redshift_grant or redshift_schema_grants instead",
+				),
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 			schemaOwnerAttr: {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
-				Description: "Name of the schema owner.",
+				Description: "Name of the schema owner. Changing it transfers the ownership of the schema with `ALTER SCHEMA ... OWNER TO`.",
 				StateFunc: func(val interface{}) string {
 					return val.(string)
 				},
+				DiffSuppressFunc: ownerDiffSuppress,
 			},
 			schemaQuotaAttr: {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      0,
-				Description:  "The maximum amount of disk space that the specified schema can use. GB is the default unit of measurement.",
+				Description:  "The maximum amount of disk space that the specified schema can use, expressed in `quota_unit`. `0` means the quota is unlimited. The state holds the quota in MB.",
 				ValidateFunc: validation.IntAtLeast(0),
-				StateFunc: func(val interface{}) string {
-					return fmt.Sprintf("%d", val.(int)*1024)
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					newQuota, err := strconv.Atoi(new)
+					if err != nil {
+						return false
+					}
+					return old == strconv.Itoa(newQuota*schemaQuotaUnitInMB(d))
+				},
+				ConflictsWith: []string{
+					schemaExternalSchemaAttr,
+				},
+			},
+			schemaQuotaUnitAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The unit of measurement of `quota`. One of `MB`, `GB` or `TB`. Defaults to `GB`.",
+				ValidateFunc: validation.StringInSlice([]string{"MB", "GB", "TB"}, false),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == new || (old == "" && new == defaultSchemaQuotaUnit) || (old == defaultSchemaQuotaUnit && new == "")
 				},
 				ConflictsWith: []string{
 					schemaExternalSchemaAttr,
@@ -79,11 +114,16 @@ A database contains one or more named schemas. Each schema in a database contain
 			schemaCascadeOnDeleteAttr: {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Indicates to automatically drop all objects in the schema. The default action is TO NOT drop a schema if it contains any objects.",
+				Description: "Indicates to automatically drop all objects in the schema. The default action is TO NOT drop a schema if it contains any objects, in which case deleting it fails.",
 				ConflictsWith: []string{
 					schemaExternalSchemaAttr,
 				},
 			},
+			schemaIfNotExistsAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Creates the schema with `CREATE SCHEMA IF NOT EXISTS`, adopting an existing schema with the same name into the state instead of failing. Terraform then manages the existing schema as if it had created it: the attributes which differ from the configuration are changed by the next apply, and destroying the resource drops the schema. It's only used when the schema is created.",
+			},
 			schemaExternalSchemaAttr: {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -144,10 +184,12 @@ A database contains one or more named schemas. Each schema in a database contain
 
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
-	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
+	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles
+
+  Changing the roles alters the external schema in place with ALTER EXTERNAL SCHEMA when the cluster supports it, and recreates the schema otherwise.`,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: iamRoleArnValidate,
 										},
 									},
 									"catalog_role_arns": {
@@ -164,7 +206,8 @@ A database contains one or more named schemas. Each schema in a database contain
 	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
 										ForceNew: true,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: iamRoleArnValidate,
 										},
 									},
 									"create_external_database_if_not_exists": {
@@ -232,10 +275,12 @@ A database contains one or more named schemas. Each schema in a database contain
 
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
-	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
+	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles
+
+  Changing the roles alters the external schema in place with ALTER EXTERNAL SCHEMA when the cluster supports it, and recreates the schema otherwise.`,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: iamRoleArnValidate,
 										},
 									},
 								},
@@ -292,10 +337,12 @@ A database contains one or more named schemas. Each schema in a database contain
 
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
-	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
+	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles
+
+  Changing the roles alters the external schema in place with ALTER EXTERNAL SCHEMA when the cluster supports it, and recreates the schema otherwise.`,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: iamRoleArnValidate,
 										},
 									},
 									"secret_arn": {
@@ -353,10 +400,12 @@ A database contains one or more named schemas. Each schema in a database contain
 
   To chain roles, you establish a trust relationship between the roles. A role that assumes another role must have a permissions policy that allows it to assume the specified role.
 	In turn, the role that passes permissions must have a trust policy that allows it to pass its permissions to another role.
-	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles`,
-										ForceNew: true,
+	For more information, see https://docs.aws.amazon.com/redshift/latest/mgmt/authorizing-redshift-service.html#authorizing-redshift-service-chaining-roles
+
+  Changing the roles alters the external schema in place with ALTER EXTERNAL SCHEMA when the cluster supports it, and recreates the schema otherwise.`,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: iamRoleArnValidate,
 										},
 									},
 									"secret_arn": {
@@ -581,7 +630,7 @@ func resourceRedshiftSchemaReadExternal(db *DBConnection, d *schema.ResourceData
 }
 
 func resourceRedshiftSchemaDelete(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -595,6 +644,10 @@ func resourceRedshiftSchemaDelete(db *DBConnection, d *schema.ResourceData) erro
 
 	query := fmt.Sprintf("DROP SCHEMA %s %s", pq.QuoteIdentifier(schemaName), cascade_or_restrict)
 	if _, err := tx.Exec(query); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && string(pqErr.Code) == pqErrorCodeDependentObjectsStillExist {
+			return fmt.Errorf("Schema %s still contains objects, drop them first or set %s to true to drop them with the schema (DROP SCHEMA ... CASCADE): %w", schemaName, schemaCascadeOnDeleteAttr, err)
+		}
 		return err
 	}
 
@@ -602,7 +655,7 @@ func resourceRedshiftSchemaDelete(db *DBConnection, d *schema.ResourceData) erro
 }
 
 func resourceRedshiftSchemaCreate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
@@ -624,29 +677,40 @@ func resourceRedshiftSchemaCreate(db *DBConnection, d *schema.ResourceData) erro
 	return resourceRedshiftSchemaReadImpl(db, d)
 }
 
-func resourceRedshiftSchemaCreateInternal(tx *sql.Tx, d *schema.ResourceData) error {
+func schemaIfNotExistsSQL(d resourceValues) string {
+	if d.Get(schemaIfNotExistsAttr).(bool) {
+		return "IF NOT EXISTS "
+	}
+	return ""
+}
+
+func createInternalSchemaQuery(d resourceValues) string {
 	schemaName := d.Get(schemaNameAttr).(string)
-	schemaQuota := d.Get(schemaQuotaAttr).(int)
 	createOpts := []string{}
 
 	if v, ok := d.GetOk(schemaOwnerAttr); ok {
 		createOpts = append(createOpts, fmt.Sprintf("AUTHORIZATION %s", pq.QuoteIdentifier(v.(string))))
 	}
 
-	quotaValue := "QUOTA UNLIMITED"
-	if schemaQuota > 0 {
-		quotaValue = fmt.Sprintf("QUOTA %d GB", schemaQuota)
-	}
-	createOpts = append(createOpts, quotaValue)
+	createOpts = append(createOpts, fmt.Sprintf("QUOTA %s", schemaQuotaToSQL(d)))
 
-	query := fmt.Sprintf("CREATE SCHEMA %s %s", pq.QuoteIdentifier(schemaName), strings.Join(createOpts, " "))
+	return fmt.Sprintf("CREATE SCHEMA %s%s %s", schemaIfNotExistsSQL(d), pq.QuoteIdentifier(schemaName), strings.Join(createOpts, " "))
+}
 
-	if _, err := tx.Exec(query); err != nil {
+func resourceRedshiftSchemaCreateInternal(tx *DBTransaction, d *schema.ResourceData) error {
+	schemaName := d.Get(schemaNameAttr).(string)
+
+	if _, err := tx.Exec(createInternalSchemaQuery(d)); err != nil {
+		return createObjectError(err, "redshift_schema")
+	}
+
+	names, err := storedIdentifiers(tx, schemaName)
+	if err != nil {
 		return err
 	}
 
 	var schemaOID string
-	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", strings.ToLower(schemaName)).Scan(&schemaOID); err != nil {
+	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", names[0]).Scan(&schemaOID); err != nil {
 		return err
 	}
 
@@ -655,9 +719,9 @@ func resourceRedshiftSchemaCreateInternal(tx *sql.Tx, d *schema.ResourceData) er
 	return nil
 }
 
-func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) error {
+func createExternalSchemaQuery(d resourceValues) (string, error) {
 	schemaName := d.Get(schemaNameAttr).(string)
-	query := fmt.Sprintf("CREATE EXTERNAL SCHEMA %s", pq.QuoteIdentifier(schemaName))
+	query := fmt.Sprintf("CREATE EXTERNAL SCHEMA %s%s", schemaIfNotExistsSQL(d), pq.QuoteIdentifier(schemaName))
 	sourceDbName := d.Get(fmt.Sprintf("%s.0.%s", schemaExternalSchemaAttr, "database_name")).(string)
 	var configQuery string
 	if _, isDataCatalog := d.GetOk(dataCatalogAttr); isDataCatalog {
@@ -676,26 +740,39 @@ func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) er
 		// redshift source
 		configQuery = getRedshiftConfigQueryPart(d, sourceDbName)
 	} else {
-		return fmt.Errorf("Can't create external schema. No source configuration found.")
+		return "", fmt.Errorf("Can't create external schema. No source configuration found.")
 	}
 
-	query = fmt.Sprintf("%s %s", query, configQuery)
+	return fmt.Sprintf("%s %s", query, configQuery), nil
+}
+
+func resourceRedshiftSchemaCreateExternal(tx *DBTransaction, d *schema.ResourceData) error {
+	schemaName := d.Get(schemaNameAttr).(string)
+	query, err := createExternalSchemaQuery(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[DEBUG] creating external schema: %s\n", query)
 	if _, err := tx.Exec(query); err != nil {
-		return err
+		return createObjectError(err, "redshift_schema")
 	}
 
 	if v, ok := d.GetOk(schemaOwnerAttr); ok {
-		query = fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(v.(string)))
+		query = schemaOwnerQuery(schemaName, v.(string))
 		log.Printf("[DEBUG] setting schema owner: %s\n", query)
 		if _, err := tx.Exec(query); err != nil {
 			return err
 		}
 	}
 
+	names, err := storedIdentifiers(tx, schemaName)
+	if err != nil {
+		return err
+	}
+
 	var schemaOID string
-	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", strings.ToLower(schemaName)).Scan(&schemaOID); err != nil {
+	if err := tx.QueryRow("SELECT oid FROM pg_namespace WHERE nspname = $1", names[0]).Scan(&schemaOID); err != nil {
 		return err
 	}
 
@@ -704,7 +781,7 @@ func resourceRedshiftSchemaCreateExternal(tx *sql.Tx, d *schema.ResourceData) er
 	return nil
 }
 
-func getDataCatalogConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getDataCatalogConfigQueryPart(d resourceValues, sourceDbName string) string {
 	query := fmt.Sprintf("FROM DATA CATALOG DATABASE '%s'", pqQuoteLiteral(sourceDbName))
 	if region, hasRegion := d.GetOk(fmt.Sprintf("%s.%s", dataCatalogAttr, "region")); hasRegion {
 		query = fmt.Sprintf("%s REGION '%s'", query, pqQuoteLiteral(region.(string)))
@@ -730,7 +807,7 @@ func getDataCatalogConfigQueryPart(d *schema.ResourceData, sourceDbName string)
 	return query
 }
 
-func getHiveMetastoreConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getHiveMetastoreConfigQueryPart(d resourceValues, sourceDbName string) string {
 	query := fmt.Sprintf("FROM HIVE METASTORE DATABASE '%s'", pqQuoteLiteral(sourceDbName))
 	hostName := d.Get(fmt.Sprintf("%s.%s", hiveMetastoreAttr, "hostname")).(string)
 	query = fmt.Sprintf("%s URI '%s'", query, pqQuoteLiteral(hostName))
@@ -746,7 +823,7 @@ func getHiveMetastoreConfigQueryPart(d *schema.ResourceData, sourceDbName string
 	return query
 }
 
-func getRdsPostgresConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getRdsPostgresConfigQueryPart(d resourceValues, sourceDbName string) string {
 	query := fmt.Sprintf("FROM POSTGRES DATABASE '%s'", pqQuoteLiteral(sourceDbName))
 	if sourceSchema, sourceSchemaIsSet := d.GetOk(fmt.Sprintf("%s.%s", rdsPostgresAttr, "schema")); sourceSchemaIsSet {
 		query = fmt.Sprintf("%s SCHEMA '%s'", query, pqQuoteLiteral(sourceSchema.(string)))
@@ -767,7 +844,7 @@ func getRdsPostgresConfigQueryPart(d *schema.ResourceData, sourceDbName string)
 	return query
 }
 
-func getRdsMysqlConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getRdsMysqlConfigQueryPart(d resourceValues, sourceDbName string) string {
 	query := fmt.Sprintf("FROM MYSQL DATABASE '%s'", pqQuoteLiteral(sourceDbName))
 	hostName := d.Get(fmt.Sprintf("%s.%s", rdsMysqlAttr, "hostname")).(string)
 	query = fmt.Sprintf("%s URI '%s'", query, pqQuoteLiteral(hostName))
@@ -785,7 +862,7 @@ func getRdsMysqlConfigQueryPart(d *schema.ResourceData, sourceDbName string) str
 	return query
 }
 
-func getRedshiftConfigQueryPart(d *schema.ResourceData, sourceDbName string) string {
+func getRedshiftConfigQueryPart(d resourceValues, sourceDbName string) string {
 	query := fmt.Sprintf("FROM REDSHIFT DATABASE '%s'", pqQuoteLiteral(sourceDbName))
 	if sourceSchema, sourceSchemaIsSet := d.GetOk(fmt.Sprintf("%s.%s", redshiftAttr, "schema")); sourceSchemaIsSet {
 		query = fmt.Sprintf("%s SCHEMA '%s'", query, pqQuoteLiteral(sourceSchema.(string)))
@@ -794,32 +871,152 @@ func getRedshiftConfigQueryPart(d *schema.ResourceData, sourceDbName string) str
 }
 
 func resourceRedshiftSchemaUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
+	if d.HasChange(schemaOwnerAttr) {
+		if err := checkOwnerExists(tx, d.Get(schemaOwnerAttr).(string)); err != nil {
+			return err
+		}
+	}
+
+	if err := updateSchema(tx, d); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftSchemaReadImpl(db, d)
+}
+
+// updateSchema runs the statements applying the changed attributes, in order.
+func updateSchema(tx sqlExecutor, d resourceValues) error {
 	if err := setSchemaName(tx, d); err != nil {
 		return err
 	}
 
-	if err := setSchemaOwner(tx, db, d); err != nil {
+	if err := setSchemaOwner(tx, d); err != nil {
 		return err
 	}
 
-	if err := setSchemaQuota(tx, d); err != nil {
+	if err := setExternalSchemaIAMRole(tx, d); err != nil {
 		return err
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("could not commit transaction: %w", err)
+	return setSchemaQuota(tx, d)
+}
+
+// externalSchemaIAMRoleSources are the sources of external schemas
+// authenticating with IAM roles.
+var externalSchemaIAMRoleSources = []string{dataCatalogAttr, hiveMetastoreAttr, rdsPostgresAttr, rdsMysqlAttr}
+
+// changedExternalSchemaIAMRoleKey returns the key of the IAM roles of the
+// source of an external schema when they change, and an empty string
+// otherwise. A source that is replaced by another one recreates the schema.
+func changedExternalSchemaIAMRoleKey(d resourceValues) string {
+	for _, source := range externalSchemaIAMRoleSources {
+		key := fmt.Sprintf("%s.%s", source, "iam_role_arns")
+		if _, ok := d.GetOk(source); ok && d.HasChange(key) {
+			return key
+		}
 	}
 
-	return resourceRedshiftSchemaReadImpl(db, d)
+	return ""
+}
+
+// customizeExternalSchemaIAMRole recreates an external schema whose IAM roles
+// change when the cluster can't alter it.
+func customizeExternalSchemaIAMRole(supportsAlter func(meta interface{}) (bool, error)) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		key := changedExternalSchemaIAMRoleKey(d)
+		if d.Id() == "" || key == "" {
+			return nil
+		}
+
+		supported, err := supportsAlter(meta)
+		if err != nil {
+			return err
+		}
+		if !supported {
+			return d.ForceNew(key)
+		}
+
+		return nil
+	}
+}
+
+// supportsAlterExternalSchema reports whether the cluster the provider
+// connects to supports ALTER EXTERNAL SCHEMA. It doesn't without a client to
+// check it with.
+func supportsAlterExternalSchema(meta interface{}) (bool, error) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return false, nil
+	}
+
+	db, err := client.Connect()
+	if err != nil {
+		return false, fmt.Errorf("could not connect to check whether the cluster supports %s: %w", clusterFeatureAlterExternalSchema.name, err)
+	}
+
+	return db.supports(clusterFeatureAlterExternalSchema)
+}
+
+func setExternalSchemaIAMRole(tx sqlExecutor, d resourceValues) error {
+	key := changedExternalSchemaIAMRoleKey(d)
+	if key == "" {
+		return nil
+	}
+
+	if _, err := tx.Exec(externalSchemaIAMRoleQuery(d, key)); err != nil {
+		return fmt.Errorf("Error updating external schema IAM_ROLE: %w", err)
+	}
+
+	return nil
+}
+
+func externalSchemaIAMRoleQuery(d resourceValues, key string) string {
+	iamRoleArns := []string{}
+	for _, arn := range d.Get(key).([]interface{}) {
+		iamRoleArns = append(iamRoleArns, arn.(string))
+	}
+
+	return fmt.Sprintf("ALTER EXTERNAL SCHEMA %s IAM_ROLE '%s'", pq.QuoteIdentifier(d.Get(schemaNameAttr).(string)), pqQuoteLiteral(strings.Join(iamRoleArns, ",")))
+}
+
+// generateSchemaSQL records the statements creating or updating the schema.
+func generateSchemaSQL(tx sqlExecutor, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" {
+		return updateSchema(tx, d)
+	}
+
+	if _, isExternal := d.GetOk(fmt.Sprintf("%s.0.%s", schemaExternalSchemaAttr, "database_name")); !isExternal {
+		_, err := tx.Exec(createInternalSchemaQuery(d))
+		return err
+	}
+
+	query, err := createExternalSchemaQuery(d)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+	if v, ok := d.GetOk(schemaOwnerAttr); ok {
+		if _, err := tx.Exec(schemaOwnerQuery(d.Get(schemaNameAttr).(string), v.(string))); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func setSchemaName(tx *sql.Tx, d *schema.ResourceData) error {
+func setSchemaName(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(schemaNameAttr) {
 		return nil
 	}
@@ -840,7 +1037,7 @@ func setSchemaName(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setSchemaOwner(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error {
+func setSchemaOwner(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(schemaOwnerAttr) {
 		return nil
 	}
@@ -848,23 +1045,48 @@ func setSchemaOwner(tx *sql.Tx, db *DBConnection, d *schema.ResourceData) error
 	schemaName := d.Get(schemaNameAttr).(string)
 	schemaOwner := d.Get(schemaOwnerAttr).(string)
 
-	_, err := tx.Exec(fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(schemaOwner)))
-	return err
+	if _, err := tx.Exec(schemaOwnerQuery(schemaName, schemaOwner)); err != nil {
+		return fmt.Errorf("Error updating schema OWNER: %w", err)
+	}
+
+	return nil
 }
 
-func setSchemaQuota(tx *sql.Tx, d *schema.ResourceData) error {
-	if !d.HasChange(schemaQuotaAttr) {
+func schemaOwnerQuery(schemaName, owner string) string {
+	return fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(owner))
+}
+
+func setSchemaQuota(tx sqlExecutor, d resourceValues) error {
+	if !d.HasChanges(schemaQuotaAttr, schemaQuotaUnitAttr) {
 		return nil
 	}
 
 	schemaName := d.Get(schemaNameAttr).(string)
-	schemaQuota := d.Get(schemaQuotaAttr).(int)
 
-	quotaValue := "UNLIMITED"
-	if schemaQuota > 0 {
-		quotaValue = fmt.Sprintf("%d GB", schemaQuota)
+	_, err := tx.Exec(fmt.Sprintf("ALTER SCHEMA %s QUOTA %s", pq.QuoteIdentifier(schemaName), schemaQuotaToSQL(d)))
+	return err
+}
+
+func schemaQuotaUnit(d resourceValues) string {
+	if unit, ok := d.GetOk(schemaQuotaUnitAttr); ok {
+		return unit.(string)
 	}
+	return defaultSchemaQuotaUnit
+}
 
-	_, err := tx.Exec(fmt.Sprintf("ALTER SCHEMA %s QUOTA %s", pq.QuoteIdentifier(schemaName), quotaValue))
-	return err
+func schemaQuotaUnitInMB(d resourceValues) int {
+	if multiplier, ok := schemaQuotaUnitsInMB[schemaQuotaUnit(d)]; ok {
+		return multiplier
+	}
+	return schemaQuotaUnitsInMB[defaultSchemaQuotaUnit]
+}
+
+// schemaQuotaToSQL returns the quota clause value, UNLIMITED when
+// no quota is configured.
+func schemaQuotaToSQL(d resourceValues) string {
+	schemaQuota := d.Get(schemaQuotaAttr).(int)
+	if schemaQuota <= 0 {
+		return "UNLIMITED"
+	}
+	return fmt.Sprintf("%d %s", schemaQuota, schemaQuotaUnit(d))
 }
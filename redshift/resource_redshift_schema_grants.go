@@ -0,0 +1,585 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	schemaGrantsDatabaseAttr         = "database"
+	schemaGrantsSchemaAttr           = "schema"
+	schemaGrantsGrantAttr            = "grant"
+	schemaGrantsGranteeTypeAttr      = "grantee_type"
+	schemaGrantsGranteeAttr          = "grantee"
+	schemaGrantsSchemaPrivilegesAttr = "schema_privileges"
+	schemaGrantsTablePrivilegesAttr  = "table_privileges"
+
+	schemaGrantsImportIDFormat = "<database>.<schema>"
+)
+
+var (
+	schemaGrantsGranteeTypes     = []string{"user", "group", "role", "public"}
+	schemaGrantsSchemaPrivileges = []string{"create", "usage"}
+	schemaGrantsTablePrivileges  = []string{"select", "insert", "update", "delete", "drop", "references", "rule", "trigger"}
+)
+
+func redshiftSchemaGrants() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages all the privileges granted on a schema and on all its tables and views as a single unit. Unlike ` + "`redshift_grant`" + `, this resource is authoritative: the privileges on the schema and its tables which aren't listed in a ` + "`grant`" + ` block are revoked, including the ones granted outside of Terraform or by ` + "`redshift_grant`" + ` resources, so don't combine them on the same schema. The provider's user must be a superuser or be granted the ` + "`ACCESS SYSTEM TABLE`" + ` privilege: other users only see their own privileges in ` + "`svv_schema_privileges`" + ` and ` + "`svv_relation_privileges`" + `, so the privileges not listed couldn't be revoked, and the resource fails instead. The privileges of the owners of the schema and of the tables aren't managed. Table privileges are granted with ` + "`ON ALL TABLES IN SCHEMA`" + `, so the tables created later only get them on the next apply, unless ` + "`redshift_default_privileges`" + ` are set up as well.
+`,
+		CreateContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftSchemaGrantsCreate),
+		),
+		ReadContext: RedshiftResourceFunc(resourceRedshiftSchemaGrantsRead),
+		UpdateContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftSchemaGrantsUpdate),
+		),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftSchemaGrantsDelete),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRedshiftSchemaGrantsImport,
+		},
+		CustomizeDiff: validateSchemaGrantsGrantees,
+
+		Schema: map[string]*schema.Schema{
+			schemaGrantsDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database the schema belongs to. Defaults to the database the provider connects to.",
+			},
+			schemaGrantsSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The name of the schema whose privileges are managed.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			schemaGrantsGrantAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The privileges of a grantee. Grantees without a block have all their privileges on the schema and its tables revoked.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						schemaGrantsGranteeTypeAttr: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The type of the grantee, one of `user`, `group`, `role` or `public`.",
+							ValidateFunc: validation.StringInSlice(schemaGrantsGranteeTypes, false),
+						},
+						schemaGrantsGranteeAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the user, group or role. It must be omitted for `public`.",
+						},
+						schemaGrantsSchemaPrivilegesAttr: {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The privileges on the schema, among `usage` and `create`.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(schemaGrantsSchemaPrivileges, false),
+							},
+						},
+						schemaGrantsTablePrivilegesAttr: {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The privileges on all the tables and views of the schema, among `select`, `insert`, `update`, `delete`, `drop`, `references`, `rule` and `trigger`.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(schemaGrantsTablePrivileges, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// schemaGrantee identifies who privileges are granted to. The name is empty
+// for public.
+type schemaGrantee struct {
+	identityType string
+	name         string
+}
+
+func (g schemaGrantee) String() string {
+	if g.identityType == "public" {
+		return "public"
+	}
+	return fmt.Sprintf("%s %s", g.identityType, g.name)
+}
+
+// schemaGranteeACL holds the privileges of a grantee on the schema, and on its
+// tables: the privileges granted on any of them and on all of them.
+type schemaGranteeACL struct {
+	schema    map[string]bool
+	anyTables map[string]bool
+	allTables map[string]bool
+}
+
+func newSchemaGranteeACL() *schemaGranteeACL {
+	return &schemaGranteeACL{
+		schema:    map[string]bool{},
+		anyTables: map[string]bool{},
+		allTables: map[string]bool{},
+	}
+}
+
+func validateSchemaGrantsGrantees(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(schemaGrantsGrantAttr) {
+		return nil
+	}
+
+	seen := map[schemaGrantee]bool{}
+	for _, raw := range d.Get(schemaGrantsGrantAttr).(*schema.Set).List() {
+		grant := raw.(map[string]interface{})
+		grantee := schemaGrantee{grant[schemaGrantsGranteeTypeAttr].(string), grant[schemaGrantsGranteeAttr].(string)}
+
+		switch {
+		case grantee.identityType == "public" && grantee.name != "":
+			return fmt.Errorf("`%s` must be omitted when `%s` is public", schemaGrantsGranteeAttr, schemaGrantsGranteeTypeAttr)
+		case grantee.identityType != "public" && grantee.name == "":
+			return fmt.Errorf("`%s` is required when `%s` is %s", schemaGrantsGranteeAttr, schemaGrantsGranteeTypeAttr, grantee.identityType)
+		case seen[grantee]:
+			return fmt.Errorf("%s is listed in more than one `%s` block", grantee, schemaGrantsGrantAttr)
+		}
+		seen[grantee] = true
+	}
+
+	return nil
+}
+
+// expandSchemaGrants returns the privileges of each grantee listed in the grant blocks.
+func expandSchemaGrants(grants *schema.Set) map[schemaGrantee]*schemaGranteeACL {
+	expanded := map[schemaGrantee]*schemaGranteeACL{}
+	for _, raw := range grants.List() {
+		grant := raw.(map[string]interface{})
+		acl := newSchemaGranteeACL()
+		for _, privilege := range grant[schemaGrantsSchemaPrivilegesAttr].(*schema.Set).List() {
+			acl.schema[privilege.(string)] = true
+		}
+		for _, privilege := range grant[schemaGrantsTablePrivilegesAttr].(*schema.Set).List() {
+			acl.anyTables[privilege.(string)] = true
+			acl.allTables[privilege.(string)] = true
+		}
+		expanded[schemaGrantee{grant[schemaGrantsGranteeTypeAttr].(string), grant[schemaGrantsGranteeAttr].(string)}] = acl
+	}
+
+	return expanded
+}
+
+// flattenSchemaGrants builds the grant blocks from the privileges read from
+// Redshift. When the tables don't all have the same privileges, the table
+// privileges differ from the managed ones, so that the next apply grants or
+// revokes them. Without tables, the managed table privileges can't be checked
+// and are kept.
+func flattenSchemaGrants(actual, managed map[schemaGrantee]*schemaGranteeACL, hasTables bool) []interface{} {
+	grantees := map[schemaGrantee]bool{}
+	for grantee := range actual {
+		grantees[grantee] = true
+	}
+	for grantee := range managed {
+		grantees[grantee] = true
+	}
+
+	grants := []interface{}{}
+	for _, grantee := range sortedSchemaGrantees(grantees) {
+		acl, ok := actual[grantee]
+		if !ok {
+			acl = newSchemaGranteeACL()
+		}
+		managedACL, ok := managed[grantee]
+		if !ok {
+			managedACL = newSchemaGranteeACL()
+		}
+
+		tablePrivileges := acl.allTables
+		switch {
+		case !hasTables:
+			tablePrivileges = managedACL.allTables
+		case !privilegesEqual(acl.anyTables, managedACL.allTables):
+			tablePrivileges = acl.anyTables
+		}
+
+		if len(acl.schema) == 0 && len(tablePrivileges) == 0 {
+			continue
+		}
+		grants = append(grants, map[string]interface{}{
+			schemaGrantsGranteeTypeAttr:      grantee.identityType,
+			schemaGrantsGranteeAttr:          grantee.name,
+			schemaGrantsSchemaPrivilegesAttr: sortedPrivileges(acl.schema),
+			schemaGrantsTablePrivilegesAttr:  sortedPrivileges(tablePrivileges),
+		})
+	}
+
+	return grants
+}
+
+// schemaGrantsQueries returns the statements revoking the privileges which
+// aren't managed and then granting the missing ones.
+func schemaGrantsQueries(schemaName string, actual, managed map[schemaGrantee]*schemaGranteeACL) []string {
+	grantees := map[schemaGrantee]bool{}
+	for grantee := range actual {
+		grantees[grantee] = true
+	}
+	for grantee := range managed {
+		grantees[grantee] = true
+	}
+
+	revokes := []string{}
+	grants := []string{}
+	for _, grantee := range sortedSchemaGrantees(grantees) {
+		actualACL, ok := actual[grantee]
+		if !ok {
+			actualACL = newSchemaGranteeACL()
+		}
+		managedACL, ok := managed[grantee]
+		if !ok {
+			managedACL = newSchemaGranteeACL()
+		}
+		to := granteeSQL(grantee.identityType, grantee.name)
+
+		if privileges := privilegesDifference(actualACL.schema, managedACL.schema); len(privileges) > 0 {
+			revokes = append(revokes, fmt.Sprintf("REVOKE %s ON SCHEMA %s FROM %s", privilegesSQL(privileges), pq.QuoteIdentifier(schemaName), to))
+		}
+		if privileges := privilegesDifference(actualACL.anyTables, managedACL.allTables); len(privileges) > 0 {
+			revokes = append(revokes, fmt.Sprintf("REVOKE %s ON ALL TABLES IN SCHEMA %s FROM %s", privilegesSQL(privileges), pq.QuoteIdentifier(schemaName), to))
+		}
+		if privileges := privilegesDifference(managedACL.schema, actualACL.schema); len(privileges) > 0 {
+			grants = append(grants, fmt.Sprintf("GRANT %s ON SCHEMA %s TO %s", privilegesSQL(privileges), pq.QuoteIdentifier(schemaName), to))
+		}
+		if privileges := privilegesDifference(managedACL.allTables, actualACL.allTables); len(privileges) > 0 {
+			grants = append(grants, fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA %s TO %s", privilegesSQL(privileges), pq.QuoteIdentifier(schemaName), to))
+		}
+	}
+
+	return append(revokes, grants...)
+}
+
+func sortedSchemaGrantees(grantees map[schemaGrantee]bool) []schemaGrantee {
+	sorted := make([]schemaGrantee, 0, len(grantees))
+	for grantee := range grantees {
+		sorted = append(sorted, grantee)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].identityType != sorted[j].identityType {
+			return sorted[i].identityType < sorted[j].identityType
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	return sorted
+}
+
+func sortedPrivileges(privileges map[string]bool) []string {
+	sorted := make([]string, 0, len(privileges))
+	for privilege := range privileges {
+		sorted = append(sorted, privilege)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func privilegesEqual(a, b map[string]bool) bool {
+	return len(privilegesDifference(a, b)) == 0 && len(privilegesDifference(b, a)) == 0
+}
+
+// privilegesDifference returns the sorted privileges of a which aren't in b.
+func privilegesDifference(a, b map[string]bool) []string {
+	difference := []string{}
+	for _, privilege := range sortedPrivileges(a) {
+		if !b[privilege] {
+			difference = append(difference, privilege)
+		}
+	}
+
+	return difference
+}
+
+func privilegesSQL(privileges []string) string {
+	return strings.ToUpper(strings.Join(privileges, ", "))
+}
+
+// readSchemaACL reads the privileges granted on the schema and on its tables
+// and views, leaving out the ones of their owners. It also reports whether the
+// schema has any tables. The ACLs don't show the privileges of roles, so they
+// can't replace the privilege views when those hide the other grantees.
+func readSchemaACL(db *DBConnection, schemaName string) (map[schemaGrantee]*schemaGranteeACL, bool, error) {
+	visible, err := db.seesAllPrivileges()
+	if err != nil {
+		return nil, false, err
+	}
+	if !visible {
+		return nil, false, fmt.Errorf("redshift_schema_grants requires the provider's user to be a superuser or to be granted ACCESS SYSTEM TABLE: svv_schema_privileges and svv_relation_privileges only show the other users their own privileges, so the privileges on schema %s granted to others couldn't be revoked", schemaName)
+	}
+
+	var schemaOwner string
+	if err := db.QueryRow("SELECT pg_get_userbyid(nspowner) FROM pg_namespace WHERE lower(nspname) = lower($1)", schemaName).Scan(&schemaOwner); err != nil {
+		return nil, false, fmt.Errorf("Error reading schema %s: %w", schemaName, err)
+	}
+
+	tableOwners := map[string]string{}
+	rows, err := db.Query(`
+  SELECT cl.relname, pg_get_userbyid(cl.relowner)
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE lower(nsp.nspname) = lower($1) AND cl.relkind = ANY($2)
+`, schemaName, pq.Array(grantObjectTypesCodes["table"]))
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, owner string
+		if err := rows.Scan(&tableName, &owner); err != nil {
+			return nil, false, err
+		}
+		tableOwners[tableName] = owner
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	acls := map[schemaGrantee]*schemaGranteeACL{}
+	granteeACL := func(identityType, identityName string) *schemaGranteeACL {
+		grantee := schemaGrantee{identityType, identityName}
+		if identityType == "public" {
+			grantee.name = ""
+		}
+		if _, ok := acls[grantee]; !ok {
+			acls[grantee] = newSchemaGranteeACL()
+		}
+		return acls[grantee]
+	}
+
+	schemaRows, err := db.Query(`
+  SELECT identity_type, identity_name, privilege_type
+  FROM svv_schema_privileges
+  WHERE lower(namespace_name) = lower($1)
+`, schemaName)
+	if err != nil {
+		return nil, false, err
+	}
+	defer schemaRows.Close()
+	for schemaRows.Next() {
+		var identityType, identityName, privilege string
+		if err := schemaRows.Scan(&identityType, &identityName, &privilege); err != nil {
+			return nil, false, err
+		}
+		if identityType == "user" && identityName == schemaOwner {
+			continue
+		}
+		granteeACL(identityType, identityName).schema[normalizeRolePrivilege(privilege)] = true
+	}
+	if err := schemaRows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	tableRows, err := db.Query(`
+  SELECT identity_type, identity_name, relation_name, privilege_type
+  FROM svv_relation_privileges
+  WHERE lower(namespace_name) = lower($1)
+`, schemaName)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tableRows.Close()
+
+	// Count the tables each privilege is granted on, to tell the privileges
+	// granted on all the tables apart.
+	tableCounts := map[schemaGrantee]map[string]map[string]bool{}
+	for tableRows.Next() {
+		var identityType, identityName, tableName, privilege string
+		if err := tableRows.Scan(&identityType, &identityName, &tableName, &privilege); err != nil {
+			return nil, false, err
+		}
+		owner, isTable := tableOwners[tableName]
+		if !isTable || (identityType == "user" && identityName == owner) {
+			continue
+		}
+
+		privilege = normalizeRolePrivilege(privilege)
+		granteeACL(identityType, identityName).anyTables[privilege] = true
+
+		grantee := schemaGrantee{identityType, identityName}
+		if identityType == "public" {
+			grantee.name = ""
+		}
+		if _, ok := tableCounts[grantee]; !ok {
+			tableCounts[grantee] = map[string]map[string]bool{}
+		}
+		if _, ok := tableCounts[grantee][privilege]; !ok {
+			tableCounts[grantee][privilege] = map[string]bool{}
+		}
+		tableCounts[grantee][privilege][tableName] = true
+	}
+	if err := tableRows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	for grantee, privileges := range tableCounts {
+		// Owners have all the privileges on their tables.
+		owned := 0
+		if grantee.identityType == "user" {
+			for _, owner := range tableOwners {
+				if owner == grantee.name {
+					owned++
+				}
+			}
+		}
+		for privilege, tables := range privileges {
+			if len(tables)+owned == len(tableOwners) {
+				acls[grantee].allTables[privilege] = true
+			}
+		}
+	}
+
+	return acls, len(tableOwners) > 0, nil
+}
+
+func generateSchemaGrantsID(databaseName, schemaName string) string {
+	return fmt.Sprintf("%s.%s", databaseName, schemaName)
+}
+
+func resourceRedshiftSchemaGrantsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid schema grants import ID %q, expected %s", d.Id(), schemaGrantsImportIDFormat)
+	}
+
+	d.Set(schemaGrantsDatabaseAttr, parts[0])
+	d.Set(schemaGrantsSchemaAttr, parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRedshiftSchemaGrantsCreate(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftSchemaGrantsApply(db, d)
+}
+
+func resourceRedshiftSchemaGrantsUpdate(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftSchemaGrantsApply(db, d)
+}
+
+func resourceRedshiftSchemaGrantsDelete(db *DBConnection, d *schema.ResourceData) error {
+	// Only the privileges of the grantees managed by the resource are revoked.
+	managed := expandSchemaGrants(d.Get(schemaGrantsGrantAttr).(*schema.Set))
+
+	db, err := connectToDatabase(db, d.Get(schemaGrantsDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	schemaName := d.Get(schemaGrantsSchemaAttr).(string)
+	actual, _, err := readSchemaACL(db, schemaName)
+	if err != nil {
+		return err
+	}
+	for grantee := range actual {
+		if _, ok := managed[grantee]; !ok {
+			delete(actual, grantee)
+		}
+	}
+
+	return execSchemaGrantsQueries(db, schemaGrantsQueries(schemaName, actual, nil))
+}
+
+// resourceRedshiftSchemaGrantsApply reconciles the privileges on the schema and
+// its tables with the grant blocks.
+func resourceRedshiftSchemaGrantsApply(db *DBConnection, d *schema.ResourceData) error {
+	db, err := connectToDatabase(db, d.Get(schemaGrantsDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	schemaName := d.Get(schemaGrantsSchemaAttr).(string)
+	actual, _, err := readSchemaACL(db, schemaName)
+	if err != nil {
+		return err
+	}
+
+	if err := execSchemaGrantsQueries(db, schemaGrantsQueries(schemaName, actual, expandSchemaGrants(d.Get(schemaGrantsGrantAttr).(*schema.Set)))); err != nil {
+		return err
+	}
+
+	d.SetId(generateSchemaGrantsID(db.client.databaseName, schemaName))
+
+	return resourceRedshiftSchemaGrantsReadImpl(db, d)
+}
+
+func execSchemaGrantsQueries(db *DBConnection, queries []string) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	for _, query := range queries {
+		log.Printf("[DEBUG] %s\n", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("could not reconcile schema privileges with %q: %w", query, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func resourceRedshiftSchemaGrantsRead(db *DBConnection, d *schema.ResourceData) error {
+	db, err := connectToDatabase(db, d.Get(schemaGrantsDatabaseAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceRedshiftSchemaGrantsReadImpl(db, d)
+}
+
+func resourceRedshiftSchemaGrantsReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	schemaName := d.Get(schemaGrantsSchemaAttr).(string)
+
+	exists, err := schemaGrantsSchemaExists(db, schemaName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("[WARN] Redshift Schema (%s) not found, removing its grants from the state", schemaName)
+		d.SetId("")
+		return nil
+	}
+
+	actual, hasTables, err := readSchemaACL(db, schemaName)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(generateSchemaGrantsID(db.client.databaseName, schemaName))
+	d.Set(schemaGrantsDatabaseAttr, db.client.databaseName)
+	d.Set(schemaGrantsGrantAttr, flattenSchemaGrants(actual, expandSchemaGrants(d.Get(schemaGrantsGrantAttr).(*schema.Set)), hasTables))
+
+	return nil
+}
+
+func schemaGrantsSchemaExists(db *DBConnection, schemaName string) (bool, error) {
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE lower(nspname) = lower($1))", schemaName).Scan(&exists); err != nil {
+		return false, fmt.Errorf("Error checking whether schema %s exists: %w", schemaName, err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,300 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+)
+
+func TestAccRedshiftSchemaGrants_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grants"), "-", "_")
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grants_user"), "-", "_")
+	groupName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_grants_group"), "-", "_")
+	config := func(tablePrivileges string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_user" "user" {
+  name = %[2]q
+}
+
+resource "redshift_group" "group" {
+  name = %[3]q
+}
+
+resource "redshift_table" "table" {
+  name   = "test_table"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_schema_grants" "grants" {
+  schema = redshift_schema.schema.name
+
+  grant {
+    grantee_type      = "user"
+    grantee           = redshift_user.user.name
+    schema_privileges = ["usage"]
+    table_privileges  = %[4]s
+  }
+
+  grant {
+    grantee_type      = "group"
+    grantee           = redshift_group.group.name
+    schema_privileges = ["usage", "create"]
+  }
+
+  depends_on = [redshift_table.table]
+}
+`, schemaName, userName, groupName, tablePrivileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(s *terraform.State) error { return nil },
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["select"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema_grants.grants", "schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_schema_grants.grants", "grant.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("redshift_schema_grants.grants", "grant.*", map[string]string{
+						"grantee_type":       "user",
+						"grantee":            userName,
+						"table_privileges.#": "1",
+						"table_privileges.0": "select",
+					}),
+				),
+			},
+			{
+				Config: config(`["select", "insert"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("redshift_schema_grants.grants", "grant.*", map[string]string{
+						"grantee_type":       "user",
+						"table_privileges.#": "2",
+					}),
+				),
+			},
+			// Privileges granted outside of Terraform are detected and revoked.
+			{
+				PreConfig: func() {
+					dbClient := testAccProvider.Meta().(*Client)
+					conn, err := dbClient.Connect()
+					defer dbClient.Close()
+					if err != nil {
+						t.Fatalf("couldn't start redshift connection: %s", err)
+					}
+					queries := []string{
+						fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO PUBLIC", pq.QuoteIdentifier(schemaName)),
+						fmt.Sprintf("GRANT DELETE ON %s.test_table TO %s", pq.QuoteIdentifier(schemaName), pq.QuoteIdentifier(userName)),
+					}
+					for _, query := range queries {
+						if _, err := conn.Exec(query); err != nil {
+							t.Fatalf("couldn't grant privileges outside of terraform: %s", err)
+						}
+					}
+				},
+				Config:             config(`["select", "insert"]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(`["select", "insert"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema_grants.grants", "grant.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "redshift_schema_grants.grants",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSchemaGrantsQueries(t *testing.T) {
+	user := schemaGrantee{"user", "alice"}
+	group := schemaGrantee{"group", "analysts"}
+	public := schemaGrantee{"public", ""}
+
+	acl := func(schemaPrivileges, anyTables, allTables []string) *schemaGranteeACL {
+		result := newSchemaGranteeACL()
+		for _, privilege := range schemaPrivileges {
+			result.schema[privilege] = true
+		}
+		for _, privilege := range anyTables {
+			result.anyTables[privilege] = true
+		}
+		for _, privilege := range allTables {
+			result.allTables[privilege] = true
+		}
+		return result
+	}
+
+	tests := map[string]struct {
+		actual   map[schemaGrantee]*schemaGranteeACL
+		managed  map[schemaGrantee]*schemaGranteeACL
+		expected []string
+	}{
+		"nothing to do": {
+			actual:   map[schemaGrantee]*schemaGranteeACL{user: acl([]string{"usage"}, []string{"select"}, []string{"select"})},
+			managed:  map[schemaGrantee]*schemaGranteeACL{user: acl([]string{"usage"}, []string{"select"}, []string{"select"})},
+			expected: []string{},
+		},
+		"grant missing privileges": {
+			actual:  map[schemaGrantee]*schemaGranteeACL{},
+			managed: map[schemaGrantee]*schemaGranteeACL{group: acl([]string{"usage", "create"}, []string{"select", "insert"}, []string{"select", "insert"})},
+			expected: []string{
+				`GRANT CREATE, USAGE ON SCHEMA "schema" TO GROUP "analysts"`,
+				`GRANT INSERT, SELECT ON ALL TABLES IN SCHEMA "schema" TO GROUP "analysts"`,
+			},
+		},
+		"revoke unmanaged grantees first": {
+			actual: map[schemaGrantee]*schemaGranteeACL{
+				public: acl([]string{"usage"}, nil, nil),
+			},
+			managed: map[schemaGrantee]*schemaGranteeACL{user: acl([]string{"usage"}, nil, nil)},
+			expected: []string{
+				`REVOKE USAGE ON SCHEMA "schema" FROM PUBLIC`,
+				`GRANT USAGE ON SCHEMA "schema" TO "alice"`,
+			},
+		},
+		"privileges on some tables only": {
+			actual:  map[schemaGrantee]*schemaGranteeACL{user: acl(nil, []string{"select", "delete"}, nil)},
+			managed: map[schemaGrantee]*schemaGranteeACL{user: acl(nil, []string{"select"}, []string{"select"})},
+			expected: []string{
+				`REVOKE DELETE ON ALL TABLES IN SCHEMA "schema" FROM "alice"`,
+				`GRANT SELECT ON ALL TABLES IN SCHEMA "schema" TO "alice"`,
+			},
+		},
+		"revoke everything": {
+			actual:  map[schemaGrantee]*schemaGranteeACL{user: acl([]string{"usage"}, []string{"select"}, []string{"select"})},
+			managed: nil,
+			expected: []string{
+				`REVOKE USAGE ON SCHEMA "schema" FROM "alice"`,
+				`REVOKE SELECT ON ALL TABLES IN SCHEMA "schema" FROM "alice"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			queries := schemaGrantsQueries("schema", test.actual, test.managed)
+			if !reflect.DeepEqual(queries, test.expected) {
+				t.Errorf("expected queries %q, got %q", test.expected, queries)
+			}
+		})
+	}
+}
+
+func TestFlattenSchemaGrants(t *testing.T) {
+	user := schemaGrantee{"user", "alice"}
+
+	acl := func(anyTables, allTables []string) *schemaGranteeACL {
+		result := newSchemaGranteeACL()
+		result.schema["usage"] = true
+		for _, privilege := range anyTables {
+			result.anyTables[privilege] = true
+		}
+		for _, privilege := range allTables {
+			result.allTables[privilege] = true
+		}
+		return result
+	}
+
+	tests := map[string]struct {
+		actual    *schemaGranteeACL
+		managed   *schemaGranteeACL
+		hasTables bool
+		expected  []string
+	}{
+		"privileges on all tables": {
+			actual:    acl([]string{"select"}, []string{"select"}),
+			managed:   acl([]string{"select"}, []string{"select"}),
+			hasTables: true,
+			expected:  []string{"select"},
+		},
+		"extra privileges on some tables": {
+			actual:    acl([]string{"delete", "select"}, []string{"select"}),
+			managed:   acl([]string{"select"}, []string{"select"}),
+			hasTables: true,
+			expected:  []string{"delete", "select"},
+		},
+		"managed privileges missing on some tables": {
+			actual:    acl([]string{"select"}, nil),
+			managed:   acl([]string{"select"}, []string{"select"}),
+			hasTables: true,
+			expected:  []string{},
+		},
+		"no tables": {
+			actual:    acl(nil, nil),
+			managed:   acl([]string{"select"}, []string{"select"}),
+			hasTables: false,
+			expected:  []string{"select"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			grants := flattenSchemaGrants(
+				map[schemaGrantee]*schemaGranteeACL{user: test.actual},
+				map[schemaGrantee]*schemaGranteeACL{user: test.managed},
+				test.hasTables,
+			)
+			if len(grants) != 1 {
+				t.Fatalf("expected 1 grant, got %d", len(grants))
+			}
+			tablePrivileges := grants[0].(map[string]interface{})[schemaGrantsTablePrivilegesAttr].([]string)
+			if !reflect.DeepEqual(tablePrivileges, test.expected) {
+				t.Errorf("expected table privileges %q, got %q", test.expected, tablePrivileges)
+			}
+		})
+	}
+}
+
+func TestReadSchemaACLRequiresVisiblePrivileges(t *testing.T) {
+	visible := false
+	db := &DBConnection{
+		capabilities: &clusterCapabilities{supported: map[string]bool{}, privilegesVisible: &visible},
+	}
+
+	_, _, err := readSchemaACL(db, "sales")
+	if err == nil {
+		t.Fatal("Expected an error when the privilege views hide the other grantees")
+	}
+	if !strings.Contains(err.Error(), "ACCESS SYSTEM TABLE") {
+		t.Errorf("Expected the error to name the required privilege, got %q", err)
+	}
+}
+
+func TestSchemaGrantsImportID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftSchemaGrants().Schema, map[string]interface{}{})
+	d.SetId("dev.Analytics")
+
+	if _, err := resourceRedshiftSchemaGrantsImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if databaseName := d.Get(schemaGrantsDatabaseAttr).(string); databaseName != "dev" {
+		t.Errorf("Expected database dev, got %q", databaseName)
+	}
+	if schemaName := d.Get(schemaGrantsSchemaAttr).(string); schemaName != "Analytics" {
+		t.Errorf("Expected schema Analytics, got %q", schemaName)
+	}
+}
@@ -1,15 +1,20 @@
 package redshift
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftSchema_Basic(t *testing.T) {
@@ -98,6 +103,236 @@ resource "redshift_user" "schema_user1" {
 	})
 }
 
+func TestAccRedshiftSchema_OwnerChange(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_owner"), "-", "_")
+	userName1 := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_owner1"), "-", "_")
+	userName2 := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_owner2"), "-", "_")
+	config := func(owner string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user1" {
+  name = %[2]q
+}
+
+resource "redshift_user" "user2" {
+  name = %[3]q
+}
+
+resource "redshift_schema" "schema" {
+  name  = %[1]q
+  owner = %[4]s
+
+  depends_on = [redshift_user.user1, redshift_user.user2]
+}
+`, schemaName, userName1, userName2, owner)
+	}
+
+	var schemaID string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("redshift_user.user1.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaOwnerAttr, userName1),
+					func(s *terraform.State) error {
+						schemaID = s.RootModule().Resources["redshift_schema.schema"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("redshift_user.user2.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaOwnerAttr, userName2),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["redshift_schema.schema"].Primary.ID; id != schemaID {
+							return fmt.Errorf("Expected the owner to change in place, but the schema was recreated (%s != %s)", id, schemaID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config(fmt.Sprintf("%q", strings.ToUpper(userName2))),
+				PlanOnly: true,
+			},
+			{
+				Config:      config(`"tf_acc_schema_owner_missing"`),
+				ExpectError: regexp.MustCompile(`User "tf_acc_schema_owner_missing" does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_CascadeOnDelete(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_cascade"), "-", "_")
+	config := func(cascade bool) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = %[2]t
+}
+`, schemaName, cascade)
+	}
+
+	createTable := func() {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := db.Exec(fmt.Sprintf("CREATE TABLE %s.cascade_test (id INTEGER)", pq.QuoteIdentifier(schemaName))); err != nil {
+			t.Fatalf("Could not create a table in schema %s: %s", schemaName, err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check:  testAccCheckRedshiftSchemaExists(schemaName),
+			},
+			{
+				// Without CASCADE, a schema containing a table can't be dropped.
+				PreConfig:   createTable,
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("set cascade_on_delete to true"),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaCascadeOnDeleteAttr, "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_IfNotExists(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_if_not_exists"), "-", "_")
+	// The provider is only configured once a step has run, so the first step
+	// creates a placeholder before the schema is created outside of Terraform.
+	placeholder := fmt.Sprintf(`
+resource "redshift_group" "placeholder" {
+  name = "%s_placeholder"
+}
+`, schemaName)
+	config := placeholder + fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name          = %[1]q
+  if_not_exists = true
+}
+`, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: placeholder,
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("Unexpected error: %s", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(schemaName))); err != nil {
+						t.Fatalf("Could not create schema %s: %s", schemaName, err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaNameAttr, schemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", schemaIfNotExistsAttr, "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftSchema_QuotaUnit(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_schema_quota"), "-", "_")
+	config := func(quota string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+  %[2]s
+}
+`, schemaName, quota)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("quota = 512\n  quota_unit = \"MB\""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaExists(schemaName),
+					resource.TestCheckResourceAttr("redshift_schema.schema", "quota", "512"),
+				),
+			},
+			{
+				Config: config("quota = 1\n  quota_unit = \"TB\""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "quota", "1048576"),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "quota", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSchemaQuotaToSQL(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		"unlimited": {
+			input:    map[string]interface{}{},
+			expected: "UNLIMITED",
+		},
+		"default unit": {
+			input:    map[string]interface{}{schemaQuotaAttr: 15},
+			expected: "15 GB",
+		},
+		"megabytes": {
+			input:    map[string]interface{}{schemaQuotaAttr: 512, schemaQuotaUnitAttr: "MB"},
+			expected: "512 MB",
+		},
+		"unit without quota": {
+			input:    map[string]interface{}{schemaQuotaUnitAttr: "TB"},
+			expected: "UNLIMITED",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftSchema().Schema, tc.input)
+			if quota := schemaQuotaToSQL(d); quota != tc.expected {
+				t.Errorf("Expected quota %q, got %q", tc.expected, quota)
+			}
+		})
+	}
+}
+
 func TestAccRedshiftSchema_UpdateComplex(t *testing.T) {
 	var configCreate = `
 resource "redshift_schema" "update_dl_schema" {
@@ -198,9 +433,10 @@ resource "redshift_schema" "spectrum" {
 				),
 			},
 			{
-				ResourceName:      "redshift_schema.spectrum",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "redshift_schema.spectrum",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
 			},
 		},
 	})
@@ -269,9 +505,10 @@ resource "redshift_schema" "hive" {
 				),
 			},
 			{
-				ResourceName:      "redshift_schema.hive",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "redshift_schema.hive",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
 			},
 		},
 	})
@@ -351,9 +588,10 @@ resource "redshift_schema" "postgres" {
 				),
 			},
 			{
-				ResourceName:      "redshift_schema.postgres",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "redshift_schema.postgres",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
 			},
 		},
 	})
@@ -426,9 +664,10 @@ resource "redshift_schema" "mysql" {
 				),
 			},
 			{
-				ResourceName:      "redshift_schema.mysql",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "redshift_schema.mysql",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
 			},
 		},
 	})
@@ -478,9 +717,10 @@ resource "redshift_schema" "redshift" {
 				),
 			},
 			{
-				ResourceName:      "redshift_schema.redshift",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "redshift_schema.redshift",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
 			},
 		},
 	})
@@ -636,3 +876,174 @@ resource "redshift_user" "schema_test_user1" {
   name = "schema_test_user1"
 }
 `
+
+func TestExternalSchemaConfigQueryParts(t *testing.T) {
+	tests := map[string]struct {
+		source   map[string]interface{}
+		query    func(d resourceValues, sourceDbName string) string
+		expected string
+	}{
+		"data catalog": {
+			source: map[string]interface{}{
+				"data_catalog_source": []interface{}{
+					map[string]interface{}{
+						"region":                                 "us-west-2",
+						"iam_role_arns":                          []interface{}{"arn:aws:iam::123456789012:role/myRedshiftRole", "arn:aws:iam::123456789012:role/myS3Role"},
+						"catalog_role_arns":                      []interface{}{"arn:aws:iam::123456789012:role/myAthenaRole"},
+						"create_external_database_if_not_exists": true,
+					},
+				},
+			},
+			query:    getDataCatalogConfigQueryPart,
+			expected: "FROM DATA CATALOG DATABASE 'source_db' REGION 'us-west-2' IAM_ROLE 'arn:aws:iam::123456789012:role/myRedshiftRole,arn:aws:iam::123456789012:role/myS3Role' CATALOG_ROLE 'arn:aws:iam::123456789012:role/myAthenaRole' CREATE EXTERNAL DATABASE IF NOT EXISTS",
+		},
+		"data catalog without external database creation": {
+			source: map[string]interface{}{
+				"data_catalog_source": []interface{}{
+					map[string]interface{}{
+						"iam_role_arns": []interface{}{"arn:aws:iam::123456789012:role/myRedshiftRole"},
+					},
+				},
+			},
+			query:    getDataCatalogConfigQueryPart,
+			expected: "FROM DATA CATALOG DATABASE 'source_db' IAM_ROLE 'arn:aws:iam::123456789012:role/myRedshiftRole'",
+		},
+		"hive metastore": {
+			source: map[string]interface{}{
+				"hive_metastore_source": []interface{}{
+					map[string]interface{}{
+						"hostname":      "172.10.10.10",
+						"port":          99,
+						"iam_role_arns": []interface{}{"arn:aws:iam::123456789012:role/MySpectrumRole"},
+					},
+				},
+			},
+			query:    getHiveMetastoreConfigQueryPart,
+			expected: "FROM HIVE METASTORE DATABASE 'source_db' URI '172.10.10.10' PORT 99 IAM_ROLE 'arn:aws:iam::123456789012:role/MySpectrumRole'",
+		},
+		"rds postgres": {
+			source: map[string]interface{}{
+				"rds_postgres_source": []interface{}{
+					map[string]interface{}{
+						"hostname":      "aurora.example.com",
+						"schema":        "my_schema",
+						"iam_role_arns": []interface{}{"arn:aws:iam::123456789012:role/MyAuroraRole"},
+						"secret_arn":    "arn:aws:secretsmanager:us-east-2:123456789012:secret:MySecret",
+					},
+				},
+			},
+			query:    getRdsPostgresConfigQueryPart,
+			expected: "FROM POSTGRES DATABASE 'source_db' SCHEMA 'my_schema' URI 'aurora.example.com' PORT 5432 IAM_ROLE 'arn:aws:iam::123456789012:role/MyAuroraRole' SECRET_ARN 'arn:aws:secretsmanager:us-east-2:123456789012:secret:MySecret'",
+		},
+		"rds mysql": {
+			source: map[string]interface{}{
+				"rds_mysql_source": []interface{}{
+					map[string]interface{}{
+						"hostname":      "aurora.example.com",
+						"iam_role_arns": []interface{}{"arn:aws:iam::123456789012:role/MyAuroraRole"},
+						"secret_arn":    "arn:aws:secretsmanager:us-east-2:123456789012:secret:MySecret",
+					},
+				},
+			},
+			query:    getRdsMysqlConfigQueryPart,
+			expected: "FROM MYSQL DATABASE 'source_db' URI 'aurora.example.com' PORT 3306 IAM_ROLE 'arn:aws:iam::123456789012:role/MyAuroraRole' SECRET_ARN 'arn:aws:secretsmanager:us-east-2:123456789012:secret:MySecret'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			externalSchema := map[string]interface{}{
+				"database_name": "source_db",
+			}
+			for k, v := range test.source {
+				externalSchema[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, redshiftSchema().Schema, map[string]interface{}{
+				schemaNameAttr:           "external_schema",
+				schemaExternalSchemaAttr: []interface{}{externalSchema},
+			})
+
+			if result := test.query(d, "source_db"); result != test.expected {
+				t.Errorf("Unexpected query.\nExpected: %s\nGot:      %s", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestCustomizeExternalSchemaIAMRole(t *testing.T) {
+	externalSchema := func(iamRoleArn string) map[string]interface{} {
+		return map[string]interface{}{
+			schemaNameAttr: "external_schema",
+			schemaExternalSchemaAttr: []interface{}{
+				map[string]interface{}{
+					"database_name": "source_db",
+					"data_catalog_source": []interface{}{
+						map[string]interface{}{
+							"iam_role_arns": []interface{}{iamRoleArn},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for name, supported := range map[string]bool{"supported": true, "unsupported": false} {
+		t.Run(name, func(t *testing.T) {
+			r := redshiftSchema()
+			r.CustomizeDiff = customdiff.All(customizeExternalSchemaIAMRole(func(interface{}) (bool, error) {
+				return supported, nil
+			}))
+
+			old := schema.TestResourceDataRaw(t, r.Schema, externalSchema("arn:aws:iam::123456789012:role/myOldRole"))
+			old.Set(schemaOwnerAttr, "root")
+			old.SetId("1")
+
+			diff, err := r.Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(externalSchema("arn:aws:iam::123456789012:role/myNewRole")), nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if diff.RequiresNew() == supported {
+				t.Errorf("Expected RequiresNew to be %t", !supported)
+			}
+			if !supported {
+				return
+			}
+
+			d, err := schema.InternalMap(r.Schema).Data(old.State(), diff)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			recorder := &statementRecorder{}
+			if err := setExternalSchemaIAMRole(recorder, d); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			expected := `ALTER EXTERNAL SCHEMA "external_schema" IAM_ROLE 'arn:aws:iam::123456789012:role/myNewRole'`
+			if strings.Join(recorder.statements, ";") != expected {
+				t.Errorf("Expected statements %q, got %q", expected, recorder.statements)
+			}
+		})
+	}
+}
+
+func TestIamRoleArnValidate(t *testing.T) {
+	tests := map[string]bool{
+		"arn:aws:iam::123456789012:role/myRedshiftRole":        true,
+		"arn:aws:iam::123456789012:role/path/to/myRole":        true,
+		"arn:aws-us-gov:iam::123456789012:role/myRedshiftRole": true,
+		"arn:aws-cn:iam::123456789012:role/myRedshiftRole":     true,
+		"arn:aws:iam::123456789012:user/myUser":                false,
+		"arn:aws:s3:::my-bucket":                               false,
+		"arn:aws:iam::12345:role/myRedshiftRole":               false,
+		"myRedshiftRole":                                       false,
+	}
+
+	for arn, valid := range tests {
+		_, errs := iamRoleArnValidate(arn, "iam_role_arns.0")
+		if valid && len(errs) > 0 {
+			t.Errorf("Expected %q to be a valid IAM role ARN, got errors: %v", arn, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("Expected %q to be an invalid IAM role ARN", arn)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	storedProcedureNameAttr         = "name"
+	storedProcedureSchemaAttr       = "schema"
+	storedProcedureArgumentAttr     = "argument"
+	storedProcedureArgumentNameAttr = "name"
+	storedProcedureArgumentTypeAttr = "type"
+	storedProcedureArgumentModeAttr = "mode"
+	storedProcedureLanguageAttr     = "language"
+	storedProcedureSecurityAttr     = "security"
+	storedProcedureBodyAttr         = "body"
+)
+
+func redshiftStoredProcedure() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a stored procedure. A stored procedure is a collection of SQL statements that multiple programs can use. Redshift identifies a procedure by its name and the types of its input arguments, so changing the arguments drops the procedure and creates a new one.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftStoredProcedureCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftStoredProcedureRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftStoredProcedureUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftStoredProcedureDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftStoredProcedureExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			storedProcedureNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the stored procedure.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			storedProcedureSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the schema the stored procedure belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			storedProcedureArgumentAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arguments of the stored procedure, in order. Arguments aren't read back from Redshift, so they must be configured when importing a procedure which has some.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						storedProcedureArgumentNameAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Name of the argument.",
+						},
+						storedProcedureArgumentTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Data type of the argument, e.g. `integer` or `varchar(256)`.",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeColumnType(old) == normalizeColumnType(new)
+							},
+						},
+						storedProcedureArgumentModeAttr: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "IN",
+							Description:  "Mode of the argument. One of `IN`, `OUT` or `INOUT`.",
+							ValidateFunc: validation.StringInSlice([]string{"IN", "OUT", "INOUT"}, false),
+						},
+					},
+				},
+			},
+			storedProcedureLanguageAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "plpgsql",
+				Description:  "Language of the stored procedure. Redshift only supports `plpgsql`.",
+				ValidateFunc: validation.StringInSlice([]string{"plpgsql"}, false),
+			},
+			storedProcedureSecurityAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "INVOKER",
+				Description:  "Whether the procedure runs with the privileges of the user calling it (`INVOKER`) or of its owner (`DEFINER`).",
+				ValidateFunc: validation.StringInSlice([]string{"INVOKER", "DEFINER"}, false),
+			},
+			storedProcedureBodyAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The body of the stored procedure, without the surrounding dollar quotes, e.g. `BEGIN ... END;`. Differences in whitespace are ignored.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizeCallableBody(old) == normalizeCallableBody(new)
+				},
+			},
+		},
+	}
+}
+
+func normalizeCallableBody(body string) string {
+	return strings.Join(strings.Fields(body), " ")
+}
+
+func resourceRedshiftStoredProcedureExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT proname FROM pg_proc_info WHERE prooid = $1 AND prokind = 'p'", d.Id()).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftStoredProcedureRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftStoredProcedureReadImpl(db, d)
+}
+
+func resourceRedshiftStoredProcedureReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var procedureName, schemaName, body string
+	var securityDefiner bool
+
+	err := db.QueryRow(`
+  SELECT pr.proname, nsp.nspname, pr.prosecdef, pr.prosrc
+  FROM pg_proc_info pr
+    JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+  WHERE pr.prooid = $1 AND pr.prokind = 'p'
+`, d.Id()).Scan(&procedureName, &schemaName, &securityDefiner, &body)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift Stored Procedure (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Stored Procedure: %w", err)
+	}
+
+	d.Set(storedProcedureNameAttr, procedureName)
+	d.Set(storedProcedureSchemaAttr, schemaName)
+	d.Set(storedProcedureLanguageAttr, "plpgsql")
+	if securityDefiner {
+		d.Set(storedProcedureSecurityAttr, "DEFINER")
+	} else {
+		d.Set(storedProcedureSecurityAttr, "INVOKER")
+	}
+
+	// Keep the configured formatting when only the whitespace differs.
+	if normalizeCallableBody(body) != normalizeCallableBody(d.Get(storedProcedureBodyAttr).(string)) {
+		d.Set(storedProcedureBodyAttr, strings.TrimSpace(body))
+	}
+
+	return nil
+}
+
+// storedProcedureSignature returns the procedure name followed by the types of
+// its input arguments, which is how Redshift identifies the procedure.
+func storedProcedureSignature(d *schema.ResourceData, name string) string {
+	argumentTypes := []string{}
+	for _, raw := range d.Get(storedProcedureArgumentAttr).([]interface{}) {
+		argument := raw.(map[string]interface{})
+		if argument[storedProcedureArgumentModeAttr].(string) == "OUT" {
+			continue
+		}
+		argumentTypes = append(argumentTypes, argument[storedProcedureArgumentTypeAttr].(string))
+	}
+
+	return fmt.Sprintf(
+		"%s.%s(%s)",
+		pq.QuoteIdentifier(d.Get(storedProcedureSchemaAttr).(string)),
+		pq.QuoteIdentifier(name),
+		strings.Join(argumentTypes, ", "),
+	)
+}
+
+// callableBodyQuote returns a dollar quote tag which doesn't appear in the body.
+func callableBodyQuote(body string) string {
+	quote := "$$"
+	for i := 0; strings.Contains(body, quote); i++ {
+		quote = fmt.Sprintf("$body%d$", i)
+	}
+	return quote
+}
+
+func createStoredProcedureQuery(d *schema.ResourceData) string {
+	arguments := []string{}
+	for _, raw := range d.Get(storedProcedureArgumentAttr).([]interface{}) {
+		argument := raw.(map[string]interface{})
+		parts := []string{}
+		if name := argument[storedProcedureArgumentNameAttr].(string); name != "" {
+			parts = append(parts, pq.QuoteIdentifier(name))
+		}
+		parts = append(parts, argument[storedProcedureArgumentModeAttr].(string), argument[storedProcedureArgumentTypeAttr].(string))
+		arguments = append(arguments, strings.Join(parts, " "))
+	}
+
+	body := d.Get(storedProcedureBodyAttr).(string)
+	quote := callableBodyQuote(body)
+
+	return fmt.Sprintf(
+		"CREATE OR REPLACE PROCEDURE %s.%s(%s) AS %s\n%s\n%s LANGUAGE %s SECURITY %s",
+		pq.QuoteIdentifier(d.Get(storedProcedureSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(storedProcedureNameAttr).(string)),
+		strings.Join(arguments, ", "),
+		quote,
+		strings.TrimSpace(body),
+		quote,
+		d.Get(storedProcedureLanguageAttr).(string),
+		d.Get(storedProcedureSecurityAttr).(string),
+	)
+}
+
+func resourceRedshiftStoredProcedureCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(createStoredProcedureQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift stored procedure: %w", createObjectError(err, "redshift_stored_procedure"))
+	}
+
+	var procedureOID string
+	signature := storedProcedureSignature(d, d.Get(storedProcedureNameAttr).(string))
+	if err := tx.QueryRow("SELECT $1::regprocedure::oid", signature).Scan(&procedureOID); err != nil {
+		return fmt.Errorf("Could not get redshift stored procedure oid: %w", err)
+	}
+
+	d.SetId(procedureOID)
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftStoredProcedureReadImpl(db, d)
+}
+
+func resourceRedshiftStoredProcedureDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	signature := storedProcedureSignature(d, d.Get(storedProcedureNameAttr).(string))
+	if _, err := tx.Exec(fmt.Sprintf("DROP PROCEDURE %s", signature)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftStoredProcedureUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setStoredProcedureName(tx, d); err != nil {
+		return err
+	}
+
+	if d.HasChanges(storedProcedureBodyAttr, storedProcedureSecurityAttr) {
+		if _, err := tx.Exec(createStoredProcedureQuery(d)); err != nil {
+			return fmt.Errorf("Error replacing Stored Procedure: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftStoredProcedureReadImpl(db, d)
+}
+
+func setStoredProcedureName(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(storedProcedureNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(storedProcedureNameAttr)
+	sql := fmt.Sprintf(
+		"ALTER PROCEDURE %s RENAME TO %s",
+		storedProcedureSignature(d, oldRaw.(string)),
+		pq.QuoteIdentifier(newRaw.(string)),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Stored Procedure NAME: %w", err)
+	}
+
+	return nil
+}
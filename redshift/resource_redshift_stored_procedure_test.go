@@ -0,0 +1,202 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftStoredProcedure_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_proc_schema"), "-", "_")
+	procedureName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_proc"), "-", "_")
+	procedureNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_proc_updated"), "-", "_")
+	config := func(name, security, argumentType, body string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_stored_procedure" "procedure" {
+  name     = %[2]q
+  schema   = redshift_schema.schema.name
+  security = %[3]q
+
+  argument {
+    name = "value"
+    type = %[4]q
+  }
+
+  body = <<-EOT
+%[5]s
+  EOT
+}
+`, schemaName, name, security, argumentType, body)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftStoredProcedureDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(procedureName, "INVOKER", "integer", "BEGIN\n  RAISE INFO 'value: %', value;\nEND;"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftStoredProcedureExists(schemaName, procedureName),
+					resource.TestCheckResourceAttr("redshift_stored_procedure.procedure", "name", procedureName),
+					resource.TestCheckResourceAttr("redshift_stored_procedure.procedure", "schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_stored_procedure.procedure", "security", "INVOKER"),
+				),
+			},
+			{
+				ResourceName:            "redshift_stored_procedure.procedure",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{storedProcedureArgumentAttr, storedProcedureBodyAttr},
+			},
+			// Only the formatting of the body changes
+			{
+				Config:   config(procedureName, "INVOKER", "integer", "BEGIN   RAISE INFO 'value: %', value; END;"),
+				PlanOnly: true,
+			},
+			{
+				Config: config(procedureNameUpdated, "DEFINER", "integer", "BEGIN\n  RAISE INFO 'updated value: %', value;\nEND;"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftStoredProcedureExists(schemaName, procedureNameUpdated),
+					resource.TestCheckResourceAttr("redshift_stored_procedure.procedure", "name", procedureNameUpdated),
+					resource.TestCheckResourceAttr("redshift_stored_procedure.procedure", "security", "DEFINER"),
+				),
+			},
+			// Changing the arguments replaces the procedure
+			{
+				Config: config(procedureNameUpdated, "DEFINER", "varchar(32)", "BEGIN\n  RAISE INFO 'updated value: %', value;\nEND;"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftStoredProcedureExists(schemaName, procedureNameUpdated),
+					resource.TestCheckResourceAttr("redshift_stored_procedure.procedure", "argument.0.type", "varchar(32)"),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateStoredProcedureQuery(t *testing.T) {
+	tests := map[string]struct {
+		input             map[string]interface{}
+		expectedQuery     string
+		expectedSignature string
+	}{
+		"without arguments": {
+			input: map[string]interface{}{
+				storedProcedureNameAttr:   "proc",
+				storedProcedureSchemaAttr: "analytics",
+				storedProcedureBodyAttr:   "BEGIN\n  NULL;\nEND;\n",
+			},
+			expectedQuery:     "CREATE OR REPLACE PROCEDURE \"analytics\".\"proc\"() AS $$\nBEGIN\n  NULL;\nEND;\n$$ LANGUAGE plpgsql SECURITY INVOKER",
+			expectedSignature: "\"analytics\".\"proc\"()",
+		},
+		"with arguments": {
+			input: map[string]interface{}{
+				storedProcedureNameAttr:     "proc",
+				storedProcedureSchemaAttr:   "analytics",
+				storedProcedureSecurityAttr: "DEFINER",
+				storedProcedureArgumentAttr: []interface{}{
+					map[string]interface{}{storedProcedureArgumentNameAttr: "since", storedProcedureArgumentTypeAttr: "date"},
+					map[string]interface{}{storedProcedureArgumentTypeAttr: "varchar(32)", storedProcedureArgumentModeAttr: "INOUT"},
+					map[string]interface{}{storedProcedureArgumentNameAttr: "total", storedProcedureArgumentTypeAttr: "integer", storedProcedureArgumentModeAttr: "OUT"},
+				},
+				storedProcedureBodyAttr: "BEGIN total := 1; END;",
+			},
+			expectedQuery:     "CREATE OR REPLACE PROCEDURE \"analytics\".\"proc\"(\"since\" IN date, INOUT varchar(32), \"total\" OUT integer) AS $$\nBEGIN total := 1; END;\n$$ LANGUAGE plpgsql SECURITY DEFINER",
+			expectedSignature: "\"analytics\".\"proc\"(date, varchar(32))",
+		},
+		"body containing dollar quotes": {
+			input: map[string]interface{}{
+				storedProcedureNameAttr:   "proc",
+				storedProcedureSchemaAttr: "analytics",
+				storedProcedureBodyAttr:   "BEGIN EXECUTE $$SELECT 1$$; END;",
+			},
+			expectedQuery:     "CREATE OR REPLACE PROCEDURE \"analytics\".\"proc\"() AS $body0$\nBEGIN EXECUTE $$SELECT 1$$; END;\n$body0$ LANGUAGE plpgsql SECURITY INVOKER",
+			expectedSignature: "\"analytics\".\"proc\"()",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftStoredProcedure().Schema, tc.input)
+			if query := createStoredProcedureQuery(d); query != tc.expectedQuery {
+				t.Errorf("Expected query %q, got %q", tc.expectedQuery, query)
+			}
+			if signature := storedProcedureSignature(d, d.Get(storedProcedureNameAttr).(string)); signature != tc.expectedSignature {
+				t.Errorf("Expected signature %q, got %q", tc.expectedSignature, signature)
+			}
+		})
+	}
+}
+
+func testAccCheckRedshiftStoredProcedureDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_stored_procedure" {
+			continue
+		}
+
+		exists, err := checkStoredProcedureExists(client, rs.Primary.Attributes[storedProcedureSchemaAttr], rs.Primary.Attributes[storedProcedureNameAttr])
+
+		if err != nil {
+			return fmt.Errorf("Error checking stored procedure %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Stored procedure still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftStoredProcedureExists(schemaName, procedureName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkStoredProcedureExists(client, schemaName, procedureName)
+		if err != nil {
+			return fmt.Errorf("Error checking stored procedure %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Stored procedure not found")
+		}
+
+		return nil
+	}
+}
+
+func checkStoredProcedureExists(client *Client, schemaName, procedureName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	err = db.QueryRow(`
+  SELECT 1
+  FROM pg_proc_info pr
+    JOIN pg_namespace nsp ON nsp.oid = pr.pronamespace
+  WHERE nsp.nspname = $1 AND pr.proname = $2 AND pr.prokind = 'p'
+  LIMIT 1
+`, strings.ToLower(schemaName), strings.ToLower(procedureName)).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about stored procedure: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,1411 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"maps"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	tableNameAttr           = "name"
+	tableSchemaAttr         = "schema"
+	tableColumnAttr         = "column"
+	tableColumnNameAttr     = "name"
+	tableColumnTypeAttr     = "type"
+	tableColumnEncodingAttr = "encoding"
+	tableColumnNullableAttr = "nullable"
+	tableColumnDefaultAttr  = "default"
+	tableColumnIdentityAttr = "identity"
+	tableDistStyleAttr      = "diststyle"
+	tableDistKeyAttr        = "distkey"
+	tableSortKeyAttr        = "sortkey"
+	tableSortKeyStyleAttr   = "sortkey_style"
+	tableEncodeAutoAttr     = "encode_auto"
+	tableIfNotExistsAttr    = "if_not_exists"
+	tableOwnerAttr          = "owner"
+	tablePrimaryKeyAttr     = "primary_key"
+	tableUniqueAttr         = "unique"
+	tableForeignKeyAttr     = "foreign_key"
+
+	tableConstraintNameAttr              = "name"
+	tableConstraintColumnsAttr           = "columns"
+	tableConstraintReferencesSchemaAttr  = "references_schema"
+	tableConstraintReferencesTableAttr   = "references_table"
+	tableConstraintReferencesColumnsAttr = "references_columns"
+
+	tableColumnIdentitySeedAttr               = "seed"
+	tableColumnIdentityStepAttr               = "step"
+	tableColumnIdentityGeneratedByDefaultAttr = "generated_by_default"
+)
+
+var tableDistStyles = []string{"AUTO", "EVEN", "KEY", "ALL"}
+
+var tableSortKeyStyles = []string{"COMPOUND", "INTERLEAVED", "AUTO"}
+
+// tableInterleavedSortKeyMaxColumns is the maximum number of columns of an
+// interleaved sort key allowed by Redshift.
+const tableInterleavedSortKeyMaxColumns = 8
+
+// tableColumnTypeAliases maps the type names accepted by CREATE TABLE to the
+// names returned by format_type().
+var tableColumnTypeAliases = map[string]string{
+	"int":         "integer",
+	"int4":        "integer",
+	"int2":        "smallint",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float":       "double precision",
+	"float8":      "double precision",
+	"bool":        "boolean",
+	"varchar":     "character varying",
+	"char":        "character",
+	"bpchar":      "character",
+	"nchar":       "character",
+	"nvarchar":    "character varying",
+	"decimal":     "numeric",
+	"text":        "character varying(256)",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+}
+
+var tableColumnTypeRegexp = regexp.MustCompile(`^([a-z0-9 ]+?)\s*(\(.*\))?$`)
+
+// tableColumnDefaultCastRegexp matches the type cast Redshift appends to column defaults.
+var tableColumnDefaultCastRegexp = regexp.MustCompile(`::[a-z ]+(\([0-9, ]+\))?$`)
+
+// tableColumnIdentityRegexp matches the defaults Redshift stores for IDENTITY
+// and GENERATED BY DEFAULT AS IDENTITY columns, e.g.
+// `"identity"(108187, 0, '1,1'::text)`, capturing the seed and the step.
+var tableColumnIdentityRegexp = regexp.MustCompile(`^("identity"|default_identity)\(\d+,\s*\d+,\s*'(-?\d+),(-?\d+)'(?:::text)?\)$`)
+
+func redshiftTable() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the definition of a table. Changing ` + "`schema`, `diststyle`, `distkey`, `sortkey` or `sortkey_style`" + ` forces the table to be recreated, which drops all of its data, except when ` + "`diststyle`" + ` was ` + "`AUTO`" + `: the distribution style and key chosen afterwards are then set in place with ` + "`ALTER TABLE ... ALTER DISTSTYLE`" + `.
+
+Columns appended to the end of the ` + "`column`" + ` list are added in place with ` + "`ALTER TABLE ... ADD COLUMN`" + `, except for identity columns, and removed columns are dropped in place with ` + "`ALTER TABLE ... DROP COLUMN`" + `. Changing the ` + "`encoding`" + ` of an existing column is done in place with ` + "`ALTER TABLE ... ALTER COLUMN ... ENCODE`" + `. Reordering columns, renaming a column or changing the ` + "`type`, `nullable`, `default` or `identity`" + ` of an existing column forces the table to be recreated.
+
+The ` + "`primary_key`, `unique` and `foreign_key`" + ` constraints are informational: Redshift doesn't enforce them, but the query planner uses them, so they must hold for the data or queries may return wrong results. They're changed in place with ` + "`ALTER TABLE ... ADD CONSTRAINT`" + ` and ` + "`ALTER TABLE ... DROP CONSTRAINT`" + `, without moving data.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftTableCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftTableRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftTableUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftTableDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftTableExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+				if d.Id() == "" || !d.HasChange(tableColumnAttr) {
+					return nil
+				}
+
+				oldColumns, newColumns := d.GetChange(tableColumnAttr)
+				if tableColumnsRequireReplacement(oldColumns.([]interface{}), newColumns.([]interface{})) {
+					return d.ForceNew(tableColumnAttr)
+				}
+
+				return nil
+			},
+			validateTableIdentityColumns,
+			validateTableSortKey,
+			validateTableEncodeAuto,
+			customizeTableDistribution,
+			validateSchemaReference(tableSchemaAttr),
+		),
+		Schema: map[string]*schema.Schema{
+			tableNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the table.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			tableSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the schema the table belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			tableColumnAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Columns of the table, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableColumnNameAttr: {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "Name of the column.",
+							DiffSuppressFunc: suppressIdentifierCaseDiff,
+						},
+						tableColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Data type of the column, e.g. `integer` or `varchar(256)`.",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeColumnType(old) == normalizeColumnType(new)
+							},
+						},
+						tableColumnEncodingAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Compression encoding of the column, e.g. `raw`, `az64` or `zstd`. When not set, Redshift chooses the encoding.",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						tableColumnNullableAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the column accepts NULL values.",
+						},
+						tableColumnDefaultAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Default value expression of the column. Conflicts with `identity`.",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return normalizeColumnDefault(old) == normalizeColumnDefault(new)
+							},
+						},
+						tableColumnIdentityAttr: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Makes the column an identity column, generating unique values with `IDENTITY(seed, step)`, or `GENERATED BY DEFAULT AS IDENTITY(seed, step)`. A table has at most one identity column, of type `integer` or `bigint`, which must set `nullable` to `false`. Redshift can't alter identity columns, so changing it forces the table to be recreated.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									tableColumnIdentitySeedAttr: {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     1,
+										Description: "The first value generated.",
+									},
+									tableColumnIdentityStepAttr: {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										ValidateFunc: validation.IntNotInSlice([]int{0}),
+										Description:  "The increment between the generated values.",
+									},
+									tableColumnIdentityGeneratedByDefaultAttr: {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Uses `GENERATED BY DEFAULT AS IDENTITY`, which allows inserting explicit values in the column.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			tableDistStyleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The data distribution style of the table (one of: " + strings.Join(tableDistStyles, ", ") + "). With `AUTO`, Redshift chooses the distribution style and changes it as the table grows. When not set, Redshift uses `AUTO`.",
+				ValidateFunc: validation.StringInSlice(tableDistStyles, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+			},
+			tableDistKeyAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Name of the column used as the distribution key. Requires `diststyle` to be `KEY` or not set. When `diststyle` is `AUTO`, it's the distribution key chosen by Redshift, if any.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			tableSortKeyAttr: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					DiffSuppressFunc: suppressIdentifierCaseDiff,
+				},
+				Description: "Names of the columns of the sort key, in order.",
+			},
+			tableSortKeyStyleAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The style of the sort key (one of: " + strings.Join(tableSortKeyStyles, ", ") + "). An `INTERLEAVED` sort key gives the same weight to each of its columns, and can have at most 8 columns. With `AUTO`, Redshift chooses the sort key, which requires `sortkey` not to be set. When not set, the sort key is `COMPOUND`, or `AUTO` when `sortkey` isn't set either.",
+				ValidateFunc: validation.StringInSlice(tableSortKeyStyles, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToUpper(val.(string))
+				},
+			},
+			tableEncodeAutoAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether Redshift chooses and changes the encodings of the columns, with `ENCODE AUTO`, which requires the `encoding` of the columns not to be set. Setting it to false requires the `encoding` of at least one column to be set: the encodings of all the columns are then set explicitly. When not set, Redshift uses `ENCODE AUTO` unless the encoding of a column is set.",
+			},
+			tableOwnerAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Name of the table owner. The table is created as this user with `SET LOCAL SESSION AUTHORIZATION`, so that it's owned by it from the start, which requires the user of the provider to be a superuser and the owner to be allowed to create tables in the schema. Changing it transfers the ownership of the table with `ALTER TABLE ... OWNER TO`. When not set, the table is owned by the user of the provider.",
+				DiffSuppressFunc: ownerDiffSuppress,
+			},
+			tablePrimaryKeyAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The primary key of the table. It's not enforced by Redshift.",
+				Elem: &schema.Resource{
+					Schema: tableConstraintSchema(),
+				},
+			},
+			tableUniqueAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The unique constraints of the table. They're not enforced by Redshift.",
+				Elem: &schema.Resource{
+					Schema: tableConstraintSchema(),
+				},
+			},
+			tableForeignKeyAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The foreign keys of the table, referencing the primary key or a unique constraint of another table. They're not enforced by Redshift.",
+				Elem: &schema.Resource{
+					Schema: tableForeignKeySchema(),
+				},
+			},
+			tableIfNotExistsAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Creates the table with `CREATE TABLE IF NOT EXISTS`, adopting an existing table with the same name into the state instead of failing. Terraform then manages the existing table as if it had created it: the differences from the configuration show up in the next plan, which may replace the table and drop its data, and destroying the resource drops the table. It's only used when the table is created.",
+			},
+		},
+	}
+}
+
+func tableConstraintSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		tableConstraintNameAttr: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The name of the constraint. When not set, Redshift generates it.",
+			StateFunc: func(val interface{}) string {
+				return strings.ToLower(val.(string))
+			},
+		},
+		tableConstraintColumnsAttr: {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Description: "The columns of the constraint, in order.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func tableForeignKeySchema() map[string]*schema.Schema {
+	foreignKeySchema := tableConstraintSchema()
+	foreignKeySchema[tableConstraintReferencesSchemaAttr] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		Description:      "The schema of the referenced table. When not set, it's the schema of the table.",
+		DiffSuppressFunc: suppressIdentifierCaseDiff,
+	}
+	foreignKeySchema[tableConstraintReferencesTableAttr] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "The name of the referenced table.",
+		DiffSuppressFunc: suppressIdentifierCaseDiff,
+	}
+	foreignKeySchema[tableConstraintReferencesColumnsAttr] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "The referenced columns, in the order of `columns`.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	return foreignKeySchema
+}
+
+// tableConstraintTypes are the constraint attributes with their type, as in
+// SQL and in information_schema.table_constraints, in the order in which the
+// constraints are added. Foreign keys are added last, as they may reference
+// the other constraints.
+var tableConstraintTypes = []struct {
+	attr           string
+	constraintType string
+}{
+	{tablePrimaryKeyAttr, "PRIMARY KEY"},
+	{tableUniqueAttr, "UNIQUE"},
+	{tableForeignKeyAttr, "FOREIGN KEY"},
+}
+
+func tableConstraintIdentList(raw interface{}) string {
+	identifiers := []string{}
+	for _, identifier := range raw.([]interface{}) {
+		identifiers = append(identifiers, pq.QuoteIdentifier(identifier.(string)))
+	}
+	return strings.Join(identifiers, ", ")
+}
+
+// tableConstraintDefinition renders the constraint, without its name. Foreign
+// keys reference a table of the same schema unless references_schema is set.
+func tableConstraintDefinition(constraintType string, constraint map[string]interface{}, tableSchema string) string {
+	definition := fmt.Sprintf("%s (%s)", constraintType, tableConstraintIdentList(constraint[tableConstraintColumnsAttr]))
+	if constraintType != "FOREIGN KEY" {
+		return definition
+	}
+
+	referencesSchema, _ := constraint[tableConstraintReferencesSchemaAttr].(string)
+	if referencesSchema == "" {
+		referencesSchema = tableSchema
+	}
+	return fmt.Sprintf(
+		"%s REFERENCES %s.%s (%s)",
+		definition,
+		pq.QuoteIdentifier(referencesSchema),
+		pq.QuoteIdentifier(constraint[tableConstraintReferencesTableAttr].(string)),
+		tableConstraintIdentList(constraint[tableConstraintReferencesColumnsAttr]),
+	)
+}
+
+// tableConstraintSQL renders the constraint as in CREATE TABLE and ALTER
+// TABLE ... ADD, named when its name is known.
+func tableConstraintSQL(constraintType string, constraint map[string]interface{}, tableSchema string) string {
+	definition := tableConstraintDefinition(constraintType, constraint, tableSchema)
+	if name, _ := constraint[tableConstraintNameAttr].(string); name != "" {
+		return fmt.Sprintf("CONSTRAINT %s %s", pq.QuoteIdentifier(name), definition)
+	}
+	return definition
+}
+
+// tableConstraintKey identifies a constraint by its definition, as names are
+// generated by Redshift when they're not configured.
+func tableConstraintKey(constraintType string, constraint map[string]interface{}, tableSchema string) string {
+	return strings.ToLower(tableConstraintDefinition(constraintType, constraint, tableSchema))
+}
+
+// tableConstraintsQueries returns the statements dropping the constraints
+// removed from the configuration, and the ones adding the new constraints.
+// Constraints whose definition or configured name changes are dropped and
+// added again.
+func tableConstraintsQueries(d resourceValues) ([]string, []string) {
+	tableSchema := d.Get(tableSchemaAttr).(string)
+	tableIdent := fmt.Sprintf("%s.%s", pq.QuoteIdentifier(tableSchema), pq.QuoteIdentifier(d.Get(tableNameAttr).(string)))
+
+	drops, adds := []string{}, []string{}
+	for _, constraintType := range tableConstraintTypes {
+		if !d.HasChange(constraintType.attr) {
+			continue
+		}
+		oldRaw, newRaw := d.GetChange(constraintType.attr)
+
+		matched := map[int]bool{}
+		typeDrops := []string{}
+		for _, rawOld := range oldRaw.([]interface{}) {
+			oldConstraint := rawOld.(map[string]interface{})
+			oldKey := tableConstraintKey(constraintType.constraintType, oldConstraint, tableSchema)
+			found := false
+			for i, rawNew := range newRaw.([]interface{}) {
+				newConstraint := rawNew.(map[string]interface{})
+				newName := newConstraint[tableConstraintNameAttr].(string)
+				if matched[i] || tableConstraintKey(constraintType.constraintType, newConstraint, tableSchema) != oldKey || (newName != "" && !strings.EqualFold(newName, oldConstraint[tableConstraintNameAttr].(string))) {
+					continue
+				}
+				matched[i], found = true, true
+				break
+			}
+			if !found {
+				typeDrops = append(typeDrops, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tableIdent, pq.QuoteIdentifier(oldConstraint[tableConstraintNameAttr].(string))))
+			}
+		}
+		// Foreign keys are dropped first, as they may depend on the other constraints.
+		drops = append(typeDrops, drops...)
+
+		for i, rawNew := range newRaw.([]interface{}) {
+			if matched[i] {
+				continue
+			}
+			adds = append(adds, fmt.Sprintf("ALTER TABLE %s ADD %s", tableIdent, tableConstraintSQL(constraintType.constraintType, rawNew.(map[string]interface{}), tableSchema)))
+		}
+	}
+
+	return drops, adds
+}
+
+// tableConstraintRow is a column of a constraint, as read from the
+// information_schema views.
+type tableConstraintRow struct {
+	name             string
+	constraintType   string
+	column           string
+	referencesSchema string
+	referencesTable  string
+	referencesColumn string
+}
+
+// tableConstraintsFromRows groups the columns of the constraints, ordered by
+// constraint and position, into the blocks of each constraint attribute. The
+// constraints matching a configured one come in the configured order, so that
+// reading them doesn't show a diff.
+func tableConstraintsFromRows(rows []tableConstraintRow, d resourceValues) map[string][]interface{} {
+	attrs := map[string]string{}
+	for _, constraintType := range tableConstraintTypes {
+		attrs[constraintType.constraintType] = constraintType.attr
+	}
+
+	read := map[string][]interface{}{}
+	byName := map[string]map[string]interface{}{}
+	for _, row := range rows {
+		attr, ok := attrs[row.constraintType]
+		if !ok {
+			continue
+		}
+		constraint, ok := byName[row.name]
+		if !ok {
+			constraint = map[string]interface{}{
+				tableConstraintNameAttr:    row.name,
+				tableConstraintColumnsAttr: []interface{}{},
+			}
+			if attr == tableForeignKeyAttr {
+				constraint[tableConstraintReferencesSchemaAttr] = row.referencesSchema
+				constraint[tableConstraintReferencesTableAttr] = row.referencesTable
+				constraint[tableConstraintReferencesColumnsAttr] = []interface{}{}
+			}
+			byName[row.name] = constraint
+			read[attr] = append(read[attr], constraint)
+		}
+
+		constraint[tableConstraintColumnsAttr] = append(constraint[tableConstraintColumnsAttr].([]interface{}), row.column)
+		if attr == tableForeignKeyAttr {
+			constraint[tableConstraintReferencesColumnsAttr] = append(constraint[tableConstraintReferencesColumnsAttr].([]interface{}), row.referencesColumn)
+		}
+	}
+
+	tableSchema := d.Get(tableSchemaAttr).(string)
+	constraints := map[string][]interface{}{}
+	for _, constraintType := range tableConstraintTypes {
+		position := map[string]int{}
+		for i, raw := range d.Get(constraintType.attr).([]interface{}) {
+			position[tableConstraintKey(constraintType.constraintType, raw.(map[string]interface{}), tableSchema)] = i
+		}
+
+		ordered := append([]interface{}{}, read[constraintType.attr]...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			pi, ok := position[tableConstraintKey(constraintType.constraintType, ordered[i].(map[string]interface{}), tableSchema)]
+			if !ok {
+				pi = len(position)
+			}
+			pj, ok := position[tableConstraintKey(constraintType.constraintType, ordered[j].(map[string]interface{}), tableSchema)]
+			if !ok {
+				pj = len(position)
+			}
+			return pi < pj
+		})
+		constraints[constraintType.attr] = ordered
+	}
+
+	return constraints
+}
+
+// tableColumnsRequireReplacement checks if the columns can be changed in place.
+// Only dropping columns, appending new ones to the end of the list and
+// changing encodings is supported, every other change requires the table to
+// be recreated.
+func tableColumnsRequireReplacement(oldColumns, newColumns []interface{}) bool {
+	oldByName := map[string]map[string]interface{}{}
+	oldOrder := map[string]int{}
+	for i, raw := range oldColumns {
+		column := raw.(map[string]interface{})
+		name := strings.ToLower(column[tableColumnNameAttr].(string))
+		oldByName[name] = column
+		oldOrder[name] = i
+	}
+
+	lastOldIndex := -1
+	appending := false
+	for _, raw := range newColumns {
+		column := raw.(map[string]interface{})
+		oldColumn, ok := oldByName[strings.ToLower(column[tableColumnNameAttr].(string))]
+		if !ok {
+			// ALTER TABLE ... ADD COLUMN doesn't support identity columns.
+			if tableColumnIdentityOf(column) != nil {
+				return true
+			}
+			appending = true
+			continue
+		}
+
+		// Existing columns can't follow new ones nor change their relative order.
+		index := oldOrder[strings.ToLower(column[tableColumnNameAttr].(string))]
+		if appending || index < lastOldIndex {
+			return true
+		}
+		lastOldIndex = index
+
+		if !tableColumnsEqual(oldColumn, column) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tableColumnsEqual(oldColumn, newColumn map[string]interface{}) bool {
+	if normalizeColumnType(oldColumn[tableColumnTypeAttr].(string)) != normalizeColumnType(newColumn[tableColumnTypeAttr].(string)) {
+		return false
+	}
+
+	if oldColumn[tableColumnNullableAttr].(bool) != newColumn[tableColumnNullableAttr].(bool) {
+		return false
+	}
+
+	if !reflect.DeepEqual(tableColumnIdentityOf(oldColumn), tableColumnIdentityOf(newColumn)) {
+		return false
+	}
+
+	return normalizeColumnDefault(oldColumn[tableColumnDefaultAttr].(string)) == normalizeColumnDefault(newColumn[tableColumnDefaultAttr].(string))
+}
+
+// tableColumnIdentity is the identity of a column.
+type tableColumnIdentity struct {
+	seed               int
+	step               int
+	generatedByDefault bool
+}
+
+// tableColumnIdentityOf returns the identity of the column, or nil when it
+// isn't an identity column.
+func tableColumnIdentityOf(column map[string]interface{}) *tableColumnIdentity {
+	identities, _ := column[tableColumnIdentityAttr].([]interface{})
+	if len(identities) == 0 || identities[0] == nil {
+		return nil
+	}
+
+	identity := identities[0].(map[string]interface{})
+	return &tableColumnIdentity{
+		seed:               identity[tableColumnIdentitySeedAttr].(int),
+		step:               identity[tableColumnIdentityStepAttr].(int),
+		generatedByDefault: identity[tableColumnIdentityGeneratedByDefaultAttr].(bool),
+	}
+}
+
+// parseTableColumnIdentity parses the default Redshift stores for identity
+// columns into an identity block, which is empty for other defaults.
+func parseTableColumnIdentity(defaultValue string) []interface{} {
+	matches := tableColumnIdentityRegexp.FindStringSubmatch(defaultValue)
+	if matches == nil {
+		return []interface{}{}
+	}
+
+	seed, _ := strconv.Atoi(matches[2])
+	step, _ := strconv.Atoi(matches[3])
+	return []interface{}{
+		map[string]interface{}{
+			tableColumnIdentitySeedAttr:               seed,
+			tableColumnIdentityStepAttr:               step,
+			tableColumnIdentityGeneratedByDefaultAttr: matches[1] == "default_identity",
+		},
+	}
+}
+
+// validateTableIdentityColumns checks that there's at most one identity
+// column, and that it's a non nullable integer without default.
+func validateTableIdentityColumns(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(tableColumnAttr) {
+		return nil
+	}
+
+	identityColumn := ""
+	for _, raw := range d.Get(tableColumnAttr).([]interface{}) {
+		column := raw.(map[string]interface{})
+		if tableColumnIdentityOf(column) == nil {
+			continue
+		}
+
+		name := column[tableColumnNameAttr].(string)
+		if identityColumn != "" {
+			return fmt.Errorf("only one column can be an identity column, both %s and %s are", identityColumn, name)
+		}
+		identityColumn = name
+
+		if columnType := normalizeColumnType(column[tableColumnTypeAttr].(string)); columnType != "integer" && columnType != "bigint" {
+			return fmt.Errorf("identity column %s must be of type integer or bigint, not %s", name, column[tableColumnTypeAttr])
+		}
+		if column[tableColumnNullableAttr].(bool) {
+			return fmt.Errorf("identity column %s must set %s to false", name, tableColumnNullableAttr)
+		}
+		if column[tableColumnDefaultAttr].(string) != "" {
+			return fmt.Errorf("identity column %s can't have a %s", name, tableColumnDefaultAttr)
+		}
+	}
+
+	return nil
+}
+
+// validateTableSortKey checks that an interleaved sort key has columns, and no
+// more than Redshift allows, and that an automatic one has none.
+func validateTableSortKey(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	sortKeyStyle := strings.ToUpper(d.Get(tableSortKeyStyleAttr).(string))
+	// The computed sort key isn't known when it's not configured.
+	rawConfig := d.GetRawConfig()
+	if sortKeyStyle == "AUTO" && !rawConfig.IsNull() && rawConfig.IsKnown() && !rawConfig.GetAttr(tableSortKeyAttr).IsNull() {
+		return fmt.Errorf("%s AUTO requires %s not to be set", tableSortKeyStyleAttr, tableSortKeyAttr)
+	}
+	if sortKeyStyle != "INTERLEAVED" {
+		return nil
+	}
+
+	if !rawConfig.IsNull() && rawConfig.IsKnown() && rawConfig.GetAttr(tableSortKeyAttr).IsNull() {
+		return fmt.Errorf("%s INTERLEAVED requires %s to be set", tableSortKeyStyleAttr, tableSortKeyAttr)
+	}
+	if !d.NewValueKnown(tableSortKeyAttr) {
+		return nil
+	}
+	if columns := len(d.Get(tableSortKeyAttr).([]interface{})); columns > tableInterleavedSortKeyMaxColumns {
+		return fmt.Errorf("an interleaved sort key can have at most %d columns, got %d", tableInterleavedSortKeyMaxColumns, columns)
+	}
+
+	return nil
+}
+
+// validateTableEncodeAuto checks that the column encodings are set when
+// encode_auto is configured to false only. Like the sort key, the encodings
+// are computed, so the configuration tells which ones are set.
+func validateTableEncodeAuto(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsWhollyKnown() {
+		return nil
+	}
+	encodeAuto := rawConfig.GetAttr(tableEncodeAutoAttr)
+	if encodeAuto.IsNull() {
+		return nil
+	}
+
+	encodings := 0
+	if columns := rawConfig.GetAttr(tableColumnAttr); !columns.IsNull() {
+		for it := columns.ElementIterator(); it.Next(); {
+			_, column := it.Element()
+			if column.GetAttr(tableColumnEncodingAttr).IsNull() {
+				continue
+			}
+			if encodeAuto.True() {
+				return fmt.Errorf("the %s of column %s can't be set when %s is true", tableColumnEncodingAttr, column.GetAttr(tableColumnNameAttr).AsString(), tableEncodeAutoAttr)
+			}
+			encodings++
+		}
+	}
+	if encodeAuto.False() && encodings == 0 {
+		return fmt.Errorf("%s can only be false when the %s of a column is set", tableEncodeAutoAttr, tableColumnEncodingAttr)
+	}
+
+	return nil
+}
+
+// customizeTableDistribution replaces the table when its distribution style or
+// key changes, unless the distribution style was AUTO: Redshift then changes
+// the distribution in place. Pinning a distribution key on an AUTO table plans
+// the KEY distribution style that Redshift switches to, and choosing EVEN or
+// ALL removes the distribution key Redshift may have chosen.
+func customizeTableDistribution(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChanges(tableDistStyleAttr, tableDistKeyAttr) {
+		return nil
+	}
+
+	oldDistStyle, newDistStyle := d.GetChange(tableDistStyleAttr)
+	if strings.ToUpper(oldDistStyle.(string)) != "AUTO" {
+		for _, attr := range []string{tableDistStyleAttr, tableDistKeyAttr} {
+			if d.HasChange(attr) {
+				if err := d.ForceNew(attr); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	switch strings.ToUpper(newDistStyle.(string)) {
+	case "AUTO":
+		if d.HasChange(tableDistKeyAttr) && d.NewValueKnown(tableDistKeyAttr) && d.Get(tableDistKeyAttr).(string) != "" {
+			return d.SetNew(tableDistStyleAttr, "KEY")
+		}
+	case "KEY":
+		if d.NewValueKnown(tableDistKeyAttr) && d.Get(tableDistKeyAttr).(string) == "" {
+			return fmt.Errorf("%s KEY requires %s to be set", tableDistStyleAttr, tableDistKeyAttr)
+		}
+	case "EVEN", "ALL":
+		// The distribution key Redshift may have chosen goes away.
+		if !d.HasChange(tableDistKeyAttr) && d.Get(tableDistKeyAttr).(string) != "" {
+			return d.SetNew(tableDistKeyAttr, "")
+		}
+	}
+
+	return nil
+}
+
+// tableColumnEncodingChanges returns the new encodings of the existing columns
+// whose encoding has changed, keyed by column name.
+func tableColumnEncodingChanges(oldColumns, newColumns []interface{}) map[string]string {
+	oldEncodings := map[string]string{}
+	for _, raw := range oldColumns {
+		column := raw.(map[string]interface{})
+		oldEncodings[strings.ToLower(column[tableColumnNameAttr].(string))] = normalizeColumnEncoding(column[tableColumnEncodingAttr].(string))
+	}
+
+	changes := map[string]string{}
+	for _, raw := range newColumns {
+		column := raw.(map[string]interface{})
+		name := column[tableColumnNameAttr].(string)
+		oldEncoding, ok := oldEncodings[strings.ToLower(name)]
+		// An empty encoding means it's chosen by Redshift.
+		newEncoding := normalizeColumnEncoding(column[tableColumnEncodingAttr].(string))
+		if !ok || newEncoding == "" || newEncoding == oldEncoding {
+			continue
+		}
+		changes[name] = newEncoding
+	}
+
+	return changes
+}
+
+func normalizeColumnType(columnType string) string {
+	columnType = strings.Join(strings.Fields(strings.ToLower(columnType)), " ")
+
+	matches := tableColumnTypeRegexp.FindStringSubmatch(columnType)
+	if matches == nil {
+		return columnType
+	}
+
+	name, modifier := matches[1], strings.ReplaceAll(matches[2], " ", "")
+	if alias, ok := tableColumnTypeAliases[name]; ok {
+		name = alias
+	}
+
+	return name + modifier
+}
+
+func normalizeColumnDefault(value string) string {
+	return tableColumnDefaultCastRegexp.ReplaceAllString(strings.TrimSpace(value), "")
+}
+
+func normalizeColumnEncoding(encoding string) string {
+	encoding = strings.ToLower(encoding)
+	// format_encoding() reports the RAW encoding as "none".
+	if encoding == "none" {
+		return "raw"
+	}
+	return encoding
+}
+
+func tableDistStyleFromCode(code int) string {
+	switch code {
+	case 0:
+		return "EVEN"
+	case 1:
+		return "KEY"
+	case 8:
+		return "ALL"
+	default:
+		return "AUTO"
+	}
+}
+
+func tableColumnDefinition(column map[string]interface{}) string {
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column[tableColumnNameAttr].(string)), column[tableColumnTypeAttr].(string))
+
+	if defaultValue := column[tableColumnDefaultAttr].(string); defaultValue != "" {
+		definition = fmt.Sprintf("%s DEFAULT %s", definition, defaultValue)
+	}
+
+	if identity := tableColumnIdentityOf(column); identity != nil {
+		clause := "IDENTITY"
+		if identity.generatedByDefault {
+			clause = "GENERATED BY DEFAULT AS IDENTITY"
+		}
+		definition = fmt.Sprintf("%s %s(%d, %d)", definition, clause, identity.seed, identity.step)
+	}
+
+	if encoding := column[tableColumnEncodingAttr].(string); encoding != "" {
+		definition = fmt.Sprintf("%s ENCODE %s", definition, strings.ToUpper(encoding))
+	}
+
+	if !column[tableColumnNullableAttr].(bool) {
+		definition = fmt.Sprintf("%s NOT NULL", definition)
+	}
+
+	return definition
+}
+
+func createTableQuery(d *schema.ResourceData) string {
+	encodeAuto := d.Get(tableEncodeAutoAttr).(bool)
+	columns := []string{}
+	for _, raw := range d.Get(tableColumnAttr).([]interface{}) {
+		column := raw.(map[string]interface{})
+		// Redshift chooses the encodings with ENCODE AUTO.
+		if encodeAuto {
+			column = maps.Clone(column)
+			column[tableColumnEncodingAttr] = ""
+		}
+		columns = append(columns, tableColumnDefinition(column))
+	}
+	for _, constraintType := range tableConstraintTypes {
+		for _, constraint := range d.Get(constraintType.attr).([]interface{}) {
+			columns = append(columns, tableConstraintSQL(constraintType.constraintType, constraint.(map[string]interface{}), d.Get(tableSchemaAttr).(string)))
+		}
+	}
+
+	create := "CREATE TABLE"
+	if d.Get(tableIfNotExistsAttr).(bool) {
+		create = "CREATE TABLE IF NOT EXISTS"
+	}
+
+	query := fmt.Sprintf(
+		"%s %s.%s (%s)",
+		create,
+		pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(tableNameAttr).(string)),
+		strings.Join(columns, ", "),
+	)
+
+	if distStyle, ok := d.GetOk(tableDistStyleAttr); ok {
+		query = fmt.Sprintf("%s DISTSTYLE %s", query, strings.ToUpper(distStyle.(string)))
+	}
+
+	if distKey, ok := d.GetOk(tableDistKeyAttr); ok {
+		query = fmt.Sprintf("%s DISTKEY(%s)", query, pq.QuoteIdentifier(distKey.(string)))
+	}
+
+	if strings.ToUpper(d.Get(tableSortKeyStyleAttr).(string)) == "AUTO" {
+		query = fmt.Sprintf("%s SORTKEY AUTO", query)
+	} else if sortKey, ok := d.GetOk(tableSortKeyAttr); ok {
+		sortKeyColumns := []string{}
+		for _, column := range sortKey.([]interface{}) {
+			sortKeyColumns = append(sortKeyColumns, pq.QuoteIdentifier(column.(string)))
+		}
+		sortKeyStyle := ""
+		if strings.ToUpper(d.Get(tableSortKeyStyleAttr).(string)) == "INTERLEAVED" {
+			sortKeyStyle = " INTERLEAVED"
+		}
+		query = fmt.Sprintf("%s%s SORTKEY(%s)", query, sortKeyStyle, strings.Join(sortKeyColumns, ", "))
+	}
+
+	if encodeAuto {
+		query = fmt.Sprintf("%s ENCODE AUTO", query)
+	}
+
+	return query
+}
+
+func resourceRedshiftTableExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT relname FROM pg_class WHERE oid = $1 AND relkind = 'r'", d.Id()).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftTableRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftTableReadImpl(db, d)
+}
+
+func resourceRedshiftTableReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var (
+		tableName     string
+		schemaName    string
+		owner         string
+		distStyleCode int
+	)
+
+	// SVV_TABLE_INFO doesn't list empty tables, so the distribution style is read from pg_class.
+	err := db.QueryRow(`
+  SELECT cl.relname, nsp.nspname, COALESCE(u.usename, ''), cl.reldiststyle
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+    LEFT JOIN pg_user_info u ON u.usesysid = cl.relowner
+  WHERE cl.oid = $1 AND cl.relkind = 'r'
+`, d.Id()).Scan(&tableName, &schemaName, &owner, &distStyleCode)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift Table (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Table: %w", err)
+	}
+
+	// PG_TABLE_DEF only lists tables from schemas in the search_path, so the
+	// column definitions are read from the catalog tables it's built on.
+	rows, err := db.Query(`
+  SELECT
+    a.attname,
+    format_type(a.atttypid, a.atttypmod),
+    format_encoding(a.attencodingtype::integer),
+    a.attnotnull,
+    a.attisdistkey,
+    a.attsortkeyord,
+    COALESCE(ad.adsrc, '')
+  FROM pg_attribute a
+    LEFT JOIN pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+  WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+  ORDER BY a.attnum
+`, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading Table columns: %w", err)
+	}
+	defer rows.Close()
+
+	configuredColumns := map[string]map[string]interface{}{}
+	for _, raw := range d.Get(tableColumnAttr).([]interface{}) {
+		column := raw.(map[string]interface{})
+		configuredColumns[strings.ToLower(column[tableColumnNameAttr].(string))] = column
+	}
+
+	columns := []map[string]interface{}{}
+	sortKey := map[int]string{}
+	sortKeyStyle := "COMPOUND"
+	distKey := ""
+	for rows.Next() {
+		var (
+			columnName, columnType, encoding, defaultValue string
+			notNull, isDistKey                             bool
+			sortKeyOrd                                     int
+		)
+		if err := rows.Scan(&columnName, &columnType, &encoding, &notNull, &isDistKey, &sortKeyOrd, &defaultValue); err != nil {
+			return err
+		}
+
+		// The identity is stored as the default of the column.
+		identity := parseTableColumnIdentity(defaultValue)
+		if len(identity) > 0 {
+			defaultValue = ""
+		}
+
+		// Keep the configured spelling of equivalent values to avoid spurious diffs.
+		if configured, ok := configuredColumns[strings.ToLower(columnName)]; ok {
+			if normalizeColumnType(configured[tableColumnTypeAttr].(string)) == normalizeColumnType(columnType) {
+				columnType = configured[tableColumnTypeAttr].(string)
+			}
+			if normalizeColumnDefault(configured[tableColumnDefaultAttr].(string)) == normalizeColumnDefault(defaultValue) {
+				defaultValue = configured[tableColumnDefaultAttr].(string)
+			}
+		}
+
+		columns = append(columns, map[string]interface{}{
+			tableColumnNameAttr:     columnName,
+			tableColumnTypeAttr:     columnType,
+			tableColumnEncodingAttr: normalizeColumnEncoding(encoding),
+			tableColumnNullableAttr: !notNull,
+			tableColumnDefaultAttr:  defaultValue,
+			tableColumnIdentityAttr: identity,
+		})
+
+		if isDistKey {
+			distKey = columnName
+		}
+		// The columns of an interleaved sort key have a negative order.
+		if sortKeyOrd > 0 {
+			sortKey[sortKeyOrd] = columnName
+		} else if sortKeyOrd < 0 {
+			sortKey[-sortKeyOrd] = columnName
+			sortKeyStyle = "INTERLEAVED"
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	sortKeyColumns := []string{}
+	for i := 1; i <= len(sortKey); i++ {
+		sortKeyColumns = append(sortKeyColumns, sortKey[i])
+	}
+
+	// Whether Redshift manages the encodings and the sort key is only shown
+	// by SVV_TABLE_INFO, which doesn't list empty tables: the values in the
+	// state are kept for them.
+	encodeAuto := d.Get(tableEncodeAutoAttr).(bool)
+	if strings.ToUpper(d.Get(tableSortKeyStyleAttr).(string)) == "AUTO" && sortKeyStyle == "COMPOUND" {
+		sortKeyStyle = "AUTO"
+	}
+	var encoded, sortKey1 string
+	err = db.QueryRow("SELECT COALESCE(encoded, ''), COALESCE(sortkey1, '') FROM svv_table_info WHERE table_id = $1", d.Id()).Scan(&encoded, &sortKey1)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return fmt.Errorf("Error reading Table info: %w", err)
+	default:
+		encodeAuto = strings.Contains(encoded, "AUTO(ENCODE)")
+		if strings.HasPrefix(sortKey1, "AUTO(SORTKEY") {
+			sortKeyStyle = "AUTO"
+		} else if sortKeyStyle == "AUTO" {
+			sortKeyStyle = "COMPOUND"
+		}
+	}
+
+	constraints, err := readTableConstraints(db, d, schemaName, tableName)
+	if err != nil {
+		return err
+	}
+
+	d.Set(tableNameAttr, tableName)
+	d.Set(tableSchemaAttr, schemaName)
+	d.Set(tableOwnerAttr, owner)
+	d.Set(tableColumnAttr, columns)
+	d.Set(tableDistStyleAttr, tableDistStyleFromCode(distStyleCode))
+	d.Set(tableDistKeyAttr, distKey)
+	d.Set(tableSortKeyAttr, sortKeyColumns)
+	d.Set(tableSortKeyStyleAttr, sortKeyStyle)
+	d.Set(tableEncodeAutoAttr, encodeAuto)
+	for _, constraintType := range tableConstraintTypes {
+		d.Set(constraintType.attr, constraints[constraintType.attr])
+	}
+
+	return nil
+}
+
+// readTableConstraints reads the constraints of the table. The referenced
+// columns of foreign keys are the columns of the referenced primary key or
+// unique constraint at the same position.
+func readTableConstraints(db *DBConnection, d *schema.ResourceData, schemaName, tableName string) (map[string][]interface{}, error) {
+	rows, err := db.Query(`
+  SELECT
+    tc.constraint_name,
+    tc.constraint_type,
+    kcu.column_name,
+    COALESCE(ref.table_schema, ''),
+    COALESCE(ref.table_name, ''),
+    COALESCE(ref.column_name, '')
+  FROM information_schema.table_constraints tc
+    JOIN information_schema.key_column_usage kcu
+      ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+    LEFT JOIN information_schema.referential_constraints rc
+      ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+    LEFT JOIN information_schema.key_column_usage ref
+      ON ref.constraint_schema = rc.unique_constraint_schema AND ref.constraint_name = rc.unique_constraint_name AND ref.ordinal_position = kcu.ordinal_position
+  WHERE tc.table_schema = $1 AND tc.table_name = $2 AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+  ORDER BY tc.constraint_name, kcu.ordinal_position
+`, schemaName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading Table constraints: %w", err)
+	}
+	defer rows.Close()
+
+	constraintRows := []tableConstraintRow{}
+	for rows.Next() {
+		var row tableConstraintRow
+		if err := rows.Scan(&row.name, &row.constraintType, &row.column, &row.referencesSchema, &row.referencesTable, &row.referencesColumn); err != nil {
+			return nil, err
+		}
+		constraintRows = append(constraintRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tableConstraintsFromRows(constraintRows, d), nil
+}
+
+func resourceRedshiftTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if owner, ok := d.GetOk(tableOwnerAttr); ok {
+		if err := runAsOwner(tx, owner.(string)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(createTableQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift table: %w", createObjectError(err, "redshift_table"))
+	}
+
+	names, err := storedIdentifiers(tx, d.Get(tableSchemaAttr).(string), d.Get(tableNameAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	var tableOID string
+	query := `
+  SELECT cl.oid
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2 AND cl.relkind = 'r'
+`
+	if err := tx.QueryRow(query, names[0], names[1]).Scan(&tableOID); err != nil {
+		return fmt.Errorf("Could not get redshift table oid: %w", err)
+	}
+
+	d.SetId(tableOID)
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftTableReadImpl(db, d)
+}
+
+func resourceRedshiftTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := fmt.Sprintf("DROP TABLE %s.%s", pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(tableNameAttr).(string)))
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setTableName(tx, d); err != nil {
+		return err
+	}
+
+	// The constraints are dropped before the columns they may use, and added
+	// after the new columns.
+	dropConstraints, addConstraints := tableConstraintsQueries(d)
+	for _, query := range dropConstraints {
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("Error dropping constraint with %q: %w", query, err)
+		}
+	}
+
+	if err := setTableColumns(tx, d); err != nil {
+		return err
+	}
+
+	for _, query := range addConstraints {
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("Error adding constraint with %q: %w", query, err)
+		}
+	}
+
+	if err := setTableOwner(tx, d); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	if err := setTableColumnEncodings(db, d); err != nil {
+		return err
+	}
+
+	if err := setTableEncodeAuto(db, d); err != nil {
+		return err
+	}
+
+	if err := setTableDistribution(db, d); err != nil {
+		return err
+	}
+
+	return resourceRedshiftTableReadImpl(db, d)
+}
+
+func setTableName(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(tableNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(tableNameAttr)
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s.%s RENAME TO %s",
+		pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)),
+		pq.QuoteIdentifier(oldRaw.(string)),
+		pq.QuoteIdentifier(newRaw.(string)),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Table NAME: %w", err)
+	}
+
+	return nil
+}
+
+func setTableOwner(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(tableOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(tableOwnerAttr).(string)
+	if err := checkOwnerExists(tx, owner); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s.%s OWNER TO %s",
+		pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(tableNameAttr).(string)),
+		pq.QuoteIdentifier(owner),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Table OWNER: %w", err)
+	}
+
+	return nil
+}
+
+// setTableColumnEncodings alters the encoding of the existing columns. It's run
+// outside of the update transaction as ALTER COLUMN ... ENCODE can't be run
+// inside a transaction block.
+func setTableColumnEncodings(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(tableColumnAttr) {
+		return nil
+	}
+
+	tableIdent := fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(tableNameAttr).(string)))
+	oldRaw, newRaw := d.GetChange(tableColumnAttr)
+
+	for name, encoding := range tableColumnEncodingChanges(oldRaw.([]interface{}), newRaw.([]interface{})) {
+		sql := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ENCODE %s", tableIdent, pq.QuoteIdentifier(name), strings.ToUpper(encoding))
+		if _, err := db.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating encoding of column %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setTableEncodeAuto switches the table to ENCODE AUTO, or out of it by
+// setting the encodings of all the columns explicitly.
+func setTableEncodeAuto(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(tableEncodeAutoAttr) {
+		return nil
+	}
+
+	tableIdent := fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(tableNameAttr).(string)))
+	queries := []string{}
+	if d.Get(tableEncodeAutoAttr).(bool) {
+		queries = append(queries, fmt.Sprintf("ALTER TABLE %s ALTER ENCODE AUTO", tableIdent))
+	} else {
+		for _, raw := range d.Get(tableColumnAttr).([]interface{}) {
+			column := raw.(map[string]interface{})
+			if encoding := column[tableColumnEncodingAttr].(string); encoding != "" {
+				queries = append(queries, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ENCODE %s", tableIdent, pq.QuoteIdentifier(column[tableColumnNameAttr].(string)), strings.ToUpper(encoding)))
+			}
+		}
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("Error updating Table encodings with %q: %w", query, err)
+		}
+	}
+
+	return nil
+}
+
+// setTableDistribution sets the distribution chosen for a table whose
+// distribution style was AUTO. Other distribution changes replace the table in
+// CustomizeDiff.
+func setTableDistribution(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChanges(tableDistStyleAttr, tableDistKeyAttr) {
+		return nil
+	}
+
+	tableIdent := fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(tableNameAttr).(string)))
+	query := alterTableDistributionQuery(tableIdent, d.Get(tableDistStyleAttr).(string), d.Get(tableDistKeyAttr).(string))
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("Error updating Table distribution: %w", err)
+	}
+
+	return nil
+}
+
+func alterTableDistributionQuery(tableIdent, distStyle, distKey string) string {
+	if strings.ToUpper(distStyle) == "KEY" {
+		return fmt.Sprintf("ALTER TABLE %s ALTER DISTSTYLE KEY DISTKEY %s", tableIdent, pq.QuoteIdentifier(distKey))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER DISTSTYLE %s", tableIdent, strings.ToUpper(distStyle))
+}
+
+// setTableColumns drops the removed columns and adds the appended ones. Encoding
+// changes are handled by setTableColumnEncodings, other column changes force a
+// new table in CustomizeDiff.
+func setTableColumns(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(tableColumnAttr) {
+		return nil
+	}
+
+	tableIdent := fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(tableNameAttr).(string)))
+	oldRaw, newRaw := d.GetChange(tableColumnAttr)
+
+	newNames := map[string]bool{}
+	for _, column := range newRaw.([]interface{}) {
+		newNames[strings.ToLower(column.(map[string]interface{})[tableColumnNameAttr].(string))] = true
+	}
+
+	oldNames := map[string]bool{}
+	for _, raw := range oldRaw.([]interface{}) {
+		name := raw.(map[string]interface{})[tableColumnNameAttr].(string)
+		oldNames[strings.ToLower(name)] = true
+		if newNames[strings.ToLower(name)] {
+			continue
+		}
+
+		sql := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", tableIdent, pq.QuoteIdentifier(name))
+		if _, err := tx.Exec(sql); err != nil {
+			return fmt.Errorf("Error dropping column %s: %w", name, err)
+		}
+	}
+
+	for _, raw := range newRaw.([]interface{}) {
+		column := raw.(map[string]interface{})
+		if oldNames[strings.ToLower(column[tableColumnNameAttr].(string))] {
+			continue
+		}
+
+		sql := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableIdent, tableColumnDefinition(column))
+		if _, err := tx.Exec(sql); err != nil {
+			return fmt.Errorf("Error adding column %s: %w", column[tableColumnNameAttr], err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	tableAsNameAttr            = "name"
+	tableAsSchemaAttr          = "schema"
+	tableAsQueryAttr           = "query"
+	tableAsWithDataAttr        = "with_data"
+	tableAsRefreshTriggersAttr = "refresh_triggers"
+	tableAsColumnsAttr         = "columns"
+	tableAsColumnNameAttr      = "name"
+	tableAsColumnTypeAttr      = "type"
+)
+
+func redshiftTableAs() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a table created from the result of a query with ` + "`CREATE TABLE ... AS`" + `. Only the structure of the table is tracked in the state, as read from the catalog: the rows inserted by the query aren't, and changes to the tables it reads from don't show up in the plan. Redshift can't redefine a table from another query, so changing its query recreates it.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftTableAsCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftTableAsRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftTableAsUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftTableAsDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftTableAsExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			tableAsNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the table.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			tableAsSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the schema the table belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			tableAsQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The `SELECT` statement the table is created from. Differences in whitespace, letter case and a trailing semicolon are ignored. Changing it recreates the table.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return viewQueriesEqual(old, new)
+				},
+			},
+			tableAsWithDataAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether the table is populated with the result of the query when it's created. Redshift doesn't support `WITH NO DATA`, so when it's `false` the query is limited to no rows, which only creates the columns. Changing it recreates the table.",
+			},
+			tableAsRefreshTriggersAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary values which populate the table again when they change, e.g. the time of the last load of the tables the query reads from. The rows of the table are deleted and the result of the query inserted in a single transaction, also when `with_data` is `false`. Setting them when the table is created doesn't populate it again.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			tableAsColumnsAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The columns of the table, as created from the query.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableAsColumnNameAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the column.",
+						},
+						tableAsColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Data type of the column, e.g. `character varying(256)`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRedshiftTableAsExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT relname FROM pg_class WHERE oid = $1 AND relkind = 'r'", d.Id()).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftTableAsRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftTableAsReadImpl(db, d)
+}
+
+func resourceRedshiftTableAsReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var tableName, schemaName string
+
+	err := db.QueryRow(`
+  SELECT cl.relname, nsp.nspname
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE cl.oid = $1 AND cl.relkind = 'r'
+`, d.Id()).Scan(&tableName, &schemaName)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift Table (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading Table: %w", err)
+	}
+
+	rows, err := db.Query(`
+  SELECT a.attname, format_type(a.atttypid, a.atttypmod)
+  FROM pg_attribute a
+  WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+  ORDER BY a.attnum
+`, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading Table columns: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []map[string]interface{}{}
+	for rows.Next() {
+		var columnName, columnType string
+		if err := rows.Scan(&columnName, &columnType); err != nil {
+			return err
+		}
+		columns = append(columns, map[string]interface{}{
+			tableAsColumnNameAttr: columnName,
+			tableAsColumnTypeAttr: columnType,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set(tableAsNameAttr, tableName)
+	d.Set(tableAsSchemaAttr, schemaName)
+	d.Set(tableAsColumnsAttr, columns)
+
+	return nil
+}
+
+func tableAsIdent(d resourceValues) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(d.Get(tableAsSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(tableAsNameAttr).(string)))
+}
+
+func createTableAsQuery(d resourceValues) string {
+	query := normalizeViewQuery(d.Get(tableAsQueryAttr).(string))
+	// The query is wrapped so that LIMIT applies to any statement, e.g. one
+	// already limited or a UNION.
+	if !d.Get(tableAsWithDataAttr).(bool) {
+		query = fmt.Sprintf("SELECT * FROM (%s) LIMIT 0", query)
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s AS %s", tableAsIdent(d), query)
+}
+
+// populateTableAsQueries replace the rows of the table with the result of the
+// query. DELETE is used rather than TRUNCATE, which commits the transaction.
+func populateTableAsQueries(d resourceValues) []string {
+	return []string{
+		fmt.Sprintf("DELETE FROM %s", tableAsIdent(d)),
+		fmt.Sprintf("INSERT INTO %s %s", tableAsIdent(d), normalizeViewQuery(d.Get(tableAsQueryAttr).(string))),
+	}
+}
+
+func resourceRedshiftTableAsCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(createTableAsQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift table: %w", createObjectError(err, "redshift_table_as"))
+	}
+
+	names, err := storedIdentifiers(tx, d.Get(tableAsSchemaAttr).(string), d.Get(tableAsNameAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	var tableOID string
+	query := `
+  SELECT cl.oid
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2
+`
+	if err := tx.QueryRow(query, names[0], names[1]).Scan(&tableOID); err != nil {
+		return fmt.Errorf("Could not get redshift table oid: %w", err)
+	}
+
+	d.SetId(tableOID)
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftTableAsReadImpl(db, d)
+}
+
+func resourceRedshiftTableAsDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(fmt.Sprintf("DROP TABLE %s", tableAsIdent(d))); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftTableAsUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setTableAsName(tx, d); err != nil {
+		return err
+	}
+
+	if err := populateTableAs(tx, d); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftTableAsReadImpl(db, d)
+}
+
+func setTableAsName(tx sqlExecutor, d resourceValues) error {
+	if !d.HasChange(tableAsNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(tableAsNameAttr)
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s.%s RENAME TO %s",
+		pq.QuoteIdentifier(d.Get(tableAsSchemaAttr).(string)),
+		pq.QuoteIdentifier(oldRaw.(string)),
+		pq.QuoteIdentifier(newRaw.(string)),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating Table NAME: %w", err)
+	}
+
+	return nil
+}
+
+// populateTableAs runs after the table is renamed, so that the statements
+// use its new name.
+func populateTableAs(tx sqlExecutor, d resourceValues) error {
+	if !d.HasChange(tableAsRefreshTriggersAttr) {
+		return nil
+	}
+
+	for _, query := range populateTableAsQueries(d) {
+		log.Printf("[DEBUG] running %s", query)
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("Error populating Table: %w", err)
+		}
+	}
+
+	return nil
+}
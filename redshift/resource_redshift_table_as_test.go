@@ -0,0 +1,169 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftTableAs_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_as_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_as"), "-", "_")
+	tableNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_as_updated"), "-", "_")
+	config := func(name string, withData bool, trigger string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name   = "source_table"
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+
+resource "redshift_table_as" "table" {
+  name      = %[2]q
+  schema    = redshift_schema.schema.name
+  with_data = %[3]t
+  query     = "SELECT id, 'source' :: varchar(16) AS origin FROM ${redshift_schema.schema.name}.${redshift_table.table.name}"
+
+  refresh_triggers = {
+    load = %[4]q
+  }
+}
+`, schemaName, name, withData, trigger)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(tableName, false, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "name", tableName),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "columns.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "columns.0.name", "id"),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "columns.0.type", "integer"),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "columns.1.name", "origin"),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "columns.1.type", "character varying(16)"),
+				),
+			},
+			{
+				ResourceName:            "redshift_table_as.table",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{tableAsQueryAttr, tableAsWithDataAttr, tableAsRefreshTriggersAttr},
+			},
+			{
+				Config: config(tableNameUpdated, false, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableNameUpdated),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "name", tableNameUpdated),
+					resource.TestCheckResourceAttr("redshift_table_as.table", "refresh_triggers.load", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateTableAsQuery(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		"with data": {
+			input: map[string]interface{}{
+				tableAsNameAttr:   "sales_by_region",
+				tableAsSchemaAttr: "analytics",
+				tableAsQueryAttr:  "SELECT region, sum(amount) AS total\n  FROM analytics.sales\n  GROUP BY region;\n",
+			},
+			expected: `CREATE TABLE "analytics"."sales_by_region" AS SELECT region, sum(amount) AS total FROM analytics.sales GROUP BY region`,
+		},
+		"with no data": {
+			input: map[string]interface{}{
+				tableAsNameAttr:     "sales_by_region",
+				tableAsSchemaAttr:   "analytics",
+				tableAsQueryAttr:    "SELECT region FROM analytics.sales LIMIT 10",
+				tableAsWithDataAttr: false,
+			},
+			expected: `CREATE TABLE "analytics"."sales_by_region" AS SELECT * FROM (SELECT region FROM analytics.sales LIMIT 10) LIMIT 0`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftTableAs().Schema, tc.input)
+			if query := createTableAsQuery(d); query != tc.expected {
+				t.Errorf("Expected query %q, got %q", tc.expected, query)
+			}
+		})
+	}
+}
+
+func TestPopulateTableAs(t *testing.T) {
+	r := redshiftTableAs()
+	config := func(trigger string) map[string]interface{} {
+		return map[string]interface{}{
+			tableAsNameAttr:            "sales_by_region",
+			tableAsSchemaAttr:          "analytics",
+			tableAsQueryAttr:           "SELECT region FROM analytics.sales",
+			tableAsRefreshTriggersAttr: map[string]interface{}{"load": trigger},
+		}
+	}
+	old := schema.TestResourceDataRaw(t, r.Schema, config("1"))
+	old.SetId("1")
+
+	tests := map[string]struct {
+		trigger  string
+		expected []string
+	}{
+		"unchanged triggers": {
+			trigger:  "1",
+			expected: nil,
+		},
+		"changed triggers": {
+			trigger: "2",
+			expected: []string{
+				`DELETE FROM "analytics"."sales_by_region"`,
+				`INSERT INTO "analytics"."sales_by_region" SELECT region FROM analytics.sales`,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(config(tc.trigger)), nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			d, err := schema.InternalMap(r.Schema).Data(old.State(), diff)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			recorder := &statementRecorder{}
+			if err := populateTableAs(recorder, d); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if strings.Join(recorder.statements, ";") != strings.Join(tc.expected, ";") {
+				t.Errorf("Expected statements %q, got %q", tc.expected, recorder.statements)
+			}
+		})
+	}
+}
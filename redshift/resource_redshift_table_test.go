@@ -0,0 +1,1465 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedshiftTable_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name      = %[2]q
+  schema    = redshift_schema.schema.name
+  diststyle = "key"
+  distkey   = "id"
+  sortkey   = ["created_at", "id"]
+
+  column {
+    name     = "id"
+    type     = "integer"
+    encoding = "az64"
+    nullable = false
+  }
+
+  column {
+    name = "name"
+    type = "character varying(64)"
+  }
+
+  column {
+    name     = "created_at"
+    type     = "timestamp without time zone"
+    encoding = "raw"
+  }
+}
+`, schemaName, tableName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "name", tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_table.table", "diststyle", "KEY"),
+					resource.TestCheckResourceAttr("redshift_table.table", "distkey", "id"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.0", "created_at"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.1", "id"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.#", "3"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.name", "id"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.encoding", "az64"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.nullable", "false"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.name", "name"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.nullable", "true"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.2.encoding", "raw"),
+				),
+			},
+			{
+				ResourceName:      "redshift_table.table",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_Identity(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_identity"), "-", "_")
+	config := func(seed int) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name   = %[2]q
+  schema = redshift_schema.schema.name
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    nullable = false
+
+    identity {
+      seed = %[3]d
+      step = 1
+    }
+  }
+
+  column {
+    name    = "status"
+    type    = "varchar(16)"
+    default = "'new'"
+  }
+}
+`, schemaName, tableName, seed)
+	}
+
+	var tableID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.identity.#", "1"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.identity.0.seed", "1"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.identity.0.generated_by_default", "false"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.default", ""),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.identity.#", "0"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.default", "'new'"),
+					resource.TestCheckResourceAttrWith("redshift_table.table", "id", func(id string) error {
+						tableID = id
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   config(1),
+				PlanOnly: true,
+			},
+			{
+				// Redshift can't alter the identity, the table is recreated.
+				Config: config(100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.identity.0.seed", "100"),
+					resource.TestCheckResourceAttrWith("redshift_table.table", "id", func(id string) error {
+						if id == tableID {
+							return fmt.Errorf("expected the table to be recreated")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "redshift_table.table",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_Constraints(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	config := func(eventsConstraints string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "users" {
+  name   = "users"
+  schema = redshift_schema.schema.name
+
+  column {
+    name     = "id"
+    type     = "integer"
+    nullable = false
+  }
+
+  primary_key {
+    columns = ["id"]
+  }
+}
+
+resource "redshift_table" "events" {
+  name   = "events"
+  schema = redshift_schema.schema.name
+
+  column {
+    name     = "id"
+    type     = "integer"
+    nullable = false
+  }
+
+  column {
+    name = "user_id"
+    type = "integer"
+  }
+
+  %[2]s
+}
+`, schemaName, eventsConstraints)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  primary_key {
+    columns = ["id"]
+  }
+
+  foreign_key {
+    columns            = ["user_id"]
+    references_table   = redshift_table.users.name
+    references_columns = ["id"]
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.users", "primary_key.#", "1"),
+					resource.TestCheckResourceAttrSet("redshift_table.users", "primary_key.0.name"),
+					resource.TestCheckResourceAttr("redshift_table.events", "primary_key.0.columns.0", "id"),
+					resource.TestCheckResourceAttr("redshift_table.events", "foreign_key.#", "1"),
+					resource.TestCheckResourceAttr("redshift_table.events", "foreign_key.0.references_schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_table.events", "foreign_key.0.references_table", "users"),
+					resource.TestCheckResourceAttr("redshift_table.events", "foreign_key.0.references_columns.0", "id"),
+				),
+			},
+			{
+				Config: config(`
+  primary_key {
+    columns = ["id", "user_id"]
+  }
+
+  unique {
+    name    = "events_user_id_key"
+    columns = ["user_id"]
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.events", "primary_key.0.columns.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.events", "unique.#", "1"),
+					resource.TestCheckResourceAttr("redshift_table.events", "unique.0.name", "events_user_id_key"),
+					resource.TestCheckResourceAttr("redshift_table.events", "foreign_key.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "redshift_table.events",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_Owner(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_owner"), "-", "_")
+	config := func(owner string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "first" {
+  name = "%[1]s_first"
+}
+
+resource "redshift_user" "second" {
+  name = "%[1]s_second"
+}
+
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  owner             = redshift_user.first.name
+  cascade_on_delete = true
+}
+
+resource "redshift_table" "table" {
+  name   = "events"
+  schema = redshift_schema.schema.name
+  owner  = %[2]s
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+`, schemaName, owner)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("redshift_user.first.name"),
+				Check:  resource.TestCheckResourceAttr("redshift_table.table", "owner", schemaName+"_first"),
+			},
+			{
+				Config: config("redshift_user.second.name"),
+				Check:  resource.TestCheckResourceAttr("redshift_table.table", "owner", schemaName+"_second"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_MixedCaseNames(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("TF_Acc_Table_Schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("TF_Acc_Table"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name   = %[2]q
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+}
+`, schemaName, tableName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					// The cluster folds quoted identifiers unless enable_case_sensitive_identifier is on.
+					testAccCheckRedshiftTableExists(strings.ToLower(schemaName), strings.ToLower(tableName)),
+					resource.TestCheckResourceAttr("redshift_table.table", "name", strings.ToLower(tableName)),
+					resource.TestCheckResourceAttr("redshift_table.table", "schema", strings.ToLower(schemaName)),
+				),
+			},
+			// The names read back only differ from the configuration by case.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "redshift_table.table",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_UpdateColumns(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table"), "-", "_")
+	tableNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_updated"), "-", "_")
+	configCreate := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name   = %[2]q
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "int"
+  }
+
+  column {
+    name = "obsolete"
+    type = "varchar(16)"
+  }
+}
+`, schemaName, tableName)
+	configUpdate := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name   = %[2]q
+  schema = redshift_schema.schema.name
+
+  column {
+    name = "id"
+    type = "int"
+  }
+
+  column {
+    name    = "status"
+    type    = "varchar(16)"
+    default = "'new'"
+  }
+}
+`, schemaName, tableNameUpdated)
+
+	var tableID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.type", "int"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.name", "obsolete"),
+					testAccStoreResourceID("redshift_table.table", &tableID),
+				),
+			},
+			{
+				Config: configUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableNameUpdated),
+					resource.TestCheckResourceAttr("redshift_table.table", "name", tableNameUpdated),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.name", "status"),
+					resource.TestCheckResourceAttr("redshift_table.table", "column.1.default", "'new'"),
+					testAccCheckResourceID("redshift_table.table", &tableID, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_ForceNew(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table"), "-", "_")
+	config := func(sortKey string, idType string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name    = %[2]q
+  schema  = redshift_schema.schema.name
+  sortkey = [%[3]q]
+
+  column {
+    name = "id"
+    type = %[4]q
+  }
+
+  column {
+    name = "created_at"
+    type = "timestamp"
+  }
+}
+`, schemaName, tableName, sortKey, idType)
+	}
+
+	var tableID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("id", "integer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					testAccStoreResourceID("redshift_table.table", &tableID),
+				),
+			},
+			{
+				Config: config("created_at", "integer"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.0", "created_at"),
+					testAccCheckResourceID("redshift_table.table", &tableID, false),
+					testAccStoreResourceID("redshift_table.table", &tableID),
+				),
+			},
+			{
+				Config: config("created_at", "bigint"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.type", "bigint"),
+					testAccCheckResourceID("redshift_table.table", &tableID, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_InterleavedSortKey(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table"), "-", "_")
+	config := func(sortKeyStyle string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name          = %[2]q
+  schema        = redshift_schema.schema.name
+  sortkey       = ["created_at", "id"]
+  sortkey_style = %[3]q
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+
+  column {
+    name = "created_at"
+    type = "timestamp"
+  }
+}
+`, schemaName, tableName, sortKeyStyle)
+	}
+
+	var tableID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("interleaved"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey_style", "INTERLEAVED"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.#", "2"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.0", "created_at"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.1", "id"),
+					testAccStoreResourceID("redshift_table.table", &tableID),
+				),
+			},
+			{
+				Config: config("COMPOUND"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey_style", "COMPOUND"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey.#", "2"),
+					testAccCheckResourceID("redshift_table.table", &tableID, false),
+				),
+			},
+			{
+				ResourceName:      "redshift_table.table",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_Auto(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table"), "-", "_")
+	config := func(distribution string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name          = %[2]q
+  schema        = redshift_schema.schema.name
+  sortkey_style = "AUTO"
+  encode_auto   = true
+  %[3]s
+
+  column {
+    name = "id"
+    type = "integer"
+  }
+
+  column {
+    name = "user_id"
+    type = "integer"
+  }
+}
+`, schemaName, tableName, distribution)
+	}
+
+	var tableID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`diststyle = "AUTO"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftTableExists(schemaName, tableName),
+					resource.TestCheckResourceAttr("redshift_table.table", "diststyle", "AUTO"),
+					resource.TestCheckResourceAttr("redshift_table.table", "sortkey_style", "AUTO"),
+					resource.TestCheckResourceAttr("redshift_table.table", "encode_auto", "true"),
+					testAccStoreResourceID("redshift_table.table", &tableID),
+				),
+			},
+			{
+				Config: config(`distkey = "user_id"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "diststyle", "KEY"),
+					resource.TestCheckResourceAttr("redshift_table.table", "distkey", "user_id"),
+					testAccCheckResourceID("redshift_table.table", &tableID, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftTable_UpdateEncoding(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table_schema"), "-", "_")
+	tableName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_table"), "-", "_")
+	config := func(encoding string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_table" "table" {
+  name   = %[2]q
+  schema = redshift_schema.schema.name
+
+  column {
+    name     = "id"
+    type     = "bigint"
+    encoding = %[3]q
+  }
+}
+`, schemaName, tableName, encoding)
+	}
+
+	var tableID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("raw"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.encoding", "raw"),
+					testAccStoreResourceID("redshift_table.table", &tableID),
+				),
+			},
+			{
+				Config: config("az64"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.encoding", "az64"),
+					testAccCheckResourceID("redshift_table.table", &tableID, true),
+				),
+			},
+			{
+				Config: config("ZSTD"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_table.table", "column.0.encoding", "zstd"),
+					testAccCheckResourceID("redshift_table.table", &tableID, true),
+				),
+			},
+			// The encoding already matches, so there is nothing to alter.
+			{
+				Config:   config("zstd"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	tests := map[string]string{
+		"int":                         "integer",
+		"INTEGER":                     "integer",
+		"int8":                        "bigint",
+		"bool":                        "boolean",
+		"float":                       "double precision",
+		"double   precision":          "double precision",
+		"varchar(32)":                 "character varying(32)",
+		"character varying(32)":       "character varying(32)",
+		"character varying( 20 )":     "character varying(20)",
+		"char(1)":                     "character(1)",
+		"text":                        "character varying(256)",
+		"decimal(10, 2)":              "numeric(10,2)",
+		"timestamp":                   "timestamp without time zone",
+		"timestamp without time zone": "timestamp without time zone",
+		"timestamptz":                 "timestamp with time zone",
+		"super":                       "super",
+	}
+
+	for columnType, expected := range tests {
+		if result := normalizeColumnType(columnType); result != expected {
+			t.Errorf("normalizeColumnType(%q) = %q, expected %q", columnType, result, expected)
+		}
+	}
+}
+
+func TestTableColumnsRequireReplacement(t *testing.T) {
+	column := func(name, columnType, encoding string, nullable bool, defaultValue string) interface{} {
+		return map[string]interface{}{
+			tableColumnNameAttr:     name,
+			tableColumnTypeAttr:     columnType,
+			tableColumnEncodingAttr: encoding,
+			tableColumnNullableAttr: nullable,
+			tableColumnDefaultAttr:  defaultValue,
+		}
+	}
+	withIdentity := func(raw interface{}, seed, step int, generatedByDefault bool) interface{} {
+		column := raw.(map[string]interface{})
+		column[tableColumnIdentityAttr] = []interface{}{
+			map[string]interface{}{
+				tableColumnIdentitySeedAttr:               seed,
+				tableColumnIdentityStepAttr:               step,
+				tableColumnIdentityGeneratedByDefaultAttr: generatedByDefault,
+			},
+		}
+		return column
+	}
+	oldColumns := []interface{}{
+		column("id", "integer", "az64", false, ""),
+		column("name", "character varying(32)", "lzo", true, ""),
+		column("status", "character varying(16)", "lzo", true, "'new'::character varying"),
+	}
+
+	tests := map[string]struct {
+		newColumns []interface{}
+		expected   bool
+	}{
+		"unchanged with aliases": {
+			newColumns: []interface{}{
+				column("id", "int", "", false, ""),
+				column("name", "varchar(32)", "", true, ""),
+				column("status", "varchar(16)", "lzo", true, "'new'"),
+			},
+			expected: false,
+		},
+		"appended column": {
+			newColumns: append(append([]interface{}{}, oldColumns...), column("created_at", "timestamp", "", true, "")),
+			expected:   false,
+		},
+		"dropped column": {
+			newColumns: []interface{}{oldColumns[0], oldColumns[2]},
+			expected:   false,
+		},
+		"column added in the middle": {
+			newColumns: []interface{}{oldColumns[0], column("created_at", "timestamp", "", true, ""), oldColumns[1], oldColumns[2]},
+			expected:   true,
+		},
+		"reordered columns": {
+			newColumns: []interface{}{oldColumns[1], oldColumns[0], oldColumns[2]},
+			expected:   true,
+		},
+		"changed type": {
+			newColumns: []interface{}{column("id", "bigint", "az64", false, ""), oldColumns[1], oldColumns[2]},
+			expected:   true,
+		},
+		"changed nullable": {
+			newColumns: []interface{}{column("id", "integer", "az64", true, ""), oldColumns[1], oldColumns[2]},
+			expected:   true,
+		},
+		"changed default": {
+			newColumns: []interface{}{oldColumns[0], oldColumns[1], column("status", "character varying(16)", "lzo", true, "'old'")},
+			expected:   true,
+		},
+		"changed encoding": {
+			newColumns: []interface{}{column("id", "integer", "zstd", false, ""), oldColumns[1], oldColumns[2]},
+			expected:   false,
+		},
+		"added identity": {
+			newColumns: []interface{}{withIdentity(column("id", "integer", "az64", false, ""), 1, 1, false), oldColumns[1], oldColumns[2]},
+			expected:   true,
+		},
+		"appended identity column": {
+			newColumns: append(append([]interface{}{}, oldColumns...), withIdentity(column("event_id", "bigint", "", false, ""), 1, 1, false)),
+			expected:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := tableColumnsRequireReplacement(oldColumns, tc.newColumns); result != tc.expected {
+				t.Errorf("tableColumnsRequireReplacement() = %t, expected %t", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTableColumnEncodingChanges(t *testing.T) {
+	column := func(name, encoding string) interface{} {
+		return map[string]interface{}{
+			tableColumnNameAttr:     name,
+			tableColumnEncodingAttr: encoding,
+		}
+	}
+
+	tests := map[string]struct {
+		oldColumns []interface{}
+		newColumns []interface{}
+		expected   map[string]string
+	}{
+		"raw to az64": {
+			oldColumns: []interface{}{column("id", "none")},
+			newColumns: []interface{}{column("id", "az64")},
+			expected:   map[string]string{"id": "az64"},
+		},
+		"az64 to zstd": {
+			oldColumns: []interface{}{column("id", "az64")},
+			newColumns: []interface{}{column("id", "ZSTD")},
+			expected:   map[string]string{"id": "zstd"},
+		},
+		"zstd to raw": {
+			oldColumns: []interface{}{column("id", "zstd")},
+			newColumns: []interface{}{column("id", "raw")},
+			expected:   map[string]string{"id": "raw"},
+		},
+		"raw reported as none": {
+			oldColumns: []interface{}{column("id", "none")},
+			newColumns: []interface{}{column("id", "RAW")},
+			expected:   map[string]string{},
+		},
+		"same encoding": {
+			oldColumns: []interface{}{column("id", "az64"), column("name", "zstd")},
+			newColumns: []interface{}{column("id", "az64"), column("name", "zstd")},
+			expected:   map[string]string{},
+		},
+		"encoding chosen by redshift": {
+			oldColumns: []interface{}{column("id", "az64")},
+			newColumns: []interface{}{column("id", "")},
+			expected:   map[string]string{},
+		},
+		"new column": {
+			oldColumns: []interface{}{column("id", "az64")},
+			newColumns: []interface{}{column("id", "az64"), column("name", "zstd")},
+			expected:   map[string]string{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := tableColumnEncodingChanges(tc.oldColumns, tc.newColumns)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("tableColumnEncodingChanges() = %v, expected %v", result, tc.expected)
+			}
+			for columnName, encoding := range tc.expected {
+				if result[columnName] != encoding {
+					t.Errorf("tableColumnEncodingChanges()[%q] = %q, expected %q", columnName, result[columnName], encoding)
+				}
+			}
+		})
+	}
+}
+
+func testAccCheckRedshiftTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_table" && rs.Type != "redshift_table_as" {
+			continue
+		}
+
+		exists, err := checkTableExists(client, rs.Primary.Attributes[tableSchemaAttr], rs.Primary.Attributes[tableNameAttr])
+
+		if err != nil {
+			return fmt.Errorf("Error checking table %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Table still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftTableExists(schemaName, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkTableExists(client, schemaName, tableName)
+		if err != nil {
+			return fmt.Errorf("Error checking table %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Table not found")
+		}
+
+		return nil
+	}
+}
+
+func checkTableExists(client *Client, schemaName, tableName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	query := `
+  SELECT 1
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2 AND cl.relkind = 'r'
+`
+	err = db.QueryRow(query, strings.ToLower(schemaName), strings.ToLower(tableName)).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about table: %s", err)
+	}
+
+	return true, nil
+}
+
+func TestTableColumnIdentity(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:   "events",
+		tableSchemaAttr: "analytics",
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{
+				tableColumnNameAttr:     "id",
+				tableColumnTypeAttr:     "bigint",
+				tableColumnNullableAttr: false,
+				tableColumnIdentityAttr: []interface{}{
+					map[string]interface{}{tableColumnIdentitySeedAttr: 0, tableColumnIdentityStepAttr: 1},
+				},
+			},
+			map[string]interface{}{
+				tableColumnNameAttr:     "event_id",
+				tableColumnTypeAttr:     "integer",
+				tableColumnNullableAttr: false,
+				tableColumnIdentityAttr: []interface{}{
+					map[string]interface{}{tableColumnIdentityStepAttr: -1, tableColumnIdentityGeneratedByDefaultAttr: true},
+				},
+			},
+			map[string]interface{}{tableColumnNameAttr: "status", tableColumnTypeAttr: "varchar(16)", tableColumnDefaultAttr: "'new'"},
+		},
+	})
+	expected := `CREATE TABLE "analytics"."events" ("id" bigint IDENTITY(0, 1) NOT NULL, "event_id" integer GENERATED BY DEFAULT AS IDENTITY(1, -1) NOT NULL, "status" varchar(16) DEFAULT 'new')`
+	if query := createTableQuery(d); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+
+	tests := map[string]struct {
+		defaultValue string
+		expected     *tableColumnIdentity
+	}{
+		"identity":             {`"identity"(108187, 0, '0,1'::text)`, &tableColumnIdentity{seed: 0, step: 1}},
+		"generated by default": {`default_identity(108187, 1, '1,-1'::text)`, &tableColumnIdentity{seed: 1, step: -1, generatedByDefault: true}},
+		"default":              {`'new'::character varying`, nil},
+		"empty":                {``, nil},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			identity := tableColumnIdentityOf(map[string]interface{}{tableColumnIdentityAttr: parseTableColumnIdentity(tc.defaultValue)})
+			if !reflect.DeepEqual(identity, tc.expected) {
+				t.Errorf("Expected identity %v, got %v", tc.expected, identity)
+			}
+		})
+	}
+}
+
+func TestValidateTableIdentityColumns(t *testing.T) {
+	identity := []interface{}{map[string]interface{}{tableColumnIdentitySeedAttr: 1, tableColumnIdentityStepAttr: 1}}
+	tests := map[string]struct {
+		columns  []interface{}
+		expected string
+	}{
+		"one identity column": {
+			columns: []interface{}{
+				map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "int8", tableColumnNullableAttr: false, tableColumnIdentityAttr: identity},
+				map[string]interface{}{tableColumnNameAttr: "name", tableColumnTypeAttr: "varchar(16)"},
+			},
+		},
+		"two identity columns": {
+			columns: []interface{}{
+				map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnNullableAttr: false, tableColumnIdentityAttr: identity},
+				map[string]interface{}{tableColumnNameAttr: "other_id", tableColumnTypeAttr: "integer", tableColumnNullableAttr: false, tableColumnIdentityAttr: identity},
+			},
+			expected: "only one column can be an identity column, both id and other_id are",
+		},
+		"not an integer": {
+			columns: []interface{}{
+				map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "varchar(16)", tableColumnNullableAttr: false, tableColumnIdentityAttr: identity},
+			},
+			expected: "identity column id must be of type integer or bigint, not varchar(16)",
+		},
+		"nullable": {
+			columns: []interface{}{
+				map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnIdentityAttr: identity},
+			},
+			expected: "identity column id must set nullable to false",
+		},
+		"default": {
+			columns: []interface{}{
+				map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnNullableAttr: false, tableColumnDefaultAttr: "1", tableColumnIdentityAttr: identity},
+			},
+			expected: "identity column id can't have a default",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				tableNameAttr:   "events",
+				tableSchemaAttr: "analytics",
+				tableColumnAttr: tc.columns,
+			})
+			_, err := redshiftTable().Diff(context.Background(), nil, config, nil)
+			if tc.expected == "" && err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			if tc.expected != "" && (err == nil || err.Error() != tc.expected) {
+				t.Errorf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateTableSortKey(t *testing.T) {
+	columns := []interface{}{}
+	sortKey := func(n int) []interface{} {
+		names := []interface{}{}
+		for i := 0; i < n; i++ {
+			names = append(names, fmt.Sprintf("c%d", i))
+		}
+		return names
+	}
+	for _, name := range sortKey(9) {
+		columns = append(columns, map[string]interface{}{tableColumnNameAttr: name, tableColumnTypeAttr: "integer"})
+	}
+
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		"compound": {
+			raw: map[string]interface{}{tableSortKeyAttr: sortKey(9)},
+		},
+		"interleaved": {
+			raw: map[string]interface{}{tableSortKeyStyleAttr: "interleaved", tableSortKeyAttr: sortKey(8)},
+		},
+		"interleaved with too many columns": {
+			raw:      map[string]interface{}{tableSortKeyStyleAttr: "INTERLEAVED", tableSortKeyAttr: sortKey(9)},
+			expected: "an interleaved sort key can have at most 8 columns, got 9",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.raw[tableNameAttr] = "events"
+			tc.raw[tableSchemaAttr] = "analytics"
+			tc.raw[tableColumnAttr] = columns
+			_, err := redshiftTable().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.raw), nil)
+			if tc.expected == "" && err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			if tc.expected != "" && (err == nil || err.Error() != tc.expected) {
+				t.Errorf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestCreateTableQueryInterleavedSortKey(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:         "events",
+		tableSchemaAttr:       "analytics",
+		tableSortKeyAttr:      []interface{}{"created_at", "id"},
+		tableSortKeyStyleAttr: "interleaved",
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer"},
+			map[string]interface{}{tableColumnNameAttr: "created_at", tableColumnTypeAttr: "timestamp"},
+		},
+	})
+	expected := `CREATE TABLE "analytics"."events" ("id" integer, "created_at" timestamp) INTERLEAVED SORTKEY("created_at", "id")`
+	if query := createTableQuery(d); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+}
+
+func TestCreateTableQueryAuto(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:         "events",
+		tableSchemaAttr:       "analytics",
+		tableDistStyleAttr:    "auto",
+		tableSortKeyStyleAttr: "auto",
+		tableEncodeAutoAttr:   true,
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnEncodingAttr: "az64"},
+		},
+	})
+	expected := `CREATE TABLE "analytics"."events" ("id" integer) DISTSTYLE AUTO SORTKEY AUTO ENCODE AUTO`
+	if query := createTableQuery(d); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+}
+
+func TestCustomizeTableDistribution(t *testing.T) {
+	columns := []interface{}{
+		map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer"},
+		map[string]interface{}{tableColumnNameAttr: "user_id", tableColumnTypeAttr: "integer"},
+	}
+	tests := map[string]struct {
+		oldDistStyle      string
+		oldDistKey        string
+		newRaw            map[string]interface{}
+		expectedDistStyle string
+		expectedDistKey   string
+		expectedForceNew  bool
+		expectedError     string
+	}{
+		"pinning a distribution key from AUTO": {
+			oldDistStyle:      "AUTO",
+			newRaw:            map[string]interface{}{tableDistKeyAttr: "user_id"},
+			expectedDistStyle: "KEY",
+			expectedDistKey:   "user_id",
+		},
+		"changing the distribution key chosen by AUTO": {
+			oldDistStyle:      "AUTO",
+			oldDistKey:        "id",
+			newRaw:            map[string]interface{}{tableDistStyleAttr: "KEY", tableDistKeyAttr: "user_id"},
+			expectedDistStyle: "KEY",
+			expectedDistKey:   "user_id",
+		},
+		"EVEN from AUTO": {
+			oldDistStyle:      "AUTO",
+			oldDistKey:        "id",
+			newRaw:            map[string]interface{}{tableDistStyleAttr: "EVEN"},
+			expectedDistStyle: "EVEN",
+			expectedDistKey:   "",
+		},
+		"KEY from AUTO without distribution key": {
+			oldDistStyle:  "AUTO",
+			newRaw:        map[string]interface{}{tableDistStyleAttr: "KEY"},
+			expectedError: "diststyle KEY requires distkey to be set",
+		},
+		"changing the distribution key": {
+			oldDistStyle:     "KEY",
+			oldDistKey:       "id",
+			newRaw:           map[string]interface{}{tableDistKeyAttr: "user_id"},
+			expectedDistKey:  "user_id",
+			expectedForceNew: true,
+		},
+		"AUTO from EVEN": {
+			oldDistStyle:      "EVEN",
+			newRaw:            map[string]interface{}{tableDistStyleAttr: "AUTO"},
+			expectedDistStyle: "AUTO",
+			expectedForceNew:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := redshiftTable()
+			old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				tableNameAttr:      "events",
+				tableSchemaAttr:    "analytics",
+				tableColumnAttr:    columns,
+				tableDistStyleAttr: tc.oldDistStyle,
+				tableDistKeyAttr:   tc.oldDistKey,
+			})
+			// The computed attributes are read from Redshift.
+			old.Set(tableSortKeyAttr, []interface{}{})
+			old.Set(tableSortKeyStyleAttr, "AUTO")
+			old.Set(tableEncodeAutoAttr, true)
+			old.Set(tableColumnAttr, []interface{}{
+				map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnEncodingAttr: "az64", tableColumnNullableAttr: true},
+				map[string]interface{}{tableColumnNameAttr: "user_id", tableColumnTypeAttr: "integer", tableColumnEncodingAttr: "az64", tableColumnNullableAttr: true},
+			})
+			old.SetId("1")
+			tc.newRaw[tableNameAttr] = "events"
+			tc.newRaw[tableSchemaAttr] = "analytics"
+			tc.newRaw[tableColumnAttr] = columns
+			diff, err := r.Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(tc.newRaw), nil)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if diff.RequiresNew() != tc.expectedForceNew {
+				t.Errorf("Expected RequiresNew to be %t", tc.expectedForceNew)
+			}
+			d, err := schema.InternalMap(r.Schema).Data(old.State(), diff)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if distStyle := d.Get(tableDistStyleAttr).(string); distStyle != tc.expectedDistStyle {
+				t.Errorf("Expected diststyle %q, got %q", tc.expectedDistStyle, distStyle)
+			}
+			if distKey := d.Get(tableDistKeyAttr).(string); distKey != tc.expectedDistKey {
+				t.Errorf("Expected distkey %q, got %q", tc.expectedDistKey, distKey)
+			}
+		})
+	}
+}
+
+func TestAlterTableDistributionQuery(t *testing.T) {
+	tests := map[string]struct {
+		distStyle string
+		distKey   string
+		expected  string
+	}{
+		"key": {
+			distStyle: "KEY",
+			distKey:   "UserId",
+			expected:  `ALTER TABLE "analytics"."events" ALTER DISTSTYLE KEY DISTKEY "UserId"`,
+		},
+		"even": {
+			distStyle: "even",
+			expected:  `ALTER TABLE "analytics"."events" ALTER DISTSTYLE EVEN`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if query := alterTableDistributionQuery(`"analytics"."events"`, tc.distStyle, tc.distKey); query != tc.expected {
+				t.Errorf("Expected query %q, got %q", tc.expected, query)
+			}
+		})
+	}
+}
+
+func TestCreateTableQueryConstraints(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:   "events",
+		tableSchemaAttr: "analytics",
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnNullableAttr: false},
+			map[string]interface{}{tableColumnNameAttr: "user_id", tableColumnTypeAttr: "integer"},
+		},
+		tablePrimaryKeyAttr: []interface{}{
+			map[string]interface{}{tableConstraintColumnsAttr: []interface{}{"id"}},
+		},
+		tableUniqueAttr: []interface{}{
+			map[string]interface{}{tableConstraintNameAttr: "events_user_key", tableConstraintColumnsAttr: []interface{}{"user_id", "id"}},
+		},
+		tableForeignKeyAttr: []interface{}{
+			map[string]interface{}{
+				tableConstraintColumnsAttr:           []interface{}{"user_id"},
+				tableConstraintReferencesTableAttr:   "users",
+				tableConstraintReferencesColumnsAttr: []interface{}{"id"},
+			},
+		},
+	})
+	expected := `CREATE TABLE "analytics"."events" ("id" integer NOT NULL, "user_id" integer, PRIMARY KEY ("id"), CONSTRAINT "events_user_key" UNIQUE ("user_id", "id"), FOREIGN KEY ("user_id") REFERENCES "analytics"."users" ("id"))`
+	if query := createTableQuery(d); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+}
+
+func TestTableConstraintsQueries(t *testing.T) {
+	columns := []interface{}{
+		map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer", tableColumnNullableAttr: false},
+		map[string]interface{}{tableColumnNameAttr: "user_id", tableColumnTypeAttr: "integer"},
+	}
+	oldRaw := map[string]interface{}{
+		tableNameAttr:   "events",
+		tableSchemaAttr: "analytics",
+		tableColumnAttr: columns,
+		tablePrimaryKeyAttr: []interface{}{
+			map[string]interface{}{tableConstraintNameAttr: "events_pkey", tableConstraintColumnsAttr: []interface{}{"id"}},
+		},
+		tableUniqueAttr: []interface{}{
+			map[string]interface{}{tableConstraintNameAttr: "events_user_id_key", tableConstraintColumnsAttr: []interface{}{"user_id"}},
+		},
+		tableForeignKeyAttr: []interface{}{
+			map[string]interface{}{
+				tableConstraintNameAttr:              "events_user_id_fkey",
+				tableConstraintColumnsAttr:           []interface{}{"user_id"},
+				tableConstraintReferencesSchemaAttr:  "analytics",
+				tableConstraintReferencesTableAttr:   "users",
+				tableConstraintReferencesColumnsAttr: []interface{}{"id"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		newRaw        map[string]interface{}
+		expectedDrops []string
+		expectedAdds  []string
+	}{
+		"unchanged without names": {
+			newRaw: map[string]interface{}{
+				tableNameAttr:   "events",
+				tableSchemaAttr: "analytics",
+				tableColumnAttr: columns,
+				tablePrimaryKeyAttr: []interface{}{
+					map[string]interface{}{tableConstraintColumnsAttr: []interface{}{"id"}},
+				},
+				tableUniqueAttr: []interface{}{
+					map[string]interface{}{tableConstraintColumnsAttr: []interface{}{"user_id"}},
+				},
+				tableForeignKeyAttr: []interface{}{
+					map[string]interface{}{
+						tableConstraintColumnsAttr:           []interface{}{"user_id"},
+						tableConstraintReferencesTableAttr:   "users",
+						tableConstraintReferencesColumnsAttr: []interface{}{"id"},
+					},
+				},
+			},
+			expectedDrops: []string{},
+			expectedAdds:  []string{},
+		},
+		"changed and removed constraints": {
+			newRaw: map[string]interface{}{
+				tableNameAttr:   "events",
+				tableSchemaAttr: "analytics",
+				tableColumnAttr: columns,
+				tablePrimaryKeyAttr: []interface{}{
+					map[string]interface{}{tableConstraintColumnsAttr: []interface{}{"id", "user_id"}},
+				},
+			},
+			expectedDrops: []string{
+				`ALTER TABLE "analytics"."events" DROP CONSTRAINT "events_user_id_fkey"`,
+				`ALTER TABLE "analytics"."events" DROP CONSTRAINT "events_user_id_key"`,
+				`ALTER TABLE "analytics"."events" DROP CONSTRAINT "events_pkey"`,
+			},
+			expectedAdds: []string{
+				`ALTER TABLE "analytics"."events" ADD PRIMARY KEY ("id", "user_id")`,
+			},
+		},
+		"renamed constraint": {
+			newRaw: map[string]interface{}{
+				tableNameAttr:   "events",
+				tableSchemaAttr: "analytics",
+				tableColumnAttr: columns,
+				tablePrimaryKeyAttr: []interface{}{
+					map[string]interface{}{tableConstraintNameAttr: "events_pkey", tableConstraintColumnsAttr: []interface{}{"id"}},
+				},
+				tableUniqueAttr: []interface{}{
+					map[string]interface{}{tableConstraintNameAttr: "events_user", tableConstraintColumnsAttr: []interface{}{"user_id"}},
+				},
+				tableForeignKeyAttr: oldRaw[tableForeignKeyAttr],
+			},
+			expectedDrops: []string{
+				`ALTER TABLE "analytics"."events" DROP CONSTRAINT "events_user_id_key"`,
+			},
+			expectedAdds: []string{
+				`ALTER TABLE "analytics"."events" ADD CONSTRAINT "events_user" UNIQUE ("user_id")`,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := redshiftTable()
+			old := schema.TestResourceDataRaw(t, r.Schema, oldRaw)
+			old.SetId("1")
+			state := old.State()
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.newRaw), nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			d, err := schema.InternalMap(r.Schema).Data(state, diff)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			drops, adds := tableConstraintsQueries(d)
+			if !reflect.DeepEqual(drops, tc.expectedDrops) {
+				t.Errorf("Expected drops %v, got %v", tc.expectedDrops, drops)
+			}
+			if !reflect.DeepEqual(adds, tc.expectedAdds) {
+				t.Errorf("Expected adds %v, got %v", tc.expectedAdds, adds)
+			}
+		})
+	}
+}
+
+func TestTableConstraintsFromRows(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:   "events",
+		tableSchemaAttr: "analytics",
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer"},
+		},
+		tableUniqueAttr: []interface{}{
+			map[string]interface{}{tableConstraintColumnsAttr: []interface{}{"b"}},
+			map[string]interface{}{tableConstraintColumnsAttr: []interface{}{"a"}},
+		},
+	})
+	rows := []tableConstraintRow{
+		{name: "events_a_key", constraintType: "UNIQUE", column: "a"},
+		{name: "events_b_key", constraintType: "UNIQUE", column: "b"},
+		{name: "events_pkey", constraintType: "PRIMARY KEY", column: "id"},
+		{name: "events_pkey", constraintType: "PRIMARY KEY", column: "a"},
+		{name: "events_user_fkey", constraintType: "FOREIGN KEY", column: "user_id", referencesSchema: "crm", referencesTable: "users", referencesColumn: "id"},
+		{name: "events_user_fkey", constraintType: "FOREIGN KEY", column: "org_id", referencesSchema: "crm", referencesTable: "users", referencesColumn: "org_id"},
+	}
+
+	expected := map[string][]interface{}{
+		tablePrimaryKeyAttr: {
+			map[string]interface{}{tableConstraintNameAttr: "events_pkey", tableConstraintColumnsAttr: []interface{}{"id", "a"}},
+		},
+		// In the configured order.
+		tableUniqueAttr: {
+			map[string]interface{}{tableConstraintNameAttr: "events_b_key", tableConstraintColumnsAttr: []interface{}{"b"}},
+			map[string]interface{}{tableConstraintNameAttr: "events_a_key", tableConstraintColumnsAttr: []interface{}{"a"}},
+		},
+		tableForeignKeyAttr: {
+			map[string]interface{}{
+				tableConstraintNameAttr:              "events_user_fkey",
+				tableConstraintColumnsAttr:           []interface{}{"user_id", "org_id"},
+				tableConstraintReferencesSchemaAttr:  "crm",
+				tableConstraintReferencesTableAttr:   "users",
+				tableConstraintReferencesColumnsAttr: []interface{}{"id", "org_id"},
+			},
+		},
+	}
+	if result := tableConstraintsFromRows(rows, d); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCreateQueriesIfNotExists(t *testing.T) {
+	table := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:   "events",
+		tableSchemaAttr: "analytics",
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer"},
+		},
+		tableIfNotExistsAttr: true,
+	})
+	expected := `CREATE TABLE IF NOT EXISTS "analytics"."events" ("id" integer)`
+	if query := createTableQuery(table); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+
+	schemaData := schema.TestResourceDataRaw(t, redshiftSchema().Schema, map[string]interface{}{
+		schemaNameAttr:        "analytics",
+		schemaIfNotExistsAttr: true,
+	})
+	expected = `CREATE SCHEMA IF NOT EXISTS "analytics" QUOTA UNLIMITED`
+	if query := createInternalSchemaQuery(schemaData); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+}
+
+func TestCreateTableQueryQuotesMixedCaseNames(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftTable().Schema, map[string]interface{}{
+		tableNameAttr:   "MyTable",
+		tableSchemaAttr: "Analytics",
+		tableColumnAttr: []interface{}{
+			map[string]interface{}{tableColumnNameAttr: "id", tableColumnTypeAttr: "integer"},
+		},
+	})
+	expected := `CREATE TABLE "Analytics"."MyTable" ("id" integer)`
+	if query := createTableQuery(d); query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+
+	if !suppressIdentifierCaseDiff(tableNameAttr, "mytable", "MyTable", nil) {
+		t.Errorf("Expected names only differing by case to be equal")
+	}
+	if suppressIdentifierCaseDiff(tableNameAttr, "mytable", "my_table", nil) {
+		t.Errorf("Expected different names not to be equal")
+	}
+}
@@ -2,39 +2,107 @@ package redshift
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"log"
+	"math/big"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
 )
 
 const (
-	userNameAttr           = "name"
-	userPasswordAttr       = "password"
-	userValidUntilAttr     = "valid_until"
-	userCreateDBAttr       = "create_database"
-	userConnLimitAttr      = "connection_limit"
-	userSyslogAccessAttr   = "syslog_access"
-	userSuperuserAttr      = "superuser"
-	userSessionTimeoutAttr = "session_timeout"
+	userNameAttr             = "name"
+	userPasswordAttr         = "password"
+	userPasswordHashAttr     = "password_hash"
+	userEncryptedAttr        = "encrypted"
+	userPasswordDisabledAttr = "password_disabled"
+	userValidUntilAttr       = "valid_until"
+	userCreateDBAttr         = "create_database"
+	userConnLimitAttr        = "connection_limit"
+	userSyslogAccessAttr     = "syslog_access"
+	userSuperuserAttr        = "superuser"
+	userSessionTimeoutAttr   = "session_timeout"
+	userParametersAttr       = "parameters"
+	userSearchPathAttr       = "search_path"
+	userResetAllParamsAttr   = "reset_all_parameters"
+	userInGroupsAttr         = "in_groups"
+	userInRolesAttr          = "in_roles"
+	userReassignOwnedToAttr  = "reassign_owned_to"
+	userExternalAttr         = "external"
+	userAdoptExistingAttr    = "adopt_existing"
+
+	userGeneratePasswordAttr         = "generate_password"
+	userGeneratedPasswordAttr        = "generated_password"
+	userGeneratedPasswordLengthAttr  = "generated_password_length"
+	userGeneratedPasswordSpecialAttr = "generated_password_special_characters"
 
 	// defaults
 	defaultUserSyslogAccess          = "RESTRICTED"
 	defaultUserSuperuserSyslogAccess = "UNRESTRICTED"
+
+	defaultUserGeneratedPasswordLength = 32
 )
 
+// userPasswordHashRegexp matches the MD5 and SHA-256 password hashes accepted by Redshift.
+var userPasswordHashRegexp = regexp.MustCompile(`^(md5[0-9a-f]{32}|sha256\|[0-9a-fA-F]{64}\|\S+)$`)
+
+// userPasswordSpecialCharactersRegexp matches the printable ASCII characters
+// Redshift accepts in passwords, other than letters and digits: all but ', ",
+// \, /, @ and the space.
+var userPasswordSpecialCharactersRegexp = regexp.MustCompile("^[!#$%&()*+,.:;<=>?^_`{|}~\\[\\]-]*$")
+
+// knownUserParameters are the configuration parameters which can be set for a
+// user with ALTER USER ... SET. The search_path has a dedicated attribute.
+var knownUserParameters = map[string]bool{
+	"analyze_threshold_percent":             true,
+	"datestyle":                             true,
+	"describe_field_name_in_uppercase":      true,
+	"downcase_delimited_identifier":         true,
+	"enable_case_sensitive_identifier":      true,
+	"enable_case_sensitive_super_attribute": true,
+	"enable_numeric_rounding":               true,
+	"enable_result_cache_for_session":       true,
+	"enable_vacuum_boost":                   true,
+	"extra_float_digits":                    true,
+	"max_concurrency_scaling_clusters":      true,
+	"mv_enable_aqmv_for_session":            true,
+	"navigate_super_null_on_error":          true,
+	"parse_super_null_on_error":             true,
+	"query_group":                           true,
+	"spectrum_enable_pseudo_columns":        true,
+	"spectrum_query_maxerror":               true,
+	"statement_timeout":                     true,
+	"timezone":                              true,
+	"wlm_query_slot_count":                  true,
+}
+
+// userParameterNameRegexp matches the names of the configuration parameters,
+// which are lowercase SQL identifiers.
+var userParameterNameRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
 // When authenticating using temporary credentials obtained by GetClusterCredentials,
 // the resulting username is prefixed with either "IAM:"" or "IAMA:"
 // This regexp is designed to match either prefix.
 // See https://docs.aws.amazon.com/redshift/latest/APIReference/API_GetClusterCredentials.html
 var temporaryCredentialsUsernamePrefixRegexp = regexp.MustCompile("^(?:IAMA?:)")
 
+// externalUsernamePrefixRegexp matches the prefixes of the users Redshift
+// creates for IAM and identity provider federation.
+var externalUsernamePrefixRegexp = regexp.MustCompile("(?i)^(?:IAMA?|IAMR|AWSIDC):")
+
 // Resolve the "real" username by stripping the temporary credentials prefix
 func permanentUsername(username string) string {
 	return temporaryCredentialsUsernamePrefixRegexp.ReplaceAllString(username, "")
@@ -45,9 +113,9 @@ func redshiftUser() *schema.Resource {
 		Description: `
 Amazon Redshift user accounts can only be created and dropped by a database superuser. Users are authenticated when they login to Amazon Redshift. They can own databases and database objects (for example, tables) and can grant privileges on those objects to users, groups, and schemas to control who has access to which object. Users with CREATE DATABASE rights can create databases and grant privileges to those databases. Superusers have database ownership privileges for all databases.
 `,
-		CreateContext: RedshiftResourceFunc(resourceRedshiftUserCreate),
+		CreateContext: resourceRedshiftUserCreateOrAdopt,
 		ReadContext:   RedshiftResourceFunc(resourceRedshiftUserRead),
-		UpdateContext: RedshiftResourceFunc(resourceRedshiftUserUpdate),
+		UpdateContext: warnOnUserRename(RedshiftResourceFunc(resourceRedshiftUserUpdate)),
 		DeleteContext: RedshiftResourceFunc(
 			RedshiftResourceRetryOnPQErrors(resourceRedshiftUserDelete),
 		),
@@ -55,62 +123,142 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, p interface{}) error {
-			isSuperuser := d.Get(userSuperuserAttr).(bool)
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, p interface{}) error {
+				isSuperuser := d.Get(userSuperuserAttr).(bool)
+
+				isPasswordKnown := d.NewValueKnown(userPasswordAttr) && d.NewValueKnown(userPasswordHashAttr)
+				password, hasPassword := d.GetOk(userPasswordAttr)
+				if !hasPassword {
+					password, hasPassword = d.GetOk(userPasswordHashAttr)
+				}
+				if d.Get(userGeneratePasswordAttr).(bool) {
+					password, hasPassword = "generated", true
+				}
+				if isSuperuser && isPasswordKnown && (!hasPassword || password.(string) == "") {
+					return fmt.Errorf("Users that are superusers must define a password.")
+				}
 
-			isPasswordKnown := d.NewValueKnown(userPasswordAttr)
-			password, hasPassword := d.GetOk(userPasswordAttr)
-			if isSuperuser && isPasswordKnown && (!hasPassword || password.(string) == "") {
-				return fmt.Errorf("Users that are superusers must define a password.")
-			}
+				if !d.Get(userExternalAttr).(bool) {
+					if err := customizeUserPasswordDisabled(d, isPasswordKnown, hasPassword && password.(string) != ""); err != nil {
+						return err
+					}
+				}
 
-			isSyslogAccessKnown := d.NewValueKnown(userSyslogAccessAttr)
-			syslogAccess, hasSyslogAccess := d.GetOk(userSyslogAccessAttr)
-			if isSuperuser && isSyslogAccessKnown && hasSyslogAccess && syslogAccess != defaultUserSuperuserSyslogAccess {
-				return fmt.Errorf("Superusers must have syslog access set to %s.", defaultUserSuperuserSyslogAccess)
-			}
+				isSyslogAccessKnown := d.NewValueKnown(userSyslogAccessAttr)
+				syslogAccess, hasSyslogAccess := d.GetOk(userSyslogAccessAttr)
+				if isSuperuser && isSyslogAccessKnown && hasSyslogAccess && syslogAccess != defaultUserSuperuserSyslogAccess {
+					return fmt.Errorf("Superusers must have syslog access set to %s.", defaultUserSuperuserSyslogAccess)
+				}
 
-			return nil
-		},
+				if err := customizeUserGeneratedPassword(d); err != nil {
+					return err
+				}
+
+				return customizeUserResetAllParameters(d)
+			},
+			customizeGeneratedSQL(generateUserSQL),
+		),
 
 		Schema: map[string]*schema.Schema{
 			userNameAttr: {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The name of the user account to create. The user name can't be `PUBLIC`.",
+				Description: "The name of the user account to create. The user name can't be `PUBLIC`. Changing the name renames the user in place, which resets its password: the configured `password` is set again, but an MD5 hash must be recomputed for the new name.",
 				ValidateFunc: validation.StringNotInSlice([]string{
 					"public",
 				}, true),
 			},
 			userPasswordAttr: {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Sets the user's password. Users can change their own passwords, unless the password is disabled. To disable password, omit this parameter or set it to `null`. Can also be a hashed password rather than the plaintext password. Please refer to the Redshift [CREATE USER documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_CREATE_USER.html) for information on creating a password hash.",
+				ConflictsWith: []string{userPasswordHashAttr},
+			},
+			userPasswordHashAttr: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Sets the user's password from a hash, so that the plaintext password isn't stored in the configuration or the state. Either `md5` followed by the MD5 hash of the password concatenated with the user name, or `sha256|<digest>|<salt>`. Conflicts with `password`.",
+				ConflictsWith: []string{userPasswordAttr},
+				ValidateFunc:  validation.StringMatch(userPasswordHashRegexp, "must be an MD5 hash prefixed with `md5` or a SHA-256 hash in the form `sha256|<digest>|<salt>`"),
+			},
+			userEncryptedAttr: {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Sends the MD5 hash of the plaintext `password` to Redshift instead of the password itself. The hash is computed again with the new name when the user is renamed.",
+				ConflictsWith: []string{userPasswordHashAttr},
+			},
+			userPasswordDisabledAttr: {
+				Type:        schema.TypeBool,
 				Optional:    true,
+				Computed:    true,
+				Description: "Disables password login, e.g. for users authenticating only with IAM. Can't be set to `true` together with `password` or `password_hash`. Setting it to `false` again sets the configured password. When not configured, it reflects whether a password is configured.",
+			},
+			userExternalAttr: {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{userPasswordAttr, userPasswordHashAttr, userPasswordDisabledAttr},
+				Description:   "Marks the user as managed outside of Terraform, e.g. provisioned through SSO or IAM federation. The user is created with `PASSWORD DISABLE` and its password is never changed afterwards, while the other attributes and grants are still managed. When not configured, it's detected for users without a password named with an `IAM:`, `IAMA:`, `IAMR:` or `AWSIDC:` prefix.",
+			},
+			userGeneratePasswordAttr: {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{userPasswordAttr, userPasswordHashAttr, userExternalAttr},
+				Description:   "Generates a random password when creating the user, like an IAM login profile, and exposes it in `generated_password`. The password is kept until `generated_password_length` or `generated_password_special_characters` change, which generate a new one. Conflicts with `password` and `password_hash`.",
+			},
+			userGeneratedPasswordAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
 				Sensitive:   true,
-				Description: "Sets the user's password. Users can change their own passwords, unless the password is disabled. To disable password, omit this parameter or set it to `null`. Can also be a hashed password rather than the plaintext password. Please refer to the Redshift [CREATE USER documentation](https://docs.aws.amazon.com/redshift/latest/dg/r_CREATE_USER.html) for information on creating a password hash.",
+				Description: "The password generated when `generate_password` is set. It's stored in the state, which must be protected accordingly.",
 			},
+			userGeneratedPasswordLengthAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultUserGeneratedPasswordLength,
+				ValidateFunc: validation.IntBetween(8, 64),
+				Description:  "The length of the generated password, between 8 and 64 characters.",
+			},
+			userGeneratedPasswordSpecialAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringMatch(userPasswordSpecialCharactersRegexp, "must only contain printable ASCII characters other than letters, digits, ', \", \\, /, @ and spaces"),
+				Description:  "The special characters the generated password may contain besides letters and digits, e.g. `!#$%&*`. It contains at least one of them when set. The generated password always contains an uppercase letter, a lowercase letter and a digit, as Redshift requires.",
+			},
+			generatedSQLAttr: generatedSQLSchema(),
 			userValidUntilAttr: {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "infinity",
-				Description: "Sets a date and time after which the user's password is no longer valid. By default the password has no time limit.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "infinity",
+				Description:  "Sets a date and time after which the user's password is no longer valid, as an RFC 3339 timestamp, e.g. `2038-01-04T12:00:00Z`, or in the format Redshift returns it, e.g. `2038-01-04 12:00:00+00`. By default the password has no time limit, which is `infinity`.",
+				ValidateFunc: validateUserValidUntil,
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return userValidUntilEqual(oldValue, newValue)
+				},
 			},
 			userCreateDBAttr: {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Allows the user to create new databases. By default user can't create new databases.",
+				Description: "Allows the user to create new databases. By default user can't create new databases. Changing it runs `ALTER USER ... CREATEDB` or `NOCREATEDB` in place.",
 			},
 			userConnLimitAttr: {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      -1,
-				Description:  "The maximum number of database connections the user is permitted to have open concurrently. The limit isn't enforced for superusers.",
-				ValidateFunc: validation.IntAtLeast(-1),
+				Description:  "The maximum number of database connections the user is permitted to have open concurrently. The limit isn't enforced for superusers. Use `-1` (default) for `UNLIMITED`.",
+				ValidateFunc: validation.Any(validation.IntInSlice([]int{-1}), validation.IntAtLeast(1)),
 			},
 			userSyslogAccessAttr: {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "A clause that specifies the level of access that the user has to the Amazon Redshift system tables and views. If `RESTRICTED` (default) is specified, the user can see only the rows generated by that user in user-visible system tables and views. If `UNRESTRICTED` is specified, the user can see all rows in user-visible system tables and views, including rows generated by another user. `UNRESTRICTED` doesn't give a regular user access to superuser-visible tables. Only superusers can see superuser-visible tables.",
+				Description: "A clause that specifies the level of access that the user has to the Amazon Redshift system tables and views. If `RESTRICTED` (default) is specified, the user can see only the rows generated by that user in user-visible system tables and views. If `UNRESTRICTED` is specified, the user can see all rows in user-visible system tables and views, including rows generated by another user. `UNRESTRICTED` doesn't give a regular user access to superuser-visible tables. Only superusers can see superuser-visible tables. Setting it requires the provider's user to be a superuser.",
 				ValidateFunc: validation.StringInSlice([]string{
 					"RESTRICTED",
 					"UNRESTRICTED",
@@ -126,19 +274,110 @@ Amazon Redshift user accounts can only be created and dropped by a database supe
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: `Determine whether the user is a superuser with all database privileges.`,
+				Description: "Determine whether the user is a superuser with all database privileges. Changing it runs `ALTER USER ... CREATEUSER` or `NOCREATEUSER` in place, which only a superuser can do.",
 			},
 			userSessionTimeoutAttr: {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      0,
 				Description:  "The maximum time in seconds that a session remains inactive or idle. The range is 60 seconds (one minute) to 1,728,000 seconds (20 days). If no session timeout is set for the user, the cluster setting applies.",
-				ValidateFunc: validation.All(validation.IntAtLeast(60), validation.IntAtMost(1728000)),
+				ValidateFunc: validation.IntBetween(60, 1728000),
+			},
+			userParametersAttr: {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Description:      "Configuration parameters set for the user with `ALTER USER ... SET`, e.g. `statement_timeout` or `query_group`. They apply to the sessions the user opens afterwards. Removing a parameter resets it to the default of the cluster. Use `search_path` to set the schema search path.",
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateUserParameters,
+			},
+			userSearchPathAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The schemas searched, in order, for objects referenced without a schema in the sessions of the user, e.g. `[\"$user\", \"public\"]`. `$user` stands for the schema named like the user. An empty list resets the search path to the default of the cluster.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			userResetAllParamsAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Clears all the configuration parameters of the user, including the search path, with a single `ALTER USER ... RESET ALL` when it's switched to `true`. This helps when the parameters were managed outside of Terraform. It can't be combined with non-empty `parameters` or `search_path`.",
+			},
+			userInGroupsAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Names of the groups the user is a member of. When it isn't set or is empty, the memberships are read without being managed. Don't manage the same memberships with the `users` of `redshift_group` as well, the two would undo each other's changes: pick either side.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: hashIdentifier,
+			},
+			userInRolesAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Names of the roles granted to the user. When it isn't set or is empty, the roles are read without being managed. Don't grant the same roles with `redshift_grant_role` as well, the two would undo each other's changes: pick either side.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: hashIdentifier,
+			},
+			userAdoptExistingAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Adopts the user into the state instead of failing when a user with the same name already exists, which helps bringing an existing cluster under management. The attributes read from Redshift which differ from the configuration are reported as warnings and changed by the next apply. The password can't be read, so it's left as is until it's changed in the configuration. It's only used when the user is created.",
+			},
+			userReassignOwnedToAttr: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Name of the user the databases, schemas, tables, views and functions owned by this user are transferred to before it is dropped, since a user owning objects can't be dropped. Defaults to the user the provider connects with. This may move the ownership of many objects at once, so set it deliberately. It's only used when the user is deleted.",
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
 			},
 		},
 	}
 }
 
+// validateUserParameters fails on malformed parameter names and warns on names
+// which aren't known to be settable for a user.
+func validateUserParameters(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name := range v.(map[string]interface{}) {
+		if name == userSearchPathAttr {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "The search_path can't be set as a parameter",
+				Detail:        fmt.Sprintf("Use the `%s` attribute instead.", userSearchPathAttr),
+				AttributePath: path,
+			})
+			continue
+		}
+
+		if !userParameterNameRegexp.MatchString(name) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Invalid parameter name %q", name),
+				Detail:        "Parameter names must be lowercase and only contain letters, digits and underscores.",
+				AttributePath: path,
+			})
+			continue
+		}
+
+		if !knownUserParameters[name] {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Unknown parameter %q", name),
+				Detail:        "The parameter isn't known to be settable for a user. Redshift will reject it if it doesn't exist.",
+				AttributePath: path,
+			})
+		}
+	}
+
+	return diags
+}
+
 func resourceRedshiftUserExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
 	var name string
 	err := db.QueryRow("SELECT usename FROM pg_user_info WHERE usesysid = $1", d.Id()).Scan(&name)
@@ -153,18 +392,11 @@ func resourceRedshiftUserExists(db *DBConnection, d *schema.ResourceData) (bool,
 	return true, nil
 }
 
-func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
-	if err != nil {
-		return err
-	}
-	defer deferredRollback(tx)
-
+func createUserQuery(d resourceValues) string {
 	stringOpts := []struct {
 		hclKey string
 		sqlKey string
 	}{
-		{userPasswordAttr, "PASSWORD"},
 		{userValidUntilAttr, "VALID UNTIL"},
 		{userSyslogAccessAttr, "SYSLOG ACCESS"},
 	}
@@ -186,14 +418,11 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 		{userCreateDBAttr, "CREATEDB", "NOCREATEDB"},
 	}
 
-	createOpts := make([]string, 0, len(stringOpts)+len(intOpts)+len(boolOpts))
+	createOpts := make([]string, 0, 1+len(stringOpts)+len(intOpts)+len(boolOpts))
+	createOpts = append(createOpts, userPasswordToSQL(d))
 	for _, opt := range stringOpts {
 		v, ok := d.GetOk(opt.hclKey)
 		if !ok {
-			if opt.hclKey == userPasswordAttr {
-				createOpts = append(createOpts, "PASSWORD DISABLE")
-			}
-
 			if opt.hclKey == userSyslogAccessAttr {
 				if d.Get(userSuperuserAttr).(bool) {
 					createOpts = append(createOpts, "SYSLOG ACCESS UNRESTRICTED")
@@ -208,15 +437,8 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 		val := v.(string)
 		if val != "" {
 			switch {
-			case opt.hclKey == userPasswordAttr:
-				createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(val)))
 			case opt.hclKey == userValidUntilAttr:
-				switch {
-				case v.(string) == "", strings.ToLower(v.(string)) == "infinity":
-					createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, "infinity"))
-				default:
-					createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(val)))
-				}
+				createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(userValidUntilToSQL(val))))
 			case opt.hclKey == userSyslogAccessAttr:
 				createOpts = append(createOpts, fmt.Sprintf("%s %s", opt.sqlKey, val))
 			default:
@@ -227,9 +449,14 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 
 	for _, opt := range intOpts {
 		val := d.Get(opt.hclKey).(int)
-		if opt.hclKey == userSessionTimeoutAttr && val != 0 {
-			createOpts = append(createOpts, fmt.Sprintf("%s %d", opt.sqlKey, val))
-		} else if opt.hclKey != userSessionTimeoutAttr {
+		switch {
+		case opt.hclKey == userSessionTimeoutAttr:
+			if val != 0 {
+				createOpts = append(createOpts, fmt.Sprintf("%s %d", opt.sqlKey, val))
+			}
+		case opt.hclKey == userConnLimitAttr:
+			createOpts = append(createOpts, fmt.Sprintf("%s %s", opt.sqlKey, userConnLimitToSQL(val)))
+		default:
 			createOpts = append(createOpts, fmt.Sprintf("%s %d", opt.sqlKey, val))
 		}
 	}
@@ -245,23 +472,119 @@ func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error
 
 	userName := d.Get(userNameAttr).(string)
 	createStr := strings.Join(createOpts, " ")
-	sql := fmt.Sprintf("CREATE USER %s WITH %s", pq.QuoteIdentifier(userName), createStr)
+	return fmt.Sprintf("CREATE USER %s WITH %s", pq.QuoteIdentifier(userName), createStr)
+}
 
-	if _, err := tx.Exec(sql); err != nil {
-		return fmt.Errorf("error creating user %s: %w", userName, err)
+func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := setUserGeneratedPassword(d); err != nil {
+		return err
 	}
 
-	var usesysid string
-	if err := tx.QueryRow("SELECT usesysid FROM pg_user_info WHERE usename = $1", userName).Scan(&usesysid); err != nil {
-		return fmt.Errorf("user does not exist in pg_user_info table: %w", err)
+	err := db.WithTx(func(tx *DBTransaction) error {
+		userName := d.Get(userNameAttr).(string)
+		if _, err := tx.Exec(createUserQuery(d)); err != nil {
+			return fmt.Errorf("error creating user %s: %w", userName, createObjectError(err, "redshift_user"))
+		}
+
+		var usesysid string
+		if err := tx.QueryRow("SELECT usesysid FROM pg_user_info WHERE usename = $1", userName).Scan(&usesysid); err != nil {
+			return fmt.Errorf("user does not exist in pg_user_info table: %w", err)
+		}
+
+		if err := setUserParameters(tx, d); err != nil {
+			return err
+		}
+
+		if err := setUserSearchPath(tx, d); err != nil {
+			return err
+		}
+
+		if err := setUserMemberships(tx, d); err != nil {
+			return err
+		}
+
+		// The ID is only set once the user is committed, so that a rollback
+		// doesn't leave a user which doesn't exist in the state.
+		d.SetId(usesysid)
+		return nil
+	})
+	if err != nil {
+		d.SetId("")
+		return err
 	}
 
-	d.SetId(usesysid)
+	return resourceRedshiftUserReadImpl(db, d)
+}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("could not commit transaction: %w", err)
+// userAdoptedAttrs are compared with the configuration when adopting a user.
+var userAdoptedAttrs = []string{
+	userSuperuserAttr,
+	userCreateDBAttr,
+	userConnLimitAttr,
+	userSyslogAccessAttr,
+	userSessionTimeoutAttr,
+	userSearchPathAttr,
+	userParametersAttr,
+}
+
+// resourceRedshiftUserCreateOrAdopt creates the user, or adopts it when it
+// already exists and adopt_existing is set, warning about the attributes which
+// differ from the configuration.
+func resourceRedshiftUserCreateOrAdopt(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	configured := map[string]interface{}{}
+	for _, attr := range userAdoptedAttrs {
+		v, ok := d.GetOk(attr)
+		if !ok && attr == userSyslogAccessAttr {
+			// The default syslog access depends on the superuser attribute.
+			continue
+		}
+		configured[attr] = v
 	}
 
+	adopted := false
+	diags := RedshiftResourceFunc(func(db *DBConnection, d *schema.ResourceData) error {
+		err := resourceRedshiftUserCreate(db, d)
+		if !isDuplicate(err) || !d.Get(userAdoptExistingAttr).(bool) {
+			return err
+		}
+
+		adopted = true
+		return resourceRedshiftUserAdopt(db, d)
+	})(ctx, d, meta)
+	if !adopted || diags.HasError() {
+		return diags
+	}
+
+	userName := d.Get(userNameAttr).(string)
+	for _, attr := range userAdoptedAttrs {
+		expected, ok := configured[attr]
+		if !ok || reflect.DeepEqual(expected, d.Get(attr)) {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Adopted user %s differs from the configuration", userName),
+			Detail:   fmt.Sprintf("`%s` is %v in Redshift but %v in the configuration, the next apply changes it.", attr, d.Get(attr), expected),
+		})
+	}
+
+	return diags
+}
+
+// resourceRedshiftUserAdopt reads the existing user into the state.
+func resourceRedshiftUserAdopt(db *DBConnection, d *schema.ResourceData) error {
+	userName := d.Get(userNameAttr).(string)
+
+	var usesysid string
+	if err := db.QueryRow("SELECT usesysid FROM pg_user_info WHERE usename = $1", userName).Scan(&usesysid); err != nil {
+		return fmt.Errorf("could not adopt existing user %s: %w", userName, err)
+	}
+
+	log.Printf("[WARN] Adopting existing user %s (%s) into the state", userName, usesysid)
+	d.SetId(usesysid)
+	// The generated password wasn't set, a new one is set by the next apply.
+	d.Set(userGeneratedPasswordAttr, "")
+
 	return resourceRedshiftUserReadImpl(db, d)
 }
 
@@ -271,7 +594,7 @@ func resourceRedshiftUserRead(db *DBConnection, d *schema.ResourceData) error {
 
 func resourceRedshiftUserReadImpl(db *DBConnection, d *schema.ResourceData) error {
 	var userName, userValidUntil, userConnLimit, userSyslogAccess, userSessionTimeout string
-	var userSuperuser, userCreateDB bool
+	var userSuperuser, userCreateDB, userPasswordDisabled bool
 
 	columns := []string{
 		"user_name",
@@ -304,7 +627,7 @@ func resourceRedshiftUserReadImpl(db *DBConnection, d *schema.ResourceData) erro
 		return fmt.Errorf("Error reading User: %w", err)
 	}
 
-	err = db.QueryRow("SELECT COALESCE(valuntil, 'infinity') FROM pg_user_info WHERE usesysid = $1", useSysID).Scan(&userValidUntil)
+	err = db.QueryRow("SELECT COALESCE(valuntil, 'infinity'), passwd IS NULL FROM pg_user_info WHERE usesysid = $1", useSysID).Scan(&userValidUntil, &userPasswordDisabled)
 	switch {
 	case err == sql.ErrNoRows:
 		log.Printf("[WARN] Redshift User (%s) not found", useSysID)
@@ -330,23 +653,104 @@ func resourceRedshiftUserReadImpl(db *DBConnection, d *schema.ResourceData) erro
 	d.Set(userSuperuserAttr, userSuperuser)
 	d.Set(userSyslogAccessAttr, userSyslogAccess)
 	d.Set(userConnLimitAttr, userConnLimitNumber)
-	d.Set(userValidUntilAttr, userValidUntil)
+	// Keep the configured format when it's the same point in time.
+	if !userValidUntilEqual(userValidUntil, d.Get(userValidUntilAttr).(string)) {
+		d.Set(userValidUntilAttr, userValidUntil)
+	}
 	d.Set(userSessionTimeoutAttr, userSessionTimeoutNumber)
+	d.Set(userPasswordDisabledAttr, userPasswordDisabled)
+	if _, ok := d.GetOkExists(userExternalAttr); !ok {
+		d.Set(userExternalAttr, isExternalUser(userName, userPasswordDisabled))
+	}
+
+	var userConfig []string
+	if err := db.QueryRow("SELECT useconfig FROM pg_user_info WHERE usesysid = $1", useSysID).Scan(pq.Array(&userConfig)); err != nil {
+		return fmt.Errorf("Error reading User parameters: %w", err)
+	}
+	parameters := parseUserConfig(userConfig)
+	d.Set(userSearchPathAttr, parseUserSearchPath(parameters[userSearchPathAttr]))
+	delete(parameters, userSearchPathAttr)
+	d.Set(userParametersAttr, parameters)
+
+	return readUserMemberships(db, d)
+}
+
+// readUserMemberships reads the groups the user is a member of and, when the
+// cluster supports roles, the roles granted to it.
+func readUserMemberships(db *DBConnection, d *schema.ResourceData) error {
+	groups, err := queryNames(db, "SELECT groname FROM pg_group WHERE $1 = ANY(grolist)", d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading User groups: %w", err)
+	}
+	d.Set(userInGroupsAttr, groups)
+
+	supportsRoles, err := db.supports(clusterFeatureRoles)
+	if err != nil {
+		return err
+	}
+	roles := []string{}
+	if supportsRoles {
+		if roles, err = queryNames(db, "SELECT role_name FROM svv_user_grants WHERE user_name = $1", d.Get(userNameAttr).(string)); err != nil {
+			return fmt.Errorf("Error reading User roles: %w", err)
+		}
+	}
+	d.Set(userInRolesAttr, roles)
 
 	return nil
 }
 
+// queryNames returns the single column of the rows of the query.
+func queryNames(db *DBConnection, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// parseUserConfig converts the name=value entries of pg_user.useconfig to a map.
+func parseUserConfig(userConfig []string) map[string]string {
+	parameters := map[string]string{}
+	for _, entry := range userConfig {
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		parameters[strings.ToLower(name)] = value
+	}
+
+	return parameters
+}
+
 func resourceRedshiftUserDelete(db *DBConnection, d *schema.ResourceData) error {
 	useSysID := d.Id()
 	userName := d.Get(userNameAttr).(string)
 	newOwnerName := permanentUsername(db.client.config.Username)
+	if v, ok := d.GetOk(userReassignOwnedToAttr); ok {
+		newOwnerName = v.(string)
+	}
 
-	tx, err := startTransaction(db.client, "")
+	tx, err := startTransaction(db, "")
 	if err != nil {
 		return err
 	}
 	defer deferredRollback(tx)
 
+	if err := checkOwnerExists(tx, newOwnerName); err != nil {
+		return fmt.Errorf("Error reassigning the objects owned by user %s: %w", userName, err)
+	}
+
 	// Based on https://github.com/awslabs/amazon-redshift-utils/blob/master/src/AdminViews/v_find_dropuser_objs.sql
 	var reassignOwnerGenerator = `SELECT owner.ddl
 			FROM (
@@ -443,12 +847,19 @@ func resourceRedshiftUserDelete(db *DBConnection, d *schema.ResourceData) error
 }
 
 func resourceRedshiftUserUpdate(db *DBConnection, d *schema.ResourceData) error {
-	tx, err := startTransaction(db.client, "")
-	if err != nil {
+	if err := setUserGeneratedPassword(d); err != nil {
+		return err
+	}
+
+	if err := db.WithTx(func(tx *DBTransaction) error { return updateUser(tx, d) }); err != nil {
 		return err
 	}
-	defer deferredRollback(tx)
 
+	return resourceRedshiftUserReadImpl(db, d)
+}
+
+// updateUser runs the statements applying the changed attributes, in order.
+func updateUser(tx sqlExecutor, d resourceValues) error {
 	if err := setUserName(tx, d); err != nil {
 		return err
 	}
@@ -480,14 +891,77 @@ func resourceRedshiftUserUpdate(db *DBConnection, d *schema.ResourceData) error
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("could not commit transaction: %w", err)
+	// Resetting all the parameters leaves none to set, the configured ones
+	// being empty.
+	if d.HasChange(userResetAllParamsAttr) && d.Get(userResetAllParamsAttr).(bool) {
+		return resetAllUserParameters(tx, d)
 	}
 
-	return resourceRedshiftUserReadImpl(db, d)
+	if err := setUserParameters(tx, d); err != nil {
+		return err
+	}
+
+	if err := setUserSearchPath(tx, d); err != nil {
+		return err
+	}
+
+	return setUserMemberships(tx, d)
+}
+
+// generateUserSQL records the statements creating or updating the user.
+func generateUserSQL(tx sqlExecutor, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" {
+		return updateUser(tx, d)
+	}
+
+	if _, err := tx.Exec(createUserQuery(d)); err != nil {
+		return err
+	}
+	if err := setUserParameters(tx, d); err != nil {
+		return err
+	}
+	if err := setUserSearchPath(tx, d); err != nil {
+		return err
+	}
+	return setUserMemberships(tx, d)
 }
 
-func setUserName(tx *sql.Tx, d *schema.ResourceData) error {
+// warnOnUserRename warns about the password after a user was renamed. Redshift
+// clears the password on rename, as MD5 hashes use the user name as salt.
+func warnOnUserRename(update schema.UpdateContextFunc) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		renamed := d.HasChange(userNameAttr)
+		oldName, _ := d.GetChange(userNameAttr)
+
+		diags := update(ctx, d, meta)
+		if !renamed || diags.HasError() {
+			return diags
+		}
+
+		detail := "The configured password was set again after the rename."
+		password := d.Get(userPasswordHashAttr).(string)
+		if password == "" {
+			password = d.Get(userPasswordAttr).(string)
+		}
+		if password == "" {
+			password = d.Get(userGeneratedPasswordAttr).(string)
+		}
+		switch {
+		case password == "":
+			detail = "No password is configured, the user can't log in with a password."
+		case strings.HasPrefix(password, "md5"):
+			detail = "The configured password is an MD5 hash, which must be computed with the new user name for the user to log in."
+		}
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Renaming user %s to %s reset its password", oldName, d.Get(userNameAttr)),
+			Detail:   detail,
+		})
+	}
+}
+
+func setUserName(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(userNameAttr) {
 		return nil
 	}
@@ -508,34 +982,198 @@ func setUserName(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setUserPassword(tx *sql.Tx, d *schema.ResourceData) error {
-	if !d.HasChange(userPasswordAttr) && !d.HasChange(userNameAttr) {
+func setUserPassword(tx sqlExecutor, d resourceValues) error {
+	if !d.HasChanges(userPasswordAttr, userPasswordHashAttr, userEncryptedAttr, userPasswordDisabledAttr, userNameAttr, userExternalAttr, userGeneratePasswordAttr, userGeneratedPasswordAttr) {
+		return nil
+	}
+	if d.Get(userExternalAttr).(bool) {
 		return nil
 	}
 
 	userName := d.Get(userNameAttr).(string)
-	password := d.Get(userPasswordAttr).(string)
-
-	passwdTok := "PASSWORD DISABLE"
-	if password != "" {
-		passwdTok = fmt.Sprintf("PASSWORD '%s'", pqQuoteLiteral(password))
-	}
 
-	sql := fmt.Sprintf("ALTER USER %s %s", pq.QuoteIdentifier(userName), passwdTok)
+	sql := fmt.Sprintf("ALTER USER %s %s", pq.QuoteIdentifier(userName), userPasswordToSQL(d))
 	if _, err := tx.Exec(sql); err != nil {
 		return fmt.Errorf("Error updating user password: %w", err)
 	}
 	return nil
 }
 
-func setUserConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
+// customizeUserResetAllParameters rejects resetting all the parameters while
+// some are configured, as it's ambiguous which should win.
+func customizeUserResetAllParameters(d *schema.ResourceDiff) error {
+	if !d.Get(userResetAllParamsAttr).(bool) {
+		return nil
+	}
+
+	if parameters := d.Get(userParametersAttr).(map[string]interface{}); d.NewValueKnown(userParametersAttr) && len(parameters) > 0 {
+		return fmt.Errorf("%s can't be combined with non-empty %s", userResetAllParamsAttr, userParametersAttr)
+	}
+	if searchPath := d.Get(userSearchPathAttr).([]interface{}); d.NewValueKnown(userSearchPathAttr) && len(searchPath) > 0 {
+		return fmt.Errorf("%s can't be combined with a non-empty %s", userResetAllParamsAttr, userSearchPathAttr)
+	}
+
+	return nil
+}
+
+// customizeUserPasswordDisabled rejects a disabled password together with a
+// configured one. When password_disabled isn't configured, it follows whether
+// a password is configured, so that adding or removing the password doesn't
+// get hidden by the value read from Redshift.
+func customizeUserPasswordDisabled(d *schema.ResourceDiff, isPasswordKnown bool, hasPassword bool) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	if !rawConfig.GetAttr(userPasswordDisabledAttr).IsNull() {
+		if d.Get(userPasswordDisabledAttr).(bool) && hasPassword {
+			return fmt.Errorf("`%s` can't be set to true when `%s` or `%s` is configured", userPasswordDisabledAttr, userPasswordAttr, userPasswordHashAttr)
+		}
+		return nil
+	}
+
+	if !isPasswordKnown {
+		return d.SetNewComputed(userPasswordDisabledAttr)
+	}
+	if d.Get(userPasswordDisabledAttr).(bool) == hasPassword {
+		return d.SetNew(userPasswordDisabledAttr, !hasPassword)
+	}
+	return nil
+}
+
+// userPasswordToSQL renders the PASSWORD clause from the password hash or the
+// plaintext password, or disables the password when neither is configured.
+func userPasswordToSQL(d resourceValues) string {
+	if d.Get(userPasswordDisabledAttr).(bool) || d.Get(userExternalAttr).(bool) {
+		return "PASSWORD DISABLE"
+	}
+
+	password := d.Get(userPasswordHashAttr).(string)
+	if password == "" {
+		password = d.Get(userPasswordAttr).(string)
+		if d.Get(userGeneratePasswordAttr).(bool) {
+			password = d.Get(userGeneratedPasswordAttr).(string)
+			// The password is only generated when applying.
+			if password == "" {
+				return "PASSWORD '***'"
+			}
+		}
+		if password != "" && d.Get(userEncryptedAttr).(bool) {
+			password = md5PasswordHash(password, d.Get(userNameAttr).(string))
+		}
+	}
+
+	if password == "" {
+		return "PASSWORD DISABLE"
+	}
+	return fmt.Sprintf("PASSWORD '%s'", pqQuoteLiteral(password))
+}
+
+// customizeUserGeneratedPassword plans a new generated password when there's
+// none yet or when its length or characters change, and removes it when
+// generate_password is unset.
+func customizeUserGeneratedPassword(d *schema.ResourceDiff) error {
+	if !d.Get(userGeneratePasswordAttr).(bool) {
+		if d.Get(userGeneratedPasswordAttr).(string) != "" {
+			return d.SetNew(userGeneratedPasswordAttr, "")
+		}
+		return nil
+	}
+
+	if d.Get(userGeneratedPasswordAttr).(string) == "" || d.HasChanges(userGeneratedPasswordLengthAttr, userGeneratedPasswordSpecialAttr) {
+		return d.SetNewComputed(userGeneratedPasswordAttr)
+	}
+	return nil
+}
+
+// setUserGeneratedPassword generates the password planned as unknown by
+// customizeUserGeneratedPassword, before it's applied.
+func setUserGeneratedPassword(d *schema.ResourceData) error {
+	if !d.Get(userGeneratePasswordAttr).(bool) {
+		return nil
+	}
+	if d.Get(userGeneratedPasswordAttr).(string) != "" && !d.HasChanges(userGeneratedPasswordLengthAttr, userGeneratedPasswordSpecialAttr) {
+		return nil
+	}
+
+	password, err := generateUserPassword(d.Get(userGeneratedPasswordLengthAttr).(int), d.Get(userGeneratedPasswordSpecialAttr).(string))
+	if err != nil {
+		return fmt.Errorf("could not generate the password: %w", err)
+	}
+	d.Set(userGeneratedPasswordAttr, password)
+	return nil
+}
+
+// generateUserPassword returns a random password of the given length with at
+// least an uppercase letter, a lowercase letter, a digit and, when some are
+// allowed, a special character.
+func generateUserPassword(length int, specialCharacters string) (string, error) {
+	classes := []string{"ABCDEFGHIJKLMNOPQRSTUVWXYZ", "abcdefghijklmnopqrstuvwxyz", "0123456789"}
+	if specialCharacters != "" {
+		classes = append(classes, specialCharacters)
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("the length must be at least %d", len(classes))
+	}
+
+	randomIndex := func(n int) (int, error) {
+		i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+		if err != nil {
+			return 0, err
+		}
+		return int(i.Int64()), nil
+	}
+
+	password := make([]byte, 0, length)
+	for _, class := range classes {
+		i, err := randomIndex(len(class))
+		if err != nil {
+			return "", err
+		}
+		password = append(password, class[i])
+	}
+	all := strings.Join(classes, "")
+	for len(password) < length {
+		i, err := randomIndex(len(all))
+		if err != nil {
+			return "", err
+		}
+		password = append(password, all[i])
+	}
+
+	// Shuffle, so that the characters of each class aren't always first.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+// isExternalUser reports whether the user looks provisioned through IAM or an
+// identity provider, which is how external users are detected when importing.
+func isExternalUser(userName string, passwordDisabled bool) bool {
+	return passwordDisabled && externalUsernamePrefixRegexp.MatchString(userName)
+}
+
+// md5PasswordHash computes the hash Redshift expects for MD5 passwords. The
+// user name is lowercased, as Redshift does for identifiers by default.
+func md5PasswordHash(password, userName string) string {
+	return fmt.Sprintf("md5%x", md5.Sum([]byte(password+strings.ToLower(userName))))
+}
+
+func setUserConnLimit(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(userConnLimitAttr) {
 		return nil
 	}
 
 	connLimit := d.Get(userConnLimitAttr).(int)
 	userName := d.Get(userNameAttr).(string)
-	sql := fmt.Sprintf("ALTER USER %s CONNECTION LIMIT %d", pq.QuoteIdentifier(userName), connLimit)
+	sql := fmt.Sprintf("ALTER USER %s CONNECTION LIMIT %s", pq.QuoteIdentifier(userName), userConnLimitToSQL(connLimit))
 	if _, err := tx.Exec(sql); err != nil {
 		return fmt.Errorf("Error updating user CONNECTION LIMIT: %w", err)
 	}
@@ -543,7 +1181,16 @@ func setUserConnLimit(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setUserSessionTimeout(tx *sql.Tx, d *schema.ResourceData) error {
+// userConnLimitToSQL converts the connection_limit attribute to the CONNECTION LIMIT clause value,
+// where -1 stands for UNLIMITED.
+func userConnLimitToSQL(connLimit int) string {
+	if connLimit == -1 {
+		return "UNLIMITED"
+	}
+	return strconv.Itoa(connLimit)
+}
+
+func setUserSessionTimeout(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(userSessionTimeoutAttr) {
 		return nil
 	}
@@ -563,7 +1210,156 @@ func setUserSessionTimeout(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setUserCreateDB(tx *sql.Tx, d *schema.ResourceData) error {
+func setUserParameters(tx sqlExecutor, d resourceValues) error {
+	if !d.HasChange(userParametersAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(userParametersAttr)
+	for _, sql := range userParametersQueries(d.Get(userNameAttr).(string), oldRaw.(map[string]interface{}), newRaw.(map[string]interface{})) {
+		if _, err := tx.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating user parameters: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// userParametersQueries returns the statements resetting the removed parameters
+// and setting the added or changed ones, sorted by parameter name.
+func userParametersQueries(userName string, oldParameters, newParameters map[string]interface{}) []string {
+	names := []string{}
+	for name := range oldParameters {
+		if _, ok := newParameters[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for name, value := range newParameters {
+		if oldValue, ok := oldParameters[name]; !ok || oldValue != value {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	queries := make([]string, 0, len(names))
+	for _, name := range names {
+		value, ok := newParameters[name]
+		if !ok {
+			queries = append(queries, fmt.Sprintf("ALTER USER %s RESET %s", pq.QuoteIdentifier(userName), name))
+			continue
+		}
+		queries = append(queries, fmt.Sprintf("ALTER USER %s SET %s TO '%s'", pq.QuoteIdentifier(userName), name, pqQuoteLiteral(value.(string))))
+	}
+
+	return queries
+}
+
+func resetAllUserParameters(tx sqlExecutor, d resourceValues) error {
+	if _, err := tx.Exec(fmt.Sprintf("ALTER USER %s RESET ALL", pq.QuoteIdentifier(d.Get(userNameAttr).(string)))); err != nil {
+		return fmt.Errorf("Error resetting user parameters: %w", err)
+	}
+
+	return nil
+}
+
+// setUserMemberships adds the user to the added groups and roles and removes
+// it from the removed ones.
+func setUserMemberships(tx sqlExecutor, d resourceValues) error {
+	userName := d.Get(userNameAttr).(string)
+	for _, attr := range []string{userInGroupsAttr, userInRolesAttr} {
+		if !d.HasChange(attr) {
+			continue
+		}
+
+		oldRaw, newRaw := d.GetChange(attr)
+		for _, query := range userMembershipsQueries(userName, attr, oldRaw.(*schema.Set), newRaw.(*schema.Set)) {
+			if _, err := tx.Exec(query); err != nil {
+				return fmt.Errorf("Error updating user %s: %w", attr, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// userMembershipsQueries returns the statements removing the user from the
+// removed groups or roles, then adding it to the added ones, sorted by name.
+func userMembershipsQueries(userName, attr string, oldNames, newNames *schema.Set) []string {
+	added, removed := groupUsersDelta(oldNames, newNames)
+
+	queries := make([]string, 0, len(removed)+len(added))
+	for _, name := range removed {
+		if attr == userInGroupsAttr {
+			queries = append(queries, fmt.Sprintf("ALTER GROUP %s DROP USER %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(userName)))
+		} else {
+			queries = append(queries, fmt.Sprintf("REVOKE ROLE %s FROM %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(userName)))
+		}
+	}
+	for _, name := range added {
+		if attr == userInGroupsAttr {
+			queries = append(queries, fmt.Sprintf("ALTER GROUP %s ADD USER %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(userName)))
+		} else {
+			queries = append(queries, fmt.Sprintf("GRANT ROLE %s TO %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(userName)))
+		}
+	}
+
+	return queries
+}
+
+func setUserSearchPath(tx sqlExecutor, d resourceValues) error {
+	if !d.HasChange(userSearchPathAttr) {
+		return nil
+	}
+
+	schemas := []string{}
+	for _, schema := range d.Get(userSearchPathAttr).([]interface{}) {
+		schemas = append(schemas, schema.(string))
+	}
+	if _, err := tx.Exec(userSearchPathQuery(d.Get(userNameAttr).(string), schemas)); err != nil {
+		return fmt.Errorf("Error updating user SEARCH_PATH: %w", err)
+	}
+
+	return nil
+}
+
+// userSearchPathQuery sets the search path to the schemas in order, or resets
+// it when there are none.
+func userSearchPathQuery(userName string, schemas []string) string {
+	if len(schemas) == 0 {
+		return fmt.Sprintf("ALTER USER %s RESET search_path", pq.QuoteIdentifier(userName))
+	}
+
+	return fmt.Sprintf("ALTER USER %s SET search_path TO %s", pq.QuoteIdentifier(userName), searchPathSQL(schemas))
+}
+
+// searchPathSQL renders the schemas of a search path in order, each quoted as
+// a literal so that `$user` is kept as is.
+func searchPathSQL(schemas []string) string {
+	quoted := make([]string, len(schemas))
+	for i, schema := range schemas {
+		quoted[i] = fmt.Sprintf("'%s'", pqQuoteLiteral(schema))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// parseUserSearchPath splits the search_path stored in pg_user.useconfig, e.g.
+// `"$user", public`, into the schemas in order.
+func parseUserSearchPath(searchPath string) []string {
+	schemas := []string{}
+	for _, schema := range strings.Split(searchPath, ",") {
+		schema = strings.TrimSpace(schema)
+		if len(schema) >= 2 && strings.HasPrefix(schema, `"`) && strings.HasSuffix(schema, `"`) {
+			schema = strings.ReplaceAll(schema[1:len(schema)-1], `""`, `"`)
+		}
+		if schema != "" {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas
+}
+
+func setUserCreateDB(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(userCreateDBAttr) {
 		return nil
 	}
@@ -576,13 +1372,16 @@ func setUserCreateDB(tx *sql.Tx, d *schema.ResourceData) error {
 	userName := d.Get(userNameAttr).(string)
 	sql := fmt.Sprintf("ALTER USER %s WITH %s", pq.QuoteIdentifier(userName), tok)
 	if _, err := tx.Exec(sql); err != nil {
+		if isPermissionDenied(err) {
+			return fmt.Errorf("Error updating user %s to %s, the provider's user must be a superuser to change it: %w", userName, tok, err)
+		}
 		return fmt.Errorf("Error updating user CREATEDB: %w", err)
 	}
 
 	return nil
 }
 
-func setUserSuperuser(tx *sql.Tx, d *schema.ResourceData) error {
+func setUserSuperuser(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(userSuperuserAttr) {
 		return nil
 	}
@@ -595,13 +1394,16 @@ func setUserSuperuser(tx *sql.Tx, d *schema.ResourceData) error {
 	userName := d.Get(userNameAttr).(string)
 	sql := fmt.Sprintf("ALTER USER %s WITH %s", pq.QuoteIdentifier(userName), tok)
 	if _, err := tx.Exec(sql); err != nil {
+		if isPermissionDenied(err) {
+			return fmt.Errorf("Error updating user %s to %s, only a superuser can grant or revoke superuser: %w", userName, tok, err)
+		}
 		return fmt.Errorf("Error updating user SUPERUSER: %w", err)
 	}
 
 	return nil
 }
 
-func setUserValidUntil(tx *sql.Tx, d *schema.ResourceData) error {
+func setUserValidUntil(tx sqlExecutor, d resourceValues) error {
 	if !d.HasChange(userValidUntilAttr) {
 		return nil
 	}
@@ -609,12 +1411,10 @@ func setUserValidUntil(tx *sql.Tx, d *schema.ResourceData) error {
 	validUntil := d.Get(userValidUntilAttr).(string)
 	if validUntil == "" {
 		return nil
-	} else if strings.ToLower(validUntil) == "infinity" {
-		validUntil = "infinity"
 	}
 
 	userName := d.Get(userNameAttr).(string)
-	sql := fmt.Sprintf("ALTER USER %s VALID UNTIL '%s'", pq.QuoteIdentifier(userName), pqQuoteLiteral(validUntil))
+	sql := fmt.Sprintf("ALTER USER %s VALID UNTIL '%s'", pq.QuoteIdentifier(userName), pqQuoteLiteral(userValidUntilToSQL(validUntil)))
 	if _, err := tx.Exec(sql); err != nil {
 		return fmt.Errorf("Error updating user VALID UNTIL: %w", err)
 	}
@@ -622,7 +1422,65 @@ func setUserValidUntil(tx *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setUserSyslogAccess(tx *sql.Tx, d *schema.ResourceData) error {
+// userValidUntilLayouts are the accepted formats of valid_until besides RFC 3339,
+// the first being the one Redshift returns.
+var userValidUntilLayouts = []string{
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseUserValidUntil parses valid_until, returning a zero time for `infinity`.
+func parseUserValidUntil(validUntil string) (time.Time, error) {
+	if strings.EqualFold(validUntil, "infinity") {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, validUntil); err == nil {
+		return t, nil
+	}
+	for _, layout := range userValidUntilLayouts {
+		if t, err := time.Parse(layout, validUntil); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is neither `infinity` nor an RFC 3339 timestamp, e.g. 2038-01-04T12:00:00Z", validUntil)
+}
+
+func validateUserValidUntil(v interface{}, k string) ([]string, []error) {
+	if v.(string) == "" {
+		return nil, nil
+	}
+	if _, err := parseUserValidUntil(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// userValidUntilEqual reports whether both values are the same point in time.
+func userValidUntilEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	timeA, errA := parseUserValidUntil(a)
+	timeB, errB := parseUserValidUntil(b)
+	return errA == nil && errB == nil && timeA.Equal(timeB)
+}
+
+// userValidUntilToSQL renders valid_until in a format Redshift accepts.
+func userValidUntilToSQL(validUntil string) string {
+	t, err := parseUserValidUntil(validUntil)
+	switch {
+	case err != nil:
+		return validUntil
+	case t.IsZero():
+		return "infinity"
+	}
+	return t.Format("2006-01-02 15:04:05-07:00")
+}
+
+func setUserSyslogAccess(tx sqlExecutor, d resourceValues) error {
 	syslogAccessCurrent := d.Get(userSyslogAccessAttr).(string)
 	syslogAccessComputed := syslogAccessCurrent
 	if syslogAccessComputed == "" {
@@ -640,13 +1498,16 @@ func setUserSyslogAccess(tx *sql.Tx, d *schema.ResourceData) error {
 	userName := d.Get(userNameAttr).(string)
 	sql := fmt.Sprintf("ALTER USER %s WITH SYSLOG ACCESS %s", pq.QuoteIdentifier(userName), syslogAccessComputed)
 	if _, err := tx.Exec(sql); err != nil {
+		if isPermissionDenied(err) {
+			return fmt.Errorf("Error updating user SYSLOG ACCESS to %s, the provider's user must be a superuser to change it: %w", syslogAccessComputed, err)
+		}
 		return fmt.Errorf("Error updating user SYSLOG ACCESS: %w", err)
 	}
 
 	return nil
 }
 
-func getDefaultSyslogAccess(d *schema.ResourceData) string {
+func getDefaultSyslogAccess(d resourceValues) string {
 	if d.Get(userSuperuserAttr).(bool) {
 		return defaultUserSuperuserSyslogAccess
 	}
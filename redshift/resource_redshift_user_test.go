@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"os"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 )
 
 func TestAccRedshiftUser_Basic(t *testing.T) {
@@ -95,6 +97,7 @@ resource "redshift_user" "update_user" {
   create_database = true
 }
 `
+	var userID string
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviders,
@@ -104,6 +107,7 @@ resource "redshift_user" "update_user" {
 				Config: configCreate,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRedshiftUserExists("update_user"),
+					testAccStoreResourceID("redshift_user.update_user", &userID),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "name", "update_user"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "connection_limit", "-1"),
 					resource.TestCheckResourceAttr("redshift_user.update_user", "password", "Foobarbaz1"),
@@ -116,6 +120,8 @@ resource "redshift_user" "update_user" {
 				Config: configUpdate,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRedshiftUserExists("update_user2"),
+					// The user is renamed in place, which keeps its usesysid
+					testAccCheckResourceID("redshift_user.update_user", &userID, true),
 					resource.TestCheckResourceAttr(
 						"redshift_user.update_user", "name", "update_user2",
 					),
@@ -151,6 +157,235 @@ resource "redshift_user" "update_user" {
 	})
 }
 
+func TestAccRedshiftUser_PasswordHash(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_hash"), "-", "_")
+	configHash := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name          = %[1]q
+  password_hash = %[2]q
+}
+`, userName, md5PasswordHash("Foobarbaz1", userName))
+	configEncrypted := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name      = %[1]q
+  password  = "Foobarbaz2"
+  encrypted = true
+}
+`, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configHash,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					testAccCheckRedshiftUserCanLogin(userName, "Foobarbaz1"),
+					resource.TestCheckNoResourceAttr("redshift_user.user", "password"),
+				),
+			},
+			{
+				Config: configEncrypted,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserCanLogin(userName, "Foobarbaz2"),
+					resource.TestCheckResourceAttr("redshift_user.user", "encrypted", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_GeneratePassword(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_generated"), "-", "_")
+	config := func(length int) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name                                  = %[1]q
+  generate_password                     = true
+  generated_password_length             = %[2]d
+  generated_password_special_characters = "!#$%%&*"
+}
+`, userName, length)
+	}
+	canLoginWithGeneratedPassword := func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["redshift_user.user"]
+		if !ok {
+			return fmt.Errorf("redshift_user.user not found")
+		}
+		return testAccCheckRedshiftUserCanLogin(userName, rs.Primary.Attributes[userGeneratedPasswordAttr])(s)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(32),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestMatchResourceAttr("redshift_user.user", userGeneratedPasswordAttr, regexp.MustCompile(`^.{32}$`)),
+					resource.TestCheckResourceAttr("redshift_user.user", userPasswordDisabledAttr, "false"),
+					canLoginWithGeneratedPassword,
+				),
+			},
+			{
+				Config:   config(32),
+				PlanOnly: true,
+			},
+			{
+				Config: config(16),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("redshift_user.user", userGeneratedPasswordAttr, regexp.MustCompile(`^.{16}$`)),
+					canLoginWithGeneratedPassword,
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_PasswordHashConflictsWithPassword(t *testing.T) {
+	config := `
+resource "redshift_user" "user" {
+  name          = "conflicting_passwords"
+  password      = "Foobarbaz1"
+  password_hash = "md508d5d11f1f947091b312fb36b25e621f"
+}
+`
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("conflicts with"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_PasswordDisabled(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_disabled"), "-", "_")
+	configPassword := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name     = %[1]q
+  password = "Foobarbaz1"
+}
+`, userName)
+	configDisabled := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name              = %[1]q
+  password_disabled = true
+}
+`, userName)
+	configEnabled := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name              = %[1]q
+  password          = "Foobarbaz2"
+  password_disabled = false
+}
+`, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configPassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserCanLogin(userName, "Foobarbaz1"),
+					resource.TestCheckResourceAttr("redshift_user.user", "password_disabled", "false"),
+				),
+			},
+			{
+				Config: configDisabled,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "password_disabled", "true"),
+				),
+			},
+			{
+				Config: configEnabled,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserCanLogin(userName, "Foobarbaz2"),
+					resource.TestCheckResourceAttr("redshift_user.user", "password_disabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_External(t *testing.T) {
+	userName := "IAMR:" + strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_external"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name             = %[1]q
+  external         = true
+  connection_limit = 5
+}
+`, userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.user", "external", "true"),
+					resource.TestCheckResourceAttr("redshift_user.user", "password_disabled", "true"),
+				),
+			},
+			{
+				ResourceName:            "redshift_user.user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{generatedSQLAttr},
+			},
+			{
+				// A password set by the identity provider doesn't cause drift.
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*Client)
+					db, err := client.Connect()
+					if err != nil {
+						t.Fatalf("Unexpected error: %s", err)
+					}
+					if _, err := db.Exec(fmt.Sprintf("ALTER USER %s PASSWORD 'Foobarbaz1'", pq.QuoteIdentifier(userName))); err != nil {
+						t.Fatalf("Could not set the password of user %s: %s", userName, err)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_PasswordDisabledConflictsWithPassword(t *testing.T) {
+	config := `
+resource "redshift_user" "user" {
+  name              = "disabled_with_password"
+  password          = "Foobarbaz1"
+  password_disabled = true
+}
+`
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`password_disabled` can't be set to true"),
+			},
+		},
+	})
+}
+
 func TestAccRedshiftUser_UpdateToSuperuser(t *testing.T) {
 
 	var configCreate = `
@@ -313,6 +548,374 @@ func TestAccRedshiftUser_SuperuserSyslogAccess(t *testing.T) {
 
 }
 
+func TestAccRedshiftUser_ConnectionLimit(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_conn_limit"), "-", "_")
+	config := func(connLimit string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  %[2]s
+}
+`, userName, connLimit)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("connection_limit = 10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.user", "connection_limit", "10"),
+				),
+			},
+			{
+				Config: config("connection_limit = -1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "connection_limit", "-1"),
+				),
+			},
+			{
+				Config: config("connection_limit = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "connection_limit", "1"),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "connection_limit", "-1"),
+				),
+			},
+			{
+				Config:      config("connection_limit = 0"),
+				ExpectError: regexp.MustCompile("connection_limit"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_SessionTimeout(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_session_timeout"), "-", "_")
+	config := func(sessionTimeout string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  %[2]s
+}
+`, userName, sessionTimeout)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("session_timeout = 60"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.user", "session_timeout", "60"),
+				),
+			},
+			{
+				Config: config("session_timeout = 1728000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "session_timeout", "1728000"),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "session_timeout", "0"),
+				),
+			},
+			{
+				Config:      config("session_timeout = 59"),
+				ExpectError: regexp.MustCompile(`expected session_timeout to be in the range \(60 - 1728000\)`),
+			},
+			{
+				Config:      config("session_timeout = 1728001"),
+				ExpectError: regexp.MustCompile(`expected session_timeout to be in the range \(60 - 1728000\)`),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_ValidUntil(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_valid_until"), "-", "_")
+	config := func(validUntil string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name        = %[1]q
+  valid_until = %[2]q
+}
+`, userName, validUntil)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The configured format is kept, as Redshift returns the same point in time.
+				Config: config("2038-01-04T12:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.user", "valid_until", "2038-01-04T12:00:00Z"),
+				),
+			},
+			{
+				Config:   config("2038-01-04 12:00:00+00"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("INFINITY"),
+				Check:  resource.TestCheckResourceAttr("redshift_user.user", "valid_until", "INFINITY"),
+			},
+			{
+				Config:      config("next week"),
+				ExpectError: regexp.MustCompile("neither `infinity` nor an RFC 3339 timestamp"),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_Parameters(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_parameters"), "-", "_")
+	config := func(parameters string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  parameters = {
+    %[2]s
+  }
+}
+`, userName, parameters)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`statement_timeout = "60000"
+    query_group = "etl"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.%", "2"),
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.statement_timeout", "60000"),
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.query_group", "etl"),
+				),
+			},
+			{
+				Config: config(`statement_timeout = "120000"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.statement_timeout", "120000"),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.%", "0"),
+				),
+			},
+			{
+				Config:      config(`"Statement-Timeout" = "1"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid parameter name`),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_ResetAllParameters(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_reset_all"), "-", "_")
+	config := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name = %[1]q
+  %[2]s
+}
+`, userName, attributes)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`parameters  = { statement_timeout = "60000" }
+  search_path = ["public"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "1"),
+				),
+			},
+			{
+				Config: config(`reset_all_parameters = true`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "parameters.%", "0"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "0"),
+				),
+			},
+			{
+				Config: config(`reset_all_parameters = true
+  parameters           = { query_group = "etl" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`reset_all_parameters can't be combined with non-empty parameters`),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_Memberships(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_memberships"), "-", "_")
+	config := func(groups, roles string) string {
+		return fmt.Sprintf(`
+resource "redshift_group" "first" {
+  name = "%[1]s_first"
+}
+
+resource "redshift_group" "second" {
+  name = "%[1]s_second"
+}
+
+resource "redshift_role" "role" {
+  name = "%[1]s_role"
+}
+
+resource "redshift_user" "user" {
+  name      = %[1]q
+  in_groups = %[2]s
+  in_roles  = %[3]s
+}
+`, userName, groups, roles)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("[redshift_group.first.name]", "[redshift_role.role.name]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "in_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_user.user", "in_groups.*", userName+"_first"),
+					resource.TestCheckResourceAttr("redshift_user.user", "in_roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_user.user", "in_roles.*", userName+"_role"),
+				),
+			},
+			{
+				Config: config("[redshift_group.second.name]", "[redshift_role.role.name]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "in_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("redshift_user.user", "in_groups.*", userName+"_second"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_SearchPath(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_search_path"), "-", "_")
+	config := func(searchPath string) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name        = %[1]q
+  search_path = %[2]s
+}
+`, userName, searchPath)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["$user", "public"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "2"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.0", "$user"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.1", "public"),
+				),
+			},
+			{
+				Config: config(`["public", "$user"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "2"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.0", "public"),
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.1", "$user"),
+				),
+			},
+			{
+				Config: config("[]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_user.user", "search_path.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftUser_ReassignOwnedTo(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_reassigned"), "-", "_")
+	targetName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_target"), "-", "_")
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_reassigned_schema"), "-", "_")
+	configTarget := fmt.Sprintf(`
+resource "redshift_user" "target" {
+  name = %[1]q
+}
+`, targetName)
+	configSchema := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+`, schemaName)
+	configUser := fmt.Sprintf(`
+resource "redshift_user" "user" {
+  name              = %[1]q
+  reassign_owned_to = redshift_user.target.name
+}
+`, userName)
+	configSchemaOwned := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name  = %[1]q
+  owner = redshift_user.user.name
+}
+`, schemaName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: configTarget + configUser + configSchemaOwned,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("redshift_schema.schema", "owner", userName),
+				),
+			},
+			{
+				// The schema stays owned by the target once the user is dropped.
+				Config: configTarget + configSchema,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftSchemaOwner(schemaName, targetName),
+				),
+			},
+		},
+	})
+}
+
 func TestAccRedshiftUser_SuperuserUnknownPassword(t *testing.T) {
 	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_superuser"), "-", "_")
 	config := fmt.Sprintf(`
@@ -369,6 +972,89 @@ resource "unknown_string" "password" {}
 	})
 }
 
+func TestAccRedshiftUser_AdoptExisting(t *testing.T) {
+	userName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_adopted"), "-", "_")
+	otherName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_user_other"), "-", "_")
+	config := func(adopt bool) string {
+		return fmt.Sprintf(`
+resource "redshift_user" "other" {
+  name = %[2]q
+}
+
+resource "redshift_user" "adopted" {
+  name           = %[1]q
+  adopt_existing = %[3]t
+}
+`, userName, otherName, adopt)
+	}
+
+	createUser := func() {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := db.Exec(fmt.Sprintf("CREATE USER %s PASSWORD DISABLE CONNECTION LIMIT 5", pq.QuoteIdentifier(userName))); err != nil {
+			t.Fatalf("Could not create user %s: %s", userName, err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "redshift_user" "other" {
+  name = %[1]q
+}
+`, otherName),
+			},
+			{
+				// Without adopt_existing, creating a user which already exists fails.
+				PreConfig:   createUser,
+				Config:      config(false),
+				ExpectError: regexp.MustCompile("terraform import redshift_user"),
+			},
+			{
+				// The adopted user keeps its connection limit until the next apply.
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftUserExists(userName),
+					resource.TestCheckResourceAttr("redshift_user.adopted", userConnLimitAttr, "5"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("redshift_user.adopted", userConnLimitAttr, "-1"),
+			},
+		},
+	})
+}
+
+func testAccCheckRedshiftSchemaOwner(schemaName, expectedOwner string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		var owner string
+		err = db.QueryRow("SELECT u.usename FROM pg_namespace n JOIN pg_user u ON u.usesysid = n.nspowner WHERE n.nspname = $1", schemaName).Scan(&owner)
+		if err != nil {
+			return fmt.Errorf("Error reading the owner of schema %s: %w", schemaName, err)
+		}
+		if owner != expectedOwner {
+			return fmt.Errorf("Expected schema %s to be owned by %s, got %s", schemaName, expectedOwner, owner)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckRedshiftUserDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
 
@@ -485,6 +1171,437 @@ func TestPermanentUsername(t *testing.T) {
 	}
 }
 
+func TestWarnOnUserRename(t *testing.T) {
+	tests := map[string]struct {
+		password string
+		detail   string
+	}{
+		"plaintext password": {"Foobarbaz1", "The configured password was set again after the rename."},
+		"md5 password":       {"md508d5d11f1f947091b312fb36b25e621f", "The configured password is an MD5 hash, which must be computed with the new user name for the user to log in."},
+		"no password":        {"", "No password is configured, the user can't log in with a password."},
+	}
+
+	update := func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil }
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftUser().Schema, map[string]interface{}{
+				userNameAttr:     "renamed_user",
+				userPasswordAttr: tc.password,
+			})
+
+			diags := warnOnUserRename(update)(context.Background(), d, nil)
+			if len(diags) != 1 || diags[0].Severity != diag.Warning {
+				t.Fatalf("Expected a single warning, got %v", diags)
+			}
+			if diags[0].Detail != tc.detail {
+				t.Errorf("Expected detail %q, got %q", tc.detail, diags[0].Detail)
+			}
+		})
+	}
+}
+
+func TestUserPasswordToSQL(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		"no password": {
+			input:    map[string]interface{}{userNameAttr: "update_user2"},
+			expected: "PASSWORD DISABLE",
+		},
+		"plaintext password": {
+			input:    map[string]interface{}{userNameAttr: "update_user2", userPasswordAttr: "Foo'barbaz6"},
+			expected: "PASSWORD 'Foo''barbaz6'",
+		},
+		"encrypted password": {
+			input:    map[string]interface{}{userNameAttr: "Update_User2", userPasswordAttr: "Foobarbaz6", userEncryptedAttr: true},
+			expected: "PASSWORD 'md508d5d11f1f947091b312fb36b25e621f'",
+		},
+		"password hash": {
+			input:    map[string]interface{}{userNameAttr: "other_name", userPasswordHashAttr: "md508d5d11f1f947091b312fb36b25e621f"},
+			expected: "PASSWORD 'md508d5d11f1f947091b312fb36b25e621f'",
+		},
+		"password disabled": {
+			input:    map[string]interface{}{userNameAttr: "update_user2", userPasswordDisabledAttr: true},
+			expected: "PASSWORD DISABLE",
+		},
+		"external": {
+			input:    map[string]interface{}{userNameAttr: "IAMR:update_user2", userExternalAttr: true},
+			expected: "PASSWORD DISABLE",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftUser().Schema, tc.input)
+			if result := userPasswordToSQL(d); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateUserPassword(t *testing.T) {
+	for _, special := range []string{"", "!#$%&*"} {
+		password, err := generateUserPassword(8, special)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(password) != 8 {
+			t.Errorf("Expected 8 characters, got %d", len(password))
+		}
+		for _, class := range []string{"ABCDEFGHIJKLMNOPQRSTUVWXYZ", "abcdefghijklmnopqrstuvwxyz", "0123456789", special} {
+			if class != "" && !strings.ContainsAny(password, class) {
+				t.Errorf("Expected the password to contain one of %q", class)
+			}
+		}
+		for _, c := range password {
+			if !strings.ContainsRune("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"+special, c) {
+				t.Errorf("Unexpected character %q", c)
+			}
+		}
+	}
+
+	if _, err := generateUserPassword(3, "!"); err == nil {
+		t.Error("Expected an error when the length can't fit all the classes")
+	}
+}
+
+func TestUserPasswordSpecialCharactersRegexp(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"":                 true,
+		"!#$%&*":           true,
+		"()[]{}<>^_~|-+=.": true,
+		"'":                false,
+		`"`:                false,
+		`\`:                false,
+		"/":                false,
+		"@":                false,
+		" ":                false,
+		"a":                false,
+	} {
+		if actual := userPasswordSpecialCharactersRegexp.MatchString(value); actual != expected {
+			t.Errorf("Expected %q to match: %t, got %t", value, expected, actual)
+		}
+	}
+}
+
+func TestGeneratedPasswordIsRedactedInGeneratedSQL(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		userNameAttr:             "foo",
+		userGeneratePasswordAttr: true,
+	})
+
+	diff, err := redshiftUser().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `CREATE USER "foo" WITH PASSWORD '***' VALID UNTIL 'infinity' SYSLOG ACCESS RESTRICTED CONNECTION LIMIT UNLIMITED NOCREATEUSER NOCREATEDB;`
+	if actual := diff.Attributes[generatedSQLAttr].New; actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+	if !diff.Attributes[userGeneratedPasswordAttr].NewComputed {
+		t.Errorf("Expected %s to be computed", userGeneratedPasswordAttr)
+	}
+
+	d := schema.TestResourceDataRaw(t, redshiftUser().Schema, map[string]interface{}{
+		userNameAttr:             "foo",
+		userGeneratePasswordAttr: true,
+	})
+	if err := setUserGeneratedPassword(d); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	password := d.Get(userGeneratedPasswordAttr).(string)
+	if len(password) != defaultUserGeneratedPasswordLength {
+		t.Fatalf("Expected a password of %d characters, got %d", defaultUserGeneratedPasswordLength, len(password))
+	}
+	recorder := &statementRecorder{}
+	if _, err := recorder.Exec(createUserQuery(d)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if strings.Contains(recorder.String(), password) {
+		t.Errorf("The generated password isn't redacted: %s", recorder.String())
+	}
+}
+
+func TestIsExternalUser(t *testing.T) {
+	tests := []struct {
+		userName         string
+		passwordDisabled bool
+		expected         bool
+	}{
+		{"IAMR:john", true, true},
+		{"AWSIDC:john@example.com", true, true},
+		{"iam:john", true, true},
+		{"IAMR:john", false, false},
+		{"john", true, false},
+	}
+
+	for _, tt := range tests {
+		if result := isExternalUser(tt.userName, tt.passwordDisabled); result != tt.expected {
+			t.Errorf("Expected isExternalUser(%q, %t) to be %t", tt.userName, tt.passwordDisabled, tt.expected)
+		}
+	}
+}
+
+func TestUserPasswordHashRegexp(t *testing.T) {
+	tests := map[string]bool{
+		"md508d5d11f1f947091b312fb36b25e621f":                                              true,
+		"sha256|9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08|somesalt": true,
+		"md508D5D11F1F947091B312FB36B25E621F":                                              false,
+		"md5tooshort":                                                                      false,
+		"sha256|Foobarbaz1":                                                                false,
+		"Foobarbaz1":                                                                       false,
+	}
+
+	for hash, expected := range tests {
+		if result := userPasswordHashRegexp.MatchString(hash); result != expected {
+			t.Errorf("Expected %q to match: %t, got %t", hash, expected, result)
+		}
+	}
+}
+
+func TestUserValidUntil(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected string
+		isValid  bool
+	}{
+		"infinity":          {"Infinity", "infinity", true},
+		"rfc3339":           {"2038-01-04T12:00:00Z", "2038-01-04 12:00:00+00:00", true},
+		"rfc3339 offset":    {"2038-01-04T14:00:00+02:00", "2038-01-04 14:00:00+02:00", true},
+		"redshift format":   {"2038-01-04 12:00:00+00", "2038-01-04 12:00:00+00:00", true},
+		"date":              {"2038-01-04", "2038-01-04 00:00:00+00:00", true},
+		"invalid timestamp": {"2038-13-04", "2038-13-04", false},
+		"invalid":           {"tomorrow", "tomorrow", false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, errs := validateUserValidUntil(tc.value, userValidUntilAttr)
+			if isValid := len(errs) == 0; isValid != tc.isValid {
+				t.Errorf("Expected %q to be valid: %t, got errors %v", tc.value, tc.isValid, errs)
+			}
+			if actual := userValidUntilToSQL(tc.value); actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+
+	if !userValidUntilEqual("2038-01-04T14:00:00+02:00", "2038-01-04 12:00:00+00") {
+		t.Errorf("Expected the same point in time to be equal")
+	}
+	if !userValidUntilEqual("INFINITY", "infinity") {
+		t.Errorf("Expected infinity to be case insensitive")
+	}
+	if userValidUntilEqual("2038-01-04 12:00:00+00", "infinity") {
+		t.Errorf("Expected a timestamp to differ from infinity")
+	}
+}
+
+// failingExecutor rejects all the statements with the given error.
+type failingExecutor struct {
+	err error
+}
+
+func (e failingExecutor) Exec(query string, _ ...interface{}) (sql.Result, error) {
+	return nil, newStatementError(e.err, query)
+}
+
+func TestSetUserSyslogAccessPermissionDenied(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftUser().Schema, map[string]interface{}{
+		userNameAttr:         "foo",
+		userSyslogAccessAttr: "UNRESTRICTED",
+	})
+
+	err := setUserSyslogAccess(failingExecutor{&pq.Error{Code: pgErrorCodeInsufficientPrivileges, Message: "permission denied"}}, d)
+	if err == nil || !strings.Contains(err.Error(), "must be a superuser") || !isPermissionDenied(err) {
+		t.Errorf("Expected a permission denied error explaining superusers are required, got %v", err)
+	}
+}
+
+func TestSetUserPrivilegesQueries(t *testing.T) {
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		expected []string
+	}{
+		"superuser": {
+			raw:      map[string]interface{}{userNameAttr: "foo", userSuperuserAttr: true, userPasswordAttr: "Foobar123"},
+			expected: []string{`ALTER USER "foo" WITH CREATEUSER`},
+		},
+		"create database": {
+			raw:      map[string]interface{}{userNameAttr: "foo", userCreateDBAttr: true},
+			expected: []string{`ALTER USER "foo" WITH CREATEDB`},
+		},
+		"unchanged": {
+			raw:      map[string]interface{}{userNameAttr: "foo"},
+			expected: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, redshiftUser().Schema, tc.raw)
+
+			recorder := &statementRecorder{}
+			if err := setUserCreateDB(recorder, d); err != nil {
+				t.Fatal(err)
+			}
+			if err := setUserSuperuser(recorder, d); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(recorder.statements, tc.expected) {
+				t.Errorf("Expected statements %q, got %q", tc.expected, recorder.statements)
+			}
+		})
+	}
+}
+
+func TestSetUserSuperuserPermissionDenied(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, redshiftUser().Schema, map[string]interface{}{
+		userNameAttr:      "foo",
+		userSuperuserAttr: true,
+		userPasswordAttr:  "Foobar123",
+	})
+
+	err := setUserSuperuser(failingExecutor{&pq.Error{Code: pgErrorCodeInsufficientPrivileges, Message: "permission denied"}}, d)
+	if err == nil || !strings.Contains(err.Error(), "only a superuser") || !isPermissionDenied(err) {
+		t.Errorf("Expected a permission denied error explaining superusers are required, got %v", err)
+	}
+}
+
+func TestUserConnLimitToSQL(t *testing.T) {
+	tests := map[int]string{
+		-1:  "UNLIMITED",
+		1:   "1",
+		500: "500",
+	}
+
+	for connLimit, expected := range tests {
+		if result := userConnLimitToSQL(connLimit); result != expected {
+			t.Errorf("userConnLimitToSQL(%d) = %q, expected %q", connLimit, result, expected)
+		}
+	}
+}
+
+func TestUserParametersQueries(t *testing.T) {
+	oldParameters := map[string]interface{}{
+		"query_group":       "etl",
+		"statement_timeout": "60000",
+		"timezone":          "UTC",
+	}
+	newParameters := map[string]interface{}{
+		"datestyle":         "ISO, MDY",
+		"statement_timeout": "120000",
+		"timezone":          "UTC",
+	}
+	expected := []string{
+		`ALTER USER "john" SET datestyle TO 'ISO, MDY'`,
+		`ALTER USER "john" RESET query_group`,
+		`ALTER USER "john" SET statement_timeout TO '120000'`,
+	}
+
+	result := userParametersQueries("john", oldParameters, newParameters)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected queries %v, got %v", expected, result)
+	}
+}
+
+func TestParseUserConfig(t *testing.T) {
+	result := parseUserConfig([]string{"statement_timeout=60000", "search_path=$user, public"})
+	expected := map[string]string{
+		"statement_timeout": "60000",
+		"search_path":       "$user, public",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected parameters %v, got %v", expected, result)
+	}
+}
+
+func TestUserSearchPathQuery(t *testing.T) {
+	tests := map[string]struct {
+		schemas  []string
+		expected string
+	}{
+		"empty": {
+			schemas:  []string{},
+			expected: `ALTER USER "john" RESET search_path`,
+		},
+		"user first": {
+			schemas:  []string{"$user", "public", "analytics"},
+			expected: `ALTER USER "john" SET search_path TO '$user', 'public', 'analytics'`,
+		},
+		"public first": {
+			schemas:  []string{"public", "$user"},
+			expected: `ALTER USER "john" SET search_path TO 'public', '$user'`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := userSearchPathQuery("john", tt.schemas); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseUserSearchPath(t *testing.T) {
+	tests := map[string][]string{
+		"":                            {},
+		`"$user", public`:             {"$user", "public"},
+		`public, "$user", analytics`:  {"public", "$user", "analytics"},
+		`"Quoted ""Schema""", public`: {`Quoted "Schema"`, "public"},
+	}
+
+	for searchPath, expected := range tests {
+		if result := parseUserSearchPath(searchPath); !reflect.DeepEqual(result, expected) {
+			t.Errorf("parseUserSearchPath(%q) = %v, expected %v", searchPath, result, expected)
+		}
+	}
+}
+
+func TestValidateUserParameters(t *testing.T) {
+	tests := map[string]struct {
+		parameters map[string]interface{}
+		severity   diag.Severity
+		count      int
+	}{
+		"known": {
+			parameters: map[string]interface{}{"statement_timeout": "60000"},
+			count:      0,
+		},
+		"unknown": {
+			parameters: map[string]interface{}{"not_a_parameter": "1"},
+			severity:   diag.Warning,
+			count:      1,
+		},
+		"invalid": {
+			parameters: map[string]interface{}{"statement timeout": "1"},
+			severity:   diag.Error,
+			count:      1,
+		},
+		"search_path": {
+			parameters: map[string]interface{}{"search_path": "public"},
+			severity:   diag.Error,
+			count:      1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := validateUserParameters(tt.parameters, nil)
+			if len(diags) != tt.count {
+				t.Fatalf("Expected %d diagnostics, got %v", tt.count, diags)
+			}
+			if tt.count > 0 && diags[0].Severity != tt.severity {
+				t.Errorf("Expected severity %v, got %v", tt.severity, diags[0].Severity)
+			}
+		})
+	}
+}
+
 func testAccCheckRedshiftUserCanLogin(user string, password string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// there doesn't seem to be a good way to extract the provider configuration
@@ -507,13 +1624,14 @@ func testAccCheckRedshiftUserCanLogin(user string, password string) resource.Tes
 			sslMode = "require"
 		}
 		config := &Config{
-			Host:     os.Getenv("REDSHIFT_HOST"),
-			Port:     portNum,
-			Username: user,
-			Password: password,
-			Database: database,
-			SSLMode:  sslMode,
-			MaxConns: defaultProviderMaxOpenConnections,
+			Host:        os.Getenv("REDSHIFT_HOST"),
+			Port:        portNum,
+			Username:    user,
+			Password:    password,
+			Database:    database,
+			SSLMode:     sslMode,
+			SSLRootCert: os.Getenv("REDSHIFT_SSLROOTCERT"),
+			MaxConns:    defaultProviderMaxOpenConnections,
 		}
 
 		client, err := config.Client()
@@ -524,3 +1642,26 @@ func testAccCheckRedshiftUserCanLogin(user string, password string) resource.Tes
 		return nil
 	}
 }
+
+func TestUserMembershipsQueries(t *testing.T) {
+	oldNames := schema.NewSet(schema.HashString, []interface{}{"analysts", "etl"})
+	newNames := schema.NewSet(schema.HashString, []interface{}{"etl", "admins", "readers"})
+
+	expected := []string{
+		`ALTER GROUP "analysts" DROP USER "john"`,
+		`ALTER GROUP "admins" ADD USER "john"`,
+		`ALTER GROUP "readers" ADD USER "john"`,
+	}
+	if queries := userMembershipsQueries("john", userInGroupsAttr, oldNames, newNames); !reflect.DeepEqual(queries, expected) {
+		t.Errorf("Expected queries %v, got %v", expected, queries)
+	}
+
+	expected = []string{
+		`REVOKE ROLE "analysts" FROM "john"`,
+		`GRANT ROLE "admins" TO "john"`,
+		`GRANT ROLE "readers" TO "john"`,
+	}
+	if queries := userMembershipsQueries("john", userInRolesAttr, oldNames, newNames); !reflect.DeepEqual(queries, expected) {
+		t.Errorf("Expected queries %v, got %v", expected, queries)
+	}
+}
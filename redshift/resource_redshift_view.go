@@ -0,0 +1,261 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	viewNameAttr                = "name"
+	viewSchemaAttr              = "schema"
+	viewQueryAttr               = "query"
+	viewWithNoSchemaBindingAttr = "with_no_schema_binding"
+	viewDefinitionAttr          = "definition"
+)
+
+var viewNoSchemaBindingRegexp = regexp.MustCompile(`(?i)\s*with\s+no\s+schema\s+binding\s*$`)
+
+func redshiftView() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages a view. Views are created with ` + "`CREATE OR REPLACE VIEW`" + `, so changing the query doesn't drop the view. Late-binding views (` + "`WITH NO SCHEMA BINDING`" + `) don't check the objects they reference, which can be dropped and recreated without dropping the view.
+`,
+		CreateContext: RedshiftResourceFunc(resourceRedshiftViewCreate),
+		ReadContext:   RedshiftResourceFunc(resourceRedshiftViewRead),
+		UpdateContext: RedshiftResourceFunc(resourceRedshiftViewUpdate),
+		DeleteContext: RedshiftResourceFunc(
+			RedshiftResourceRetryOnPQErrors(resourceRedshiftViewDelete),
+		),
+		Exists: RedshiftResourceExistsFunc(resourceRedshiftViewExists),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			viewNameAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of the view.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			viewSchemaAttr: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of the schema the view belongs to.",
+				DiffSuppressFunc: suppressIdentifierCaseDiff,
+			},
+			viewQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The `SELECT` statement defining the view. Differences in whitespace, letter case and a trailing semicolon are ignored.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return viewQueriesEqual(old, new)
+				},
+			},
+			viewWithNoSchemaBindingAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Creates a late-binding view, which isn't bound to the underlying database objects. Every object referenced in `query` must be qualified with its schema name.",
+			},
+			viewDefinitionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The definition of the view as rendered by Redshift with `pg_get_viewdef`. It's used to detect changes of the view made outside of terraform.",
+			},
+		},
+	}
+}
+
+// normalizeViewQuery collapses whitespace and strips the trailing semicolon
+// pg_get_viewdef() adds to the query.
+func normalizeViewQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	return strings.TrimSpace(strings.TrimRight(query, "; "))
+}
+
+func viewQueriesEqual(a, b string) bool {
+	return strings.EqualFold(normalizeViewQuery(a), normalizeViewQuery(b))
+}
+
+func resourceRedshiftViewExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT relname FROM pg_class WHERE oid = $1 AND relkind = 'v'", d.Id()).Scan(&name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftViewRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourceRedshiftViewReadImpl(db, d)
+}
+
+func resourceRedshiftViewReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	var viewName, schemaName string
+
+	err := db.QueryRow(`
+  SELECT cl.relname, nsp.nspname
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE cl.oid = $1 AND cl.relkind = 'v'
+`, d.Id()).Scan(&viewName, &schemaName)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift View (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading View: %w", err)
+	}
+
+	d.Set(viewNameAttr, viewName)
+	d.Set(viewSchemaAttr, schemaName)
+
+	var definition string
+	if err := db.QueryRow("SELECT pg_get_viewdef($1::oid, true)", d.Id()).Scan(&definition); err != nil {
+		// The definition of a late-binding view referencing dropped objects
+		// can't always be rendered. Keep the known state instead of failing the refresh.
+		log.Printf("[WARN] Could not read definition of Redshift View (%s), keeping the current state: %v", d.Id(), err)
+		return nil
+	}
+
+	definition = normalizeViewQuery(definition)
+	withNoSchemaBinding := viewNoSchemaBindingRegexp.MatchString(definition)
+	query := viewNoSchemaBindingRegexp.ReplaceAllString(definition, "")
+
+	// Redshift rewrites the query of regular views, so the configured query is
+	// only replaced when the view was imported or changed outside of terraform.
+	previousDefinition := d.Get(viewDefinitionAttr).(string)
+	configuredQuery := d.Get(viewQueryAttr).(string)
+	if configuredQuery == "" || (previousDefinition != "" && !viewQueriesEqual(previousDefinition, definition) && !viewQueriesEqual(configuredQuery, query)) {
+		d.Set(viewQueryAttr, query)
+	}
+	d.Set(viewWithNoSchemaBindingAttr, withNoSchemaBinding)
+	d.Set(viewDefinitionAttr, definition)
+
+	return nil
+}
+
+func createViewQuery(d *schema.ResourceData) string {
+	query := fmt.Sprintf(
+		"CREATE OR REPLACE VIEW %s.%s AS %s",
+		pq.QuoteIdentifier(d.Get(viewSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(viewNameAttr).(string)),
+		normalizeViewQuery(d.Get(viewQueryAttr).(string)),
+	)
+
+	if d.Get(viewWithNoSchemaBindingAttr).(bool) {
+		query = fmt.Sprintf("%s WITH NO SCHEMA BINDING", query)
+	}
+
+	return query
+}
+
+func resourceRedshiftViewCreate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if _, err := tx.Exec(createViewQuery(d)); err != nil {
+		return fmt.Errorf("Could not create redshift view: %w", createObjectError(err, "redshift_view"))
+	}
+
+	names, err := storedIdentifiers(tx, d.Get(viewSchemaAttr).(string), d.Get(viewNameAttr).(string))
+	if err != nil {
+		return err
+	}
+
+	var viewOID string
+	query := `
+  SELECT cl.oid
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2 AND cl.relkind = 'v'
+`
+	if err := tx.QueryRow(query, names[0], names[1]).Scan(&viewOID); err != nil {
+		return fmt.Errorf("Could not get redshift view oid: %w", err)
+	}
+
+	d.SetId(viewOID)
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftViewReadImpl(db, d)
+}
+
+func resourceRedshiftViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	query := fmt.Sprintf("DROP VIEW %s.%s", pq.QuoteIdentifier(d.Get(viewSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(viewNameAttr).(string)))
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func resourceRedshiftViewUpdate(db *DBConnection, d *schema.ResourceData) error {
+	tx, err := startTransaction(db, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(tx)
+
+	if err := setViewName(tx, d); err != nil {
+		return err
+	}
+
+	if d.HasChanges(viewQueryAttr, viewWithNoSchemaBindingAttr) {
+		if _, err := tx.Exec(createViewQuery(d)); err != nil {
+			return fmt.Errorf("Error updating View definition: %w", err)
+		}
+		// The new definition is recorded by the read below and must not be
+		// compared with the previous one.
+		d.Set(viewDefinitionAttr, "")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftViewReadImpl(db, d)
+}
+
+func setViewName(tx *DBTransaction, d *schema.ResourceData) error {
+	if !d.HasChange(viewNameAttr) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange(viewNameAttr)
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s.%s RENAME TO %s",
+		pq.QuoteIdentifier(d.Get(viewSchemaAttr).(string)),
+		pq.QuoteIdentifier(oldRaw.(string)),
+		pq.QuoteIdentifier(newRaw.(string)),
+	)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating View NAME: %w", err)
+	}
+
+	return nil
+}
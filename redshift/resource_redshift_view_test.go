@@ -0,0 +1,201 @@
+package redshift
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+)
+
+func TestAccRedshiftView_Basic(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_view_schema"), "-", "_")
+	viewName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_view"), "-", "_")
+	viewNameUpdated := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_view_updated"), "-", "_")
+	config := func(name string, query string) string {
+		return fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name = %[1]q
+}
+
+resource "redshift_view" "view" {
+  name   = %[2]q
+  schema = redshift_schema.schema.name
+  query  = <<-EOT
+    %[3]s
+  EOT
+}
+`, schemaName, name, query)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(viewName, "SELECT usename FROM pg_catalog.pg_user"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftViewExists(schemaName, viewName),
+					resource.TestCheckResourceAttr("redshift_view.view", "name", viewName),
+					resource.TestCheckResourceAttr("redshift_view.view", "schema", schemaName),
+					resource.TestCheckResourceAttr("redshift_view.view", "with_no_schema_binding", "false"),
+				),
+			},
+			{
+				Config: config(viewNameUpdated, "SELECT usename, usesysid FROM pg_catalog.pg_user"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftViewExists(schemaName, viewNameUpdated),
+					resource.TestCheckResourceAttr("redshift_view.view", "name", viewNameUpdated),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRedshiftView_LateBinding(t *testing.T) {
+	schemaName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_view_schema"), "-", "_")
+	viewName := strings.ReplaceAll(acctest.RandomWithPrefix("tf_acc_view"), "-", "_")
+	config := fmt.Sprintf(`
+resource "redshift_schema" "schema" {
+  name              = %[1]q
+  cascade_on_delete = true
+}
+
+resource "redshift_view" "view" {
+  name                   = %[2]q
+  schema                 = redshift_schema.schema.name
+  with_no_schema_binding = true
+  query                  = <<-EOT
+    select id,
+           name
+      from %[1]s.source_table;
+  EOT
+}
+`, schemaName, viewName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckRedshiftViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRedshiftViewExists(schemaName, viewName),
+					resource.TestCheckResourceAttr("redshift_view.view", "with_no_schema_binding", "true"),
+				),
+			},
+			// The referenced table doesn't exist, refreshing the view must still succeed.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					dbClient := testAccProvider.Meta().(*Client)
+					conn, err := dbClient.Connect()
+					defer dbClient.Close()
+					if err != nil {
+						t.Fatalf("couldn't start redshift connection: %s", err)
+					}
+					query := fmt.Sprintf("CREATE TABLE %s.source_table (id int, name varchar(32))", pq.QuoteIdentifier(schemaName))
+					if _, err := conn.Exec(query); err != nil {
+						t.Fatalf("couldn't create table: %s", err)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestViewQueriesEqual(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		"whitespace":         {"SELECT id\n  FROM t", "SELECT id FROM t", true},
+		"trailing newline":   {"SELECT id FROM t\n", "SELECT id FROM t", true},
+		"trailing semicolon": {"SELECT id FROM t;", "SELECT id FROM t", true},
+		"letter case":        {"select id from t", "SELECT id FROM t", true},
+		"different columns":  {"SELECT id FROM t", "SELECT id, name FROM t", false},
+		"different table":    {"SELECT id FROM t", "SELECT id FROM u", false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := viewQueriesEqual(tc.a, tc.b); result != tc.expected {
+				t.Errorf("viewQueriesEqual(%q, %q) = %t, expected %t", tc.a, tc.b, result, tc.expected)
+			}
+		})
+	}
+}
+
+func testAccCheckRedshiftViewDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "redshift_view" {
+			continue
+		}
+
+		exists, err := checkViewExists(client, rs.Primary.Attributes[viewSchemaAttr], rs.Primary.Attributes[viewNameAttr])
+
+		if err != nil {
+			return fmt.Errorf("Error checking view %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("View still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckRedshiftViewExists(schemaName, viewName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		exists, err := checkViewExists(client, schemaName, viewName)
+		if err != nil {
+			return fmt.Errorf("Error checking view %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("View not found")
+		}
+
+		return nil
+	}
+}
+
+func checkViewExists(client *Client, schemaName, viewName string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+	var _rez int
+	query := `
+  SELECT 1
+  FROM pg_class cl
+    JOIN pg_namespace nsp ON nsp.oid = cl.relnamespace
+  WHERE nsp.nspname = $1 AND cl.relname = $2 AND cl.relkind = 'v'
+`
+	err = db.QueryRow(query, strings.ToLower(schemaName), strings.ToLower(viewName)).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about view: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,89 @@
+package redshift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secretsManagerSecret is the JSON format of the secrets Redshift and Secrets
+// Manager create for a cluster. The port is a number or a string, depending on
+// what created the secret.
+type secretsManagerSecret struct {
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+	Host     string      `json:"host"`
+	Port     json.Number `json:"port"`
+	DbName   string      `json:"dbname"`
+}
+
+// secretsManagerSecretValue gets the secret with secretsmanager:GetSecretValue,
+// in secret_region or else in the region of the ARN.
+func secretsManagerSecretValue(d *schema.ResourceData, secretARN string) (secretsManagerSecret, error) {
+	cfg, err := awsConfig(d)
+	if err != nil {
+		return secretsManagerSecret{}, err
+	}
+	if region := d.Get("secret_region").(string); region != "" {
+		cfg.Region = region
+	} else if parts := strings.Split(secretARN, ":"); len(parts) > 3 && parts[3] != "" {
+		cfg.Region = parts[3]
+	}
+	if cfg.Region == "" {
+		return secretsManagerSecret{}, fmt.Errorf("secret_region is required when secret_arn isn't an ARN")
+	}
+
+	return getSecretsManagerSecret(context.TODO(), secretsmanager.NewFromConfig(cfg), secretARN)
+}
+
+func getSecretsManagerSecret(ctx context.Context, client *secretsmanager.Client, secretARN string) (secretsManagerSecret, error) {
+	log.Printf("[DEBUG] making GetSecretValue request for %s", secretARN)
+	output, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretARN)})
+	if err != nil {
+		return secretsManagerSecret{}, err
+	}
+	if output.SecretString == nil {
+		return secretsManagerSecret{}, fmt.Errorf("secret %s has no string value", secretARN)
+	}
+
+	// The errors mustn't include the secret value.
+	var secret secretsManagerSecret
+	if err := json.Unmarshal([]byte(*output.SecretString), &secret); err != nil {
+		return secretsManagerSecret{}, fmt.Errorf("secret %s isn't a JSON object with the username and the password", secretARN)
+	}
+	if secret.Password == "" {
+		return secretsManagerSecret{}, fmt.Errorf("secret %s has no password", secretARN)
+	}
+	if secret.Port != "" {
+		if _, err := strconv.Atoi(secret.Port.String()); err != nil {
+			return secretsManagerSecret{}, fmt.Errorf("secret %s has an invalid port", secretARN)
+		}
+	}
+
+	return secret, nil
+}
+
+// apply sets the connection settings found in the secret, leaving the others
+// to the values of the provider arguments.
+func (s secretsManagerSecret) apply(config *Config) {
+	config.Password = s.Password
+	if s.Username != "" {
+		config.Username = s.Username
+	}
+	if s.Host != "" {
+		config.Host = s.Host
+	}
+	if port, err := strconv.Atoi(s.Port.String()); err == nil {
+		config.Port = port
+	}
+	if s.DbName != "" {
+		config.Database = s.DbName
+	}
+}
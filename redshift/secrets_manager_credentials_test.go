@@ -0,0 +1,99 @@
+package redshift
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+func TestGetSecretsManagerSecret(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		body       string
+		expected   string
+	}{
+		"complete": {
+			statusCode: http.StatusOK,
+			body:       `{"Name":"complete","SecretString":"{\"username\":\"admin\",\"password\":\"s3cr3t\",\"host\":\"cluster.example.com\",\"port\":5439,\"dbname\":\"dev\",\"engine\":\"redshift\"}"}`,
+		},
+		"partial": {
+			statusCode: http.StatusOK,
+			body:       `{"SecretString":"{\"password\":\"s3cr3t\",\"port\":\"5441\"}"}`,
+		},
+		"API error": {
+			statusCode: http.StatusBadRequest,
+			body:       `{"__type":"ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`,
+			expected:   "ResourceNotFoundException: Secrets Manager can't find the specified secret.",
+		},
+		"binary secret": {
+			statusCode: http.StatusOK,
+			body:       `{"SecretBinary":"czNjcjN0"}`,
+			expected:   "secret binary secret has no string value",
+		},
+		"not JSON": {
+			statusCode: http.StatusOK,
+			body:       `{"SecretString":"s3cr3t"}`,
+			expected:   "secret not JSON isn't a JSON object with the username and the password",
+		},
+		"no password": {
+			statusCode: http.StatusOK,
+			body:       `{"SecretString":"{\"username\":\"admin\"}"}`,
+			expected:   "secret no password has no password",
+		},
+		"invalid port": {
+			statusCode: http.StatusOK,
+			body:       `{"SecretString":"{\"password\":\"s3cr3t\",\"port\":5439.5}"}`,
+			expected:   "secret invalid port has an invalid port",
+		},
+	}
+	client := secretsmanager.NewFromConfig(testAWSConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "secretsmanager.GetSecretValue" {
+			t.Errorf("Unexpected target %s", target)
+		}
+		var input struct{ SecretId string }
+		json.NewDecoder(r.Body).Decode(&input)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(tests[input.SecretId].statusCode)
+		w.Write([]byte(tests[input.SecretId].body))
+	}))
+
+	secret, err := getSecretsManagerSecret(context.Background(), client, "complete")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	config := Config{Username: "root", Host: "other.example.com", Port: 5440, Database: "redshift"}
+	secret.apply(&config)
+	if config.Username != "admin" || config.Password != "s3cr3t" || config.Host != "cluster.example.com" || config.Port != 5439 || config.Database != "dev" {
+		t.Errorf("Unexpected configuration for user %s, host %s, port %d and database %s", config.Username, config.Host, config.Port, config.Database)
+	}
+
+	// The arguments of the provider are used for the keys the secret doesn't have.
+	secret, err = getSecretsManagerSecret(context.Background(), client, "partial")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	config = Config{Username: "root", Host: "cluster.example.com", Port: 5439, Database: "redshift"}
+	secret.apply(&config)
+	if config.Username != "root" || config.Password != "s3cr3t" || config.Host != "cluster.example.com" || config.Port != 5441 || config.Database != "redshift" {
+		t.Errorf("Unexpected configuration for user %s, host %s, port %d and database %s", config.Username, config.Host, config.Port, config.Database)
+	}
+
+	for name, tc := range tests {
+		if tc.expected == "" {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			_, err := getSecretsManagerSecret(context.Background(), client, name)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error %q, got %v", tc.expected, err)
+			}
+			if err != nil && strings.Contains(err.Error(), "s3cr3t") {
+				t.Errorf("The error contains the secret: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftserverless"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverlessTemporaryCredentials gets temporary credentials of a Redshift
+// Serverless workgroup using redshift-serverless:GetCredentials.
+func serverlessTemporaryCredentials(d *schema.ResourceData) (string, string, time.Time, error) {
+	cfg, err := awsConfig(d)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if cfg.Region == "" {
+		return "", "", time.Time{}, fmt.Errorf("region is required to obtain Redshift Serverless credentials")
+	}
+
+	input := &redshiftserverless.GetCredentialsInput{
+		WorkgroupName: aws.String(d.Get("temporary_credentials.0.workgroup_name").(string)),
+		DbName:        aws.String(d.Get("database").(string)),
+	}
+	if durationSeconds, ok := d.GetOk("temporary_credentials.0.duration_seconds"); ok {
+		input.DurationSeconds = aws.Int32(int32(durationSeconds.(int)))
+	}
+
+	return getServerlessCredentials(context.TODO(), redshiftserverless.NewFromConfig(cfg), input)
+}
+
+func getServerlessCredentials(ctx context.Context, client *redshiftserverless.Client, input *redshiftserverless.GetCredentialsInput) (string, string, time.Time, error) {
+	log.Println("[DEBUG] making GetCredentials request")
+	response, err := client.GetCredentials(ctx, input)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	return aws.ToString(response.DbUser), aws.ToString(response.DbPassword), aws.ToTime(response.Expiration), nil
+}
@@ -0,0 +1,55 @@
+package redshift
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftserverless"
+)
+
+func TestGetServerlessCredentials(t *testing.T) {
+	var request map[string]interface{}
+	cfg := testAWSConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "RedshiftServerless.GetCredentials" {
+			t.Errorf("Unexpected target %s", target)
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request["workgroupName"] == "missing" {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Workgroup not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.Write([]byte(`{"dbUser":"IAMR:admin","dbPassword":"secret","expiration":1.7e9,"nextRefreshTime":1.7e9}`))
+	})
+	client := redshiftserverless.NewFromConfig(cfg)
+
+	user, password, expiration, err := getServerlessCredentials(context.Background(), client, &redshiftserverless.GetCredentialsInput{
+		WorkgroupName:   aws.String("analytics"),
+		DbName:          aws.String("dev"),
+		DurationSeconds: aws.Int32(900),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if request["workgroupName"] != "analytics" || request["dbName"] != "dev" || request["durationSeconds"] != float64(900) {
+		t.Errorf("Unexpected request %v", request)
+	}
+	if user != "IAMR:admin" || password != "secret" {
+		t.Errorf("Unexpected credentials for user %s", user)
+	}
+	if !expiration.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected expiration %s, got %s", time.Unix(1700000000, 0), expiration)
+	}
+
+	_, _, _, err = getServerlessCredentials(context.Background(), client, &redshiftserverless.GetCredentialsInput{WorkgroupName: aws.String("missing")})
+	if err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException: Workgroup not found") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
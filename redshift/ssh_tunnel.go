@@ -0,0 +1,144 @@
+package redshift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const sshTunnelConnectTimeout = 30 * time.Second
+
+// sshTunnel forwards the connections to Redshift through a bastion host. A
+// single SSH connection to the bastion is shared by all the connection pools,
+// every Redshift connection being a channel of it.
+type sshTunnel struct {
+	address string
+	config  *ssh.ClientConfig
+
+	mutex  sync.Mutex
+	client *ssh.Client
+}
+
+// newSSHTunnel configures a tunnel through the bastion host, authenticating
+// with a PEM encoded private key. The bastion must present hostKey, in the
+// authorized_keys format, which can only be empty when ignoreHostKey is set.
+func newSSHTunnel(host string, port int, user, privateKey, hostKey string, ignoreHostKey bool) (*sshTunnel, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			return nil, fmt.Errorf("bastion_private_key must not be protected by a passphrase")
+		}
+		return nil, fmt.Errorf("could not parse bastion_private_key: %w", err)
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case hostKey != "":
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse bastion_host_key: %w", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(publicKey)
+	case ignoreHostKey:
+		log.Printf("[WARN] bastion_insecure_ignore_host_key is set, the host key of bastion host %s won't be verified", host)
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	default:
+		return nil, fmt.Errorf("bastion_host_key is required to verify the identity of bastion host %s, unless bastion_insecure_ignore_host_key is set", host)
+	}
+
+	return &sshTunnel{
+		address: net.JoinHostPort(host, strconv.Itoa(port)),
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         sshTunnelConnectTimeout,
+		},
+	}, nil
+}
+
+// connect returns the SSH connection to the bastion, opening it when needed.
+func (t *sshTunnel) connect() (*ssh.Client, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	log.Printf("[DEBUG] opening SSH tunnel through bastion host %s", t.address)
+	client, err := ssh.Dial("tcp", t.address, t.config)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to bastion host %s: %w", t.address, err)
+	}
+	t.client = client
+
+	return client, nil
+}
+
+// reset closes the lost SSH connection to the bastion unless it was already
+// replaced, so that the next dial opens a new one.
+func (t *sshTunnel) reset(client *ssh.Client) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.client == client {
+		t.client.Close()
+		t.client = nil
+	}
+}
+
+// DialContext opens a connection to address through the bastion. The SSH
+// connection is opened again once if it was lost, e.g. when the bastion
+// restarted.
+func (t *sshTunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var client *ssh.Client
+		client, err = t.connect()
+		if err != nil {
+			return nil, err
+		}
+
+		var conn net.Conn
+		conn, err = client.DialContext(ctx, network, address)
+		if err == nil {
+			return conn, nil
+		}
+		// The bastion refusing to forward the connection means the SSH
+		// connection still works, and resetting it would break the other
+		// connections forwarded through it.
+		var openChannelErr *ssh.OpenChannelError
+		if ctx.Err() != nil || errors.As(err, &openChannelErr) {
+			break
+		}
+		t.reset(client)
+	}
+
+	return nil, fmt.Errorf("could not reach %s through bastion host %s: %w", address, t.address, err)
+}
+
+// Close closes the SSH connection to the bastion, and with it all the
+// connections forwarded through it.
+func (t *sshTunnel) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.client == nil {
+		return nil
+	}
+
+	log.Printf("[DEBUG] closing SSH tunnel through bastion host %s", t.address)
+	err := t.client.Close()
+	t.client = nil
+
+	return err
+}
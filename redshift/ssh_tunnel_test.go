@@ -0,0 +1,233 @@
+package redshift
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestBastion starts an SSH server accepting the public key of
+// clientSigner and forwarding direct-tcpip channels. It returns its address
+// and host key.
+func startTestBastion(t *testing.T, clientSigner ssh.Signer) (string, ssh.PublicKey) {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate host key: %s", err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	if err != nil {
+		t.Fatalf("couldn't create host signer: %s", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientSigner.PublicKey().Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("couldn't listen: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestBastionConn(conn, config)
+		}
+	}()
+
+	return listener.Addr().String(), hostSigner.PublicKey()
+}
+
+func serveTestBastionConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		// The payload starts with the length prefixed host and the port to forward to.
+		payload := newChannel.ExtraData()
+		hostLength := binary.BigEndian.Uint32(payload)
+		host := string(payload[4 : 4+hostLength])
+		port := binary.BigEndian.Uint32(payload[4+hostLength:])
+
+		target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelRequests)
+		go func() {
+			defer channel.Close()
+			defer target.Close()
+			go io.Copy(target, channel)
+			io.Copy(channel, target)
+		}()
+	}
+}
+
+func TestSSHTunnelForwardsConnections(t *testing.T) {
+	_, clientPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate client key: %s", err)
+	}
+	clientSigner, err := ssh.NewSignerFromKey(clientPrivateKey)
+	if err != nil {
+		t.Fatalf("couldn't create client signer: %s", err)
+	}
+	privateKeyBlock, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	if err != nil {
+		t.Fatalf("couldn't marshal client key: %s", err)
+	}
+
+	bastion, hostKey := startTestBastion(t, clientSigner)
+	bastionHost, bastionPortRaw, _ := net.SplitHostPort(bastion)
+	bastionPort, _ := strconv.Atoi(bastionPortRaw)
+
+	// A server standing in for Redshift, echoing what it receives.
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("couldn't listen: %s", err)
+	}
+	defer target.Close()
+	go func() {
+		for {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	tunnel, err := newSSHTunnel(bastionHost, bastionPort, "terraform", string(pem.EncodeToMemory(privateKeyBlock)), string(ssh.MarshalAuthorizedKey(hostKey)), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer tunnel.Close()
+
+	conn, err := tunnel.DialContext(context.Background(), "tcp", target.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	reply := make([]byte, 4)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if string(reply) != "ping" {
+		t.Errorf("Expected the reply to be ping, got %q", reply)
+	}
+	conn.Close()
+
+	// Connections are reported with the bastion they couldn't go through,
+	// without closing the SSH connection.
+	client := tunnel.client
+	_, err = tunnel.DialContext(context.Background(), "tcp", "127.0.0.1:1")
+	if err == nil || !strings.Contains(err.Error(), "through bastion host "+bastion) {
+		t.Errorf("Expected the error to name the bastion host, got %v", err)
+	}
+	if tunnel.client != client {
+		t.Errorf("Expected the SSH connection to be kept when the bastion refuses to forward a connection")
+	}
+
+	// A bastion presenting another host key is rejected.
+	_, otherHostKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherSigner, _ := ssh.NewSignerFromKey(otherHostKey)
+	untrusted, err := newSSHTunnel(bastionHost, bastionPort, "terraform", string(pem.EncodeToMemory(privateKeyBlock)), string(ssh.MarshalAuthorizedKey(otherSigner.PublicKey())), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := untrusted.DialContext(context.Background(), "tcp", target.Addr().String()); err == nil || !strings.Contains(err.Error(), "could not connect to bastion host") {
+		t.Errorf("Expected the host key mismatch to be reported, got %v", err)
+	}
+
+	// Unless verifying the host key was explicitly turned off.
+	insecure, err := newSSHTunnel(bastionHost, bastionPort, "terraform", string(pem.EncodeToMemory(privateKeyBlock)), "", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer insecure.Close()
+	conn, err = insecure.DialContext(context.Background(), "tcp", target.Addr().String())
+	if err != nil {
+		t.Fatalf("Expected the connection to go through the bastion, got %v", err)
+	}
+	conn.Close()
+}
+
+func TestNewSSHTunnelInvalidKeys(t *testing.T) {
+	_, clientPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	encryptedBlock, err := ssh.MarshalPrivateKeyWithPassphrase(clientPrivateKey, "", []byte("secret"))
+	if err != nil {
+		t.Fatalf("couldn't marshal client key: %s", err)
+	}
+	plainBlock, _ := ssh.MarshalPrivateKey(clientPrivateKey, "")
+
+	tests := map[string]struct {
+		privateKey string
+		hostKey    string
+		expected   string
+	}{
+		"invalid private key": {
+			privateKey: "not a key",
+			expected:   "could not parse bastion_private_key",
+		},
+		"passphrase": {
+			privateKey: string(pem.EncodeToMemory(encryptedBlock)),
+			expected:   "must not be protected by a passphrase",
+		},
+		"invalid host key": {
+			privateKey: string(pem.EncodeToMemory(plainBlock)),
+			hostKey:    "not a key",
+			expected:   "could not parse bastion_host_key",
+		},
+		"missing host key": {
+			privateKey: string(pem.EncodeToMemory(plainBlock)),
+			expected:   "bastion_host_key is required",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newSSHTunnel("bastion", 22, "terraform", tt.privateKey, tt.hostKey, false)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
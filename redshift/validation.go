@@ -179,4 +179,6 @@ var dbGroupValidate = validation.All(
 )
 
 var awsAccountIdRegexp = regexp.MustCompile(`^\d{12}$`)
+var iamRoleArnRegexp = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::\d{12}:role/.+$`)
+var iamRoleArnValidate = validation.StringMatch(iamRoleArnRegexp, "IAM role ARN must be in the format arn:aws:iam::<account id>:role/<role name>")
 var uuidRegex = regexp.MustCompile("^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}$")